@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -10,6 +11,8 @@ import (
 )
 
 func main() {
+	ctx := context.Background()
+
 	// 从环境变量获取配置
 	host := os.Getenv("CLOB_HOST")
 	if host == "" {
@@ -76,11 +79,11 @@ func main() {
 		// 尝试派生已存在的API密钥（使用nonce=0）
 		nonce := 0
 		var err error
-		creds, err = client.DeriveAPIKey(&nonce)
+		creds, err = client.DeriveAPIKey(ctx, &nonce)
 		if err != nil {
 			// 如果派生失败，尝试创建新的
 			fmt.Println("派生失败，尝试创建新的API密钥...")
-			creds, err = client.CreateAPIKey(&nonce)
+			creds, err = client.CreateAPIKey(ctx, &nonce)
 			if err != nil {
 				log.Fatalf("创建API密钥失败: %v", err)
 			}
@@ -106,7 +109,7 @@ func main() {
 		TokenID:   "", // 空字符串表示查询所有代币
 	}
 
-	collateralBalance, err := client.GetBalanceAllowance(collateralParams)
+	collateralBalance, err := client.GetBalanceAllowance(ctx, collateralParams)
 	if err != nil {
 		log.Fatalf("查询抵押品余额失败: %v", err)
 	}
@@ -127,7 +130,7 @@ func main() {
 			TokenID:   tokenID,
 		}
 
-		conditionalBalance, err := client.GetBalanceAllowance(conditionalParams)
+		conditionalBalance, err := client.GetBalanceAllowance(ctx, conditionalParams)
 		if err != nil {
 			log.Printf("查询条件代币余额失败: %v", err)
 		} else {
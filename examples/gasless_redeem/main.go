@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -13,6 +14,8 @@ import (
 )
 
 func main() {
+	ctx := context.Background()
+
 	// 从环境变量读取配置
 	privateKey := os.Getenv("PRIVATE_KEY")
 	if privateKey == "" {
@@ -103,7 +106,7 @@ func main() {
 
 	// 查询当前余额
 	fmt.Println("\n=== 当前余额 ===")
-	polBalance, err := client.GetPOLBalance()
+	polBalance, err := client.GetPOLBalance(ctx)
 	if err != nil {
 		log.Printf("获取 POL 余额失败: %v", err)
 	} else {
@@ -111,7 +114,7 @@ func main() {
 		fmt.Printf("POL Balance: %.4f\n", polFloat)
 	}
 
-	usdcBalanceBefore, err := client.GetUSDCBalance(common.Address{})
+	usdcBalanceBefore, err := client.GetUSDCBalance(ctx, common.Address{})
 	if err != nil {
 		log.Printf("获取 USDC 余额失败: %v", err)
 	} else {
@@ -124,7 +127,7 @@ func main() {
 	fmt.Printf("正在赎回 %.6f Yes 和 %.6f No 代币...\n", amounts[0], amounts[1])
 	fmt.Println("(通过无 Gas 中继器提交交易)")
 
-	receipt, err := client.RedeemPosition(common.HexToHash(conditionID), amounts, negRisk)
+	receipt, err := client.RedeemPosition(ctx, common.HexToHash(conditionID), amounts, negRisk)
 	if err != nil {
 		log.Fatalf("赎回失败: %v", err)
 	}
@@ -141,7 +144,7 @@ func main() {
 
 	// 查询赎回后的余额
 	fmt.Println("\n=== 赎回后余额 ===")
-	usdcBalanceAfter, err := client.GetUSDCBalance(common.Address{})
+	usdcBalanceAfter, err := client.GetUSDCBalance(ctx, common.Address{})
 	if err != nil {
 		log.Printf("获取 USDC 余额失败: %v", err)
 	} else {
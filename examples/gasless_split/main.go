@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -12,6 +13,8 @@ import (
 )
 
 func main() {
+	ctx := context.Background()
+
 	// 从环境变量读取配置
 	privateKey := os.Getenv("PRIVATE_KEY")
 	if privateKey == "" {
@@ -102,7 +105,7 @@ func main() {
 
 	// 查询当前余额
 	fmt.Println("\n=== 当前余额 ===")
-	polBalance, err := client.GetPOLBalance()
+	polBalance, err := client.GetPOLBalance(ctx)
 	if err != nil {
 		log.Printf("获取 POL 余额失败: %v", err)
 	} else {
@@ -110,7 +113,7 @@ func main() {
 		fmt.Printf("POL Balance: %.4f\n", polFloat)
 	}
 
-	usdcBalance, err := client.GetUSDCBalance(common.Address{})
+	usdcBalance, err := client.GetUSDCBalance(ctx, common.Address{})
 	if err != nil {
 		log.Printf("获取 USDC 余额失败: %v", err)
 	} else {
@@ -129,7 +132,7 @@ func main() {
 	fmt.Printf("正在将 %.6f USDC 拆分为头寸...\n", amount)
 	fmt.Println("(通过无 Gas 中继器提交交易)")
 
-	receipt, err := client.SplitPosition(common.HexToHash(conditionID), amount, negRisk)
+	receipt, err := client.SplitPosition(ctx, common.HexToHash(conditionID), amount, negRisk)
 	if err != nil {
 		log.Fatalf("拆分失败: %v", err)
 	}
@@ -146,7 +149,7 @@ func main() {
 
 	// 查询拆分后的余额
 	fmt.Println("\n=== 拆分后余额 ===")
-	usdcBalanceAfter, err := client.GetUSDCBalance(common.Address{})
+	usdcBalanceAfter, err := client.GetUSDCBalance(ctx, common.Address{})
 	if err != nil {
 		log.Printf("获取 USDC 余额失败: %v", err)
 	} else {
@@ -1,15 +1,19 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/0xNetuser/Polymarket-golang/polymarket"
 )
 
 func main() {
+	ctx := context.Background()
+
 	// 从环境变量获取配置
 	host := os.Getenv("CLOB_HOST")
 	if host == "" {
@@ -72,10 +76,10 @@ func main() {
 	} else {
 		fmt.Println("未找到API凭证，正在创建或派生...")
 		nonce := 0
-		creds, err := client.DeriveAPIKey(&nonce)
+		creds, err := client.DeriveAPIKey(ctx, &nonce)
 		if err != nil {
 			fmt.Println("派生失败，尝试创建新的API密钥...")
-			creds, err = client.CreateAPIKey(&nonce)
+			creds, err = client.CreateAPIKey(ctx, &nonce)
 			if err != nil {
 				log.Fatalf("创建API密钥失败: %v", err)
 			}
@@ -91,7 +95,7 @@ func main() {
 
 	// 获取所有订单（不带过滤条件）
 	fmt.Println("\n=== 获取所有订单 ===")
-	orders, err := client.GetOrders(nil, "")
+	orders, err := client.GetOrders(ctx, nil, "")
 	if err != nil {
 		log.Fatalf("获取订单失败: %v", err)
 	}
@@ -119,7 +123,7 @@ func main() {
 		}
 		fmt.Printf("过滤条件: ID=%s, Market=%s, AssetID=%s\n", orderID, market, assetID)
 
-		filteredOrders, err := client.GetOrders(params, "")
+		filteredOrders, err := client.GetOrders(ctx, params, "")
 		if err != nil {
 			log.Fatalf("获取过滤订单失败: %v", err)
 		}
@@ -140,7 +144,7 @@ func main() {
 		fmt.Println("\n=== 获取单个订单详情 ===")
 		fmt.Printf("订单ID: %s\n", singleOrderID)
 
-		order, err := client.GetOrder(singleOrderID)
+		order, err := client.GetOrder(ctx, singleOrderID)
 		if err != nil {
 			log.Fatalf("获取订单详情失败: %v", err)
 		}
@@ -153,52 +157,28 @@ func main() {
 }
 
 // printOrder 打印订单信息
-func printOrder(index int, order interface{}) {
-	orderMap, ok := order.(map[string]interface{})
-	if !ok {
-		fmt.Printf("订单 %d: %+v\n", index, order)
-		return
-	}
-
+func printOrder(index int, order polymarket.OpenOrder) {
 	fmt.Printf("--- 订单 %d ---\n", index)
-	
+
 	// 打印关键字段
-	if id, ok := orderMap["id"].(string); ok {
-		fmt.Printf("  ID: %s\n", id)
-	}
-	if status, ok := orderMap["status"].(string); ok {
-		fmt.Printf("  状态: %s\n", status)
-	}
-	if side, ok := orderMap["side"].(string); ok {
-		fmt.Printf("  方向: %s\n", side)
-	}
-	if price, ok := orderMap["price"].(string); ok {
-		fmt.Printf("  价格: %s\n", price)
-	}
-	if originalSize, ok := orderMap["original_size"].(string); ok {
-		fmt.Printf("  原始数量: %s\n", originalSize)
-	}
-	if sizeMatched, ok := orderMap["size_matched"].(string); ok {
-		fmt.Printf("  已成交数量: %s\n", sizeMatched)
-	}
-	if assetID, ok := orderMap["asset_id"].(string); ok {
-		// 只显示前20个字符
-		if len(assetID) > 20 {
-			fmt.Printf("  资产ID: %s...\n", assetID[:20])
-		} else {
-			fmt.Printf("  资产ID: %s\n", assetID)
-		}
-	}
-	if market, ok := orderMap["market"].(string); ok {
-		fmt.Printf("  市场: %s\n", market)
-	}
-	if orderType, ok := orderMap["type"].(string); ok {
-		fmt.Printf("  类型: %s\n", orderType)
+	fmt.Printf("  ID: %s\n", order.ID)
+	fmt.Printf("  状态: %s\n", order.Status)
+	fmt.Printf("  方向: %s\n", order.Side)
+	fmt.Printf("  价格: %s\n", order.Price)
+	fmt.Printf("  原始数量: %s\n", order.OriginalSize)
+	fmt.Printf("  已成交数量: %s\n", order.SizeMatched)
+	// 只显示前20个字符
+	if len(order.AssetID) > 20 {
+		fmt.Printf("  资产ID: %s...\n", order.AssetID[:20])
+	} else {
+		fmt.Printf("  资产ID: %s\n", order.AssetID)
 	}
-	if createdAt, ok := orderMap["created_at"].(string); ok {
-		fmt.Printf("  创建时间: %s\n", createdAt)
+	fmt.Printf("  市场: %s\n", order.Market)
+	fmt.Printf("  类型: %s\n", order.OrderType)
+	if !order.CreatedAt.IsZero() {
+		fmt.Printf("  创建时间: %s\n", order.CreatedAt.Format(time.RFC3339))
 	}
-	
+
 	fmt.Println()
 }
 
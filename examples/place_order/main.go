@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -12,6 +13,8 @@ import (
 )
 
 func main() {
+	ctx := context.Background()
+
 	// 从环境变量获取配置
 	host := os.Getenv("CLOB_HOST")
 	if host == "" {
@@ -76,10 +79,10 @@ func main() {
 		fmt.Println("未找到API凭证，正在创建或派生...")
 		nonce := 0
 		var err error
-		creds, err = client.DeriveAPIKey(&nonce)
+		creds, err = client.DeriveAPIKey(ctx, &nonce)
 		if err != nil {
 			fmt.Println("派生失败，尝试创建新的API密钥...")
-			creds, err = client.CreateAPIKey(&nonce)
+			creds, err = client.CreateAPIKey(ctx, &nonce)
 			if err != nil {
 				log.Fatalf("创建API密钥失败: %v", err)
 			}
@@ -163,7 +166,7 @@ func main() {
 
 		// 创建并签名订单
 		fmt.Println("正在创建并签名订单...")
-		signedOrder, err := client.CreateMarketOrder(marketOrderArgs, nil)
+		signedOrder, err := client.CreateMarketOrder(ctx, marketOrderArgs, nil)
 		if err != nil {
 			log.Fatalf("创建市价订单失败: %v", err)
 		}
@@ -173,7 +176,7 @@ func main() {
 
 		// 提交订单
 		fmt.Println("\n正在提交订单到交易所...")
-		result, err := client.PostOrder(signedOrder, marketOrderType)
+		result, err := client.PostOrder(ctx, signedOrder, marketOrderType)
 		if err != nil {
 			log.Fatalf("提交订单失败: %v", err)
 		}
@@ -267,7 +270,7 @@ func main() {
 		useConvenienceMethod := os.Getenv("USE_CONVENIENCE") != "false"
 		if useConvenienceMethod {
 			fmt.Println("使用便捷方法 (CreateAndPostOrder)...")
-			result, err := client.CreateAndPostOrder(orderArgs, nil)
+			result, err := client.CreateAndPostOrder(ctx, orderArgs, nil)
 			if err != nil {
 				log.Fatalf("创建并提交订单失败: %v", err)
 			}
@@ -276,7 +279,7 @@ func main() {
 		} else {
 			// 方式2: 分步操作（先创建，再提交）
 			fmt.Println("正在创建并签名订单...")
-			signedOrder, err := client.CreateOrder(orderArgs, nil)
+			signedOrder, err := client.CreateOrder(ctx, orderArgs, nil)
 			if err != nil {
 				log.Fatalf("创建订单失败: %v", err)
 			}
@@ -287,7 +290,7 @@ func main() {
 			// 提交订单
 			fmt.Println("\n正在提交订单到交易所...")
 			postOrderType := polymarket.OrderType(limitOrderType)
-			result, err := client.PostOrder(signedOrder, postOrderType)
+			result, err := client.PostOrder(ctx, signedOrder, postOrderType)
 			if err != nil {
 				log.Fatalf("提交订单失败: %v", err)
 			}
@@ -0,0 +1,117 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrAPIKeyRotationRolledBack 新密钥验证失败，客户端已恢复使用旧密钥
+var ErrAPIKeyRotationRolledBack = errors.New("api key rotation rolled back")
+
+// DefaultAPIKeyRotationGracePeriod 删除旧密钥前默认的等待时间
+const DefaultAPIKeyRotationGracePeriod = 5 * time.Second
+
+// apiKeyRollbackTimeout 回滚时删除新密钥的超时时间
+// 回滚不受调用方 ctx 取消的影响（ctx 取消正是验证失败最常见的原因），避免在服务器上留下孤立的密钥
+const apiKeyRollbackTimeout = 10 * time.Second
+
+// RotateAPIKeyOptions API密钥轮换选项
+type RotateAPIKeyOptions struct {
+	// Verify 切换后使用新凭证执行的认证请求，默认调用 GetAPIKeys
+	Verify func(ctx context.Context) error
+	// GracePeriod 删除旧密钥前等待的时间，让使用旧凭证签名、仍在进行中的请求完成；
+	// 为0时使用 DefaultAPIKeyRotationGracePeriod，为负数时不等待
+	GracePeriod time.Duration
+	// KeepOldKey 为 true 时不删除旧密钥
+	KeepOldKey bool
+	// OldNonce 旧密钥在凭证存储中使用的 nonce，默认0（与 CreateOrDeriveAPIKey(ctx, nil) 一致）；
+	// 旧密钥删除后，如果该位置保存的仍是旧密钥则从存储中删除
+	OldNonce *int
+}
+
+// RotateAPIKey 轮换API密钥
+// 流程：用 nonce 创建新密钥 -> 原子地切换客户端凭证 -> 用新凭证执行认证请求验证 -> 等待 GracePeriod -> 删除旧密钥。
+// nonce 必须与当前密钥的 nonce 不同（同一个 nonce 只能创建一个密钥）。
+//
+// 验证失败时恢复旧凭证、尽量删除新密钥（即使 ctx 已取消，最多等待10秒），并返回匹配 ErrAPIKeyRotationRolledBack 的错误；
+// 如果验证期间凭证已被其他调用修改，则不会覆盖。
+// 删除旧密钥失败时新凭证已经生效，返回新凭证和错误。
+//
+// 设置了凭证存储时新凭证按 nonce 写入存储，旧密钥删除成功后从存储中移除 OldNonce 对应的旧凭证。
+// 轮换后（包括重启后）调用方需要用新的 nonce 读取凭证，例如 CreateOrDeriveAPIKey(ctx, &nonce)；
+// 继续使用旧 nonce 会重新创建或派生该 nonce 的密钥，而不是使用轮换后的密钥。
+// 需要L2认证
+func (c *ClobClient) RotateAPIKey(ctx context.Context, nonce int, opts *RotateAPIKeyOptions) (*ApiCreds, error) {
+	if err := c.assertLevel2Auth(); err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &RotateAPIKeyOptions{}
+	}
+	verify := opts.Verify
+	if verify == nil {
+		verify = func(ctx context.Context) error {
+			_, err := c.GetAPIKeys(ctx)
+			return err
+		}
+	}
+	grace := opts.GracePeriod
+	if grace == 0 {
+		grace = DefaultAPIKeyRotationGracePeriod
+	}
+
+	oldCreds := c.apiCreds()
+	newCreds, err := c.createAPIKey(ctx, &nonce)
+	if err != nil {
+		return nil, fmt.Errorf("create new API key: %w", err)
+	}
+	if newCreds.APIKey == "" || newCreds.APIKey == oldCreds.APIKey {
+		return nil, fmt.Errorf("create new API key: server returned key %q (nonce %d already used?)", newCreds.APIKey, nonce)
+	}
+
+	if !c.swapAPICreds(oldCreds, newCreds) {
+		_ = c.rollbackAPIKey(ctx, newCreds) // 尽力清理
+		return nil, fmt.Errorf("%w: credentials were changed during rotation", ErrAPIKeyRotationRolledBack)
+	}
+
+	if err := verify(ctx); err != nil {
+		rollbackErr := fmt.Errorf("%w: verify new API key: %v", ErrAPIKeyRotationRolledBack, err)
+		if !c.swapAPICreds(newCreds, oldCreds) {
+			return nil, fmt.Errorf("%w (credentials changed concurrently, not restored)", rollbackErr)
+		}
+		if delErr := c.rollbackAPIKey(ctx, newCreds); delErr != nil {
+			return nil, fmt.Errorf("%w (failed to delete new key %s: %v)", rollbackErr, newCreds.APIKey, delErr)
+		}
+		return nil, rollbackErr
+	}
+
+	if err := c.storeCreds(ctx, &nonce, newCreds); err != nil {
+		return newCreds, err
+	}
+
+	if opts.KeepOldKey {
+		return newCreds, nil
+	}
+	if grace > 0 {
+		if err := sleepContext(ctx, grace); err != nil {
+			return newCreds, fmt.Errorf("old API key %s not deleted: %w", oldCreds.APIKey, err)
+		}
+	}
+	if _, err := c.deleteAPIKey(ctx, oldCreds); err != nil {
+		return newCreds, fmt.Errorf("old API key %s not deleted: %w", oldCreds.APIKey, err)
+	}
+	if err := c.forgetCreds(ctx, opts.OldNonce, oldCreds); err != nil {
+		return newCreds, fmt.Errorf("old API key %s deleted: %w", oldCreds.APIKey, err)
+	}
+	return newCreds, nil
+}
+
+// rollbackAPIKey 删除轮换中创建的新密钥，不受 ctx 取消的影响
+func (c *ClobClient) rollbackAPIKey(ctx context.Context, creds *ApiCreds) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), apiKeyRollbackTimeout)
+	defer cancel()
+	_, err := c.deleteAPIKey(ctx, creds)
+	return err
+}
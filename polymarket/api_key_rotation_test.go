@@ -0,0 +1,154 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// authServerStub 模拟 CLOB 的 API 密钥端点，记录被删除的密钥
+type authServerStub struct {
+	newCreds ApiCreds
+
+	mu      sync.Mutex
+	deleted []string
+}
+
+func (s *authServerStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == CreateAPIKey:
+		w.Write([]byte(`{"apiKey":"` + s.newCreds.APIKey + `","secret":"` + s.newCreds.APISecret + `","passphrase":"` + s.newCreds.APIPassphrase + `"}`))
+	case r.Method == http.MethodGet && r.URL.Path == GetAPIKeys:
+		w.Write([]byte(`{"apiKeys":["` + r.Header.Get(PolyAPIKey) + `"]}`))
+	case r.Method == http.MethodDelete && r.URL.Path == DeleteAPIKey:
+		s.mu.Lock()
+		s.deleted = append(s.deleted, r.Header.Get(PolyAPIKey))
+		s.mu.Unlock()
+		w.Write([]byte(`"OK"`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+var (
+	testOldCreds = ApiCreds{APIKey: "old-key", APISecret: "b2xkLXNlY3JldA==", APIPassphrase: "old-pass"}
+	testNewCreds = ApiCreds{APIKey: "new-key", APISecret: "bmV3LXNlY3JldA==", APIPassphrase: "new-pass"}
+)
+
+func newRotationTestClient(t *testing.T, store CredentialStore) (*ClobClient, *authServerStub) {
+	t.Helper()
+	stub := &authServerStub{newCreds: testNewCreds}
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+	old := testOldCreds
+	client, err := NewClobClientWithOptions(server.URL, 137, testPrivateKey, WithAPICreds(&old), WithCredentialStore(store))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, stub
+}
+
+func TestRotateAPIKeyUpdatesCredentialStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryCredentialStore()
+	client, stub := newRotationTestClient(t, store)
+	address := client.signer.Address()
+	old := testOldCreds
+	if err := store.Save(ctx, address, 0, &old); err != nil {
+		t.Fatal(err)
+	}
+
+	creds, err := client.RotateAPIKey(ctx, 1, &RotateAPIKeyOptions{GracePeriod: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *creds != testNewCreds || *client.GetCreds() != testNewCreds {
+		t.Errorf("creds = %+v, client creds = %+v", creds, client.GetCreds())
+	}
+	if len(stub.deleted) != 1 || stub.deleted[0] != testOldCreds.APIKey {
+		t.Errorf("deleted keys = %v, want [%s]", stub.deleted, testOldCreds.APIKey)
+	}
+	if _, err := store.Load(ctx, address, 0); !errors.Is(err, ErrCredentialsNotFound) {
+		t.Errorf("Load(nonce 0) = %v, want ErrCredentialsNotFound", err)
+	}
+	if stored, err := store.Load(ctx, address, 1); err != nil || *stored != testNewCreds {
+		t.Errorf("Load(nonce 1) = %+v, %v", stored, err)
+	}
+
+	// 重启后用新的 nonce 读取轮换后的凭证
+	restarted, _ := newRotationTestClient(t, store)
+	nonce := 1
+	loaded, err := restarted.CreateOrDeriveAPIKey(ctx, &nonce)
+	if err != nil || *loaded != testNewCreds {
+		t.Errorf("CreateOrDeriveAPIKey(nonce 1) = %+v, %v", loaded, err)
+	}
+}
+
+func TestRotateAPIKeyOldNonce(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryCredentialStore()
+	client, _ := newRotationTestClient(t, store)
+	address := client.signer.Address()
+	old, other := testOldCreds, ApiCreds{APIKey: "other-key"}
+	store.Save(ctx, address, 0, &other)
+	store.Save(ctx, address, 3, &old)
+
+	oldNonce := 3
+	if _, err := client.RotateAPIKey(ctx, 4, &RotateAPIKeyOptions{GracePeriod: -1, OldNonce: &oldNonce}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Load(ctx, address, 3); !errors.Is(err, ErrCredentialsNotFound) {
+		t.Errorf("Load(nonce 3) = %v, want ErrCredentialsNotFound", err)
+	}
+	if stored, err := store.Load(ctx, address, 0); err != nil || stored.APIKey != "other-key" {
+		t.Errorf("Load(nonce 0) = %+v, %v, want unrelated entry kept", stored, err)
+	}
+}
+
+func TestRotateAPIKeyKeepOldKey(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryCredentialStore()
+	client, stub := newRotationTestClient(t, store)
+	address := client.signer.Address()
+	old := testOldCreds
+	store.Save(ctx, address, 0, &old)
+
+	if _, err := client.RotateAPIKey(ctx, 1, &RotateAPIKeyOptions{GracePeriod: -1, KeepOldKey: true}); err != nil {
+		t.Fatal(err)
+	}
+	if len(stub.deleted) != 0 {
+		t.Errorf("deleted keys = %v, want none", stub.deleted)
+	}
+	if stored, err := store.Load(ctx, address, 0); err != nil || *stored != testOldCreds {
+		t.Errorf("Load(nonce 0) = %+v, %v, want old key kept", stored, err)
+	}
+}
+
+func TestRotateAPIKeyRollbackAfterCancel(t *testing.T) {
+	client, stub := newRotationTestClient(t, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 验证期间调用方取消了 ctx
+	_, err := client.RotateAPIKey(ctx, 1, &RotateAPIKeyOptions{
+		GracePeriod: -1,
+		Verify: func(ctx context.Context) error {
+			cancel()
+			return ctx.Err()
+		},
+	})
+	if !errors.Is(err, ErrAPIKeyRotationRolledBack) {
+		t.Fatalf("RotateAPIKey = %v, want ErrAPIKeyRotationRolledBack", err)
+	}
+	if *client.GetCreds() != testOldCreds {
+		t.Errorf("client creds = %+v, want old creds restored", client.GetCreds())
+	}
+	// 新密钥仍被删除，不会在服务器上留下孤立的密钥
+	if len(stub.deleted) != 1 || stub.deleted[0] != testNewCreds.APIKey {
+		t.Errorf("deleted keys = %v, want [%s]", stub.deleted, testNewCreds.APIKey)
+	}
+}
@@ -1,6 +1,7 @@
 package polymarket
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -24,6 +25,7 @@ type ClobClient struct {
 	mode         int
 	builder      *obuilder.OrderBuilder
 	httpClient   *HTTPClient
+	credStore    CredentialStore
 	
 	// 本地缓存
 	tickSizes    map[string]TickSize
@@ -34,6 +36,9 @@ type ClobClient struct {
 	rfq          *rfq.RfqClient
 	
 	mu           sync.RWMutex
+
+	// credsMu 保护 creds 和 mode（RotateAPIKey 会在请求进行中替换凭证）
+	credsMu      sync.RWMutex
 }
 
 // NewClobClient 创建新的CLOB客户端
@@ -44,24 +49,52 @@ type ClobClient struct {
 // signatureType: 签名类型（0=EOA, 1=Email/Magic, 2=Browser proxy，可选）
 // funder: 资金持有者地址（用于代理钱包，可选）
 func NewClobClient(host string, chainID int, privateKey string, creds *ApiCreds, signatureType *int, funder string) (*ClobClient, error) {
+	opts := []ClientOption{WithAPICreds(creds), WithFunder(funder)}
+	if signatureType != nil {
+		opts = append(opts, WithSignatureType(*signatureType))
+	}
+	return NewClobClientWithOptions(host, chainID, privateKey, opts...)
+}
+
+// NewClobClientWithOptions 使用函数式选项创建CLOB客户端
+// host: CLOB API端点
+// chainID: 链ID
+// privateKey: 私钥（十六进制字符串，可选，为空且未使用 WithSigner 时为 L0 只读模式）
+// opts: WithAPICreds, WithSigner, WithCredentialStore, WithSignatureType, WithFunder, WithHTTPClient, WithTransport, WithTimeout, WithUserAgent 等
+func NewClobClientWithOptions(host string, chainID int, privateKey string, opts ...ClientOption) (*ClobClient, error) {
+	options := &clientOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	// 移除host末尾的斜杠
 	if strings.HasSuffix(host, "/") {
 		host = host[:len(host)-1]
 	}
 
 	client := &ClobClient{
-		host:      host,
-		chainID:   chainID,
-		creds:     creds,
-		httpClient: NewHTTPClient(host),
-		tickSizes: make(map[string]TickSize),
-		negRisk:   make(map[string]bool),
-		feeRates:  make(map[string]int),
+		host:       host,
+		chainID:    chainID,
+		creds:      options.creds,
+		credStore:  options.credStore,
+		httpClient: options.buildHTTPClient(host),
+		tickSizes:  make(map[string]TickSize),
+		negRisk:    make(map[string]bool),
+		feeRates:   make(map[string]int),
 	}
 
-	// 创建签名器（如果提供了私钥）
-	if privateKey != "" {
-		signer, err := NewSigner(privateKey, chainID)
+	// 创建签名器（如果提供了私钥或签名后端）
+	if privateKey != "" && options.signer != nil {
+		return nil, fmt.Errorf("privateKey and WithSigner are mutually exclusive")
+	}
+	if privateKey != "" || options.signer != nil {
+		var signer *Signer
+		var err error
+		if options.signer != nil {
+			signer, err = NewSignerWithBackend(options.signer, chainID)
+		} else {
+			signer, err = NewSigner(privateKey, chainID)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to create signer: %w", err)
 		}
@@ -69,13 +102,13 @@ func NewClobClient(host string, chainID int, privateKey string, creds *ApiCreds,
 
 		// 创建订单构建器
 		sigType := 0 // 默认EOA
-		if signatureType != nil {
-			sigType = *signatureType
+		if options.signatureType != nil {
+			sigType = *options.signatureType
 		}
-		
+
 		funderAddr := signer.Address()
-		if funder != "" {
-			funderAddr = funder
+		if options.funder != "" {
+			funderAddr = options.funder
 		}
 
 		builder, err := obuilder.NewOrderBuilder(signer, sigType, funderAddr)
@@ -94,7 +127,7 @@ func NewClobClient(host string, chainID int, privateKey string, creds *ApiCreds,
 	return client, nil
 }
 
-// getClientMode 获取客户端模式
+// getClientMode 获取客户端模式（调用方需持有 credsMu 或在构造期间调用）
 func (c *ClobClient) getClientMode() int {
 	if c.signer == nil {
 		return L0
@@ -141,14 +174,64 @@ func (c *ClobClient) GetExchangeAddress(negRisk bool) string {
 }
 
 // SetAPICreds 设置API凭证
+// 并发安全：已经开始的请求继续使用旧凭证，之后的请求使用新凭证
 func (c *ClobClient) SetAPICreds(creds *ApiCreds) {
+	c.credsMu.Lock()
+	defer c.credsMu.Unlock()
+	c.creds = creds
+	c.mode = c.getClientMode()
+}
+
+// swapAPICreds 仅当当前凭证为 old 时替换为 creds，返回是否替换
+func (c *ClobClient) swapAPICreds(old, creds *ApiCreds) bool {
+	c.credsMu.Lock()
+	defer c.credsMu.Unlock()
+	if c.creds != old {
+		return false
+	}
 	c.creds = creds
 	c.mode = c.getClientMode()
+	return true
+}
+
+// apiCreds 返回当前API凭证的快照，同一个请求内应只读取一次
+func (c *ClobClient) apiCreds() *ApiCreds {
+	c.credsMu.RLock()
+	defer c.credsMu.RUnlock()
+	return c.creds
+}
+
+// authMode 返回当前认证级别
+func (c *ClobClient) authMode() int {
+	c.credsMu.RLock()
+	defer c.credsMu.RUnlock()
+	return c.mode
+}
+
+// SetRetryPolicy 设置HTTP重试策略
+// 默认只重试幂等的读请求，传入 nil 关闭重试
+func (c *ClobClient) SetRetryPolicy(policy *RetryPolicy) {
+	c.httpClient.SetRetryPolicy(policy)
+}
+
+// SetRateLimiter 设置客户端限流器
+// 默认不限流；传入 NewTokenBucketLimiter(nil, false) 使用 DefaultRateLimits 的阻塞式令牌桶，传入 nil 关闭限流
+func (c *ClobClient) SetRateLimiter(limiter RateLimiter) {
+	c.httpClient.SetRateLimiter(limiter)
+}
+
+// RateLimitUsage 返回各端点分组的令牌桶使用情况（用于监控），未设置限流器时返回 nil
+func (c *ClobClient) RateLimitUsage() map[EndpointGroup]BucketUsage {
+	limiter := c.httpClient.RateLimiter()
+	if limiter == nil {
+		return nil
+	}
+	return limiter.Usage()
 }
 
 // assertLevel1Auth 断言需要L1认证
 func (c *ClobClient) assertLevel1Auth() error {
-	if c.mode < L1 {
+	if c.authMode() < L1 {
 		return fmt.Errorf(L1AuthUnavailable)
 	}
 	return nil
@@ -156,7 +239,7 @@ func (c *ClobClient) assertLevel1Auth() error {
 
 // assertLevel2Auth 断言需要L2认证
 func (c *ClobClient) assertLevel2Auth() error {
-	if c.mode < L2 {
+	if c.authMode() < L2 {
 		return fmt.Errorf(L2AuthUnavailable)
 	}
 	return nil
@@ -174,7 +257,7 @@ func (c *ClobClient) GetSigner() *Signer {
 
 // GetCreds 获取API凭证（供RFQ客户端使用）
 func (c *ClobClient) GetCreds() *ApiCreds {
-	return c.creds
+	return c.apiCreds()
 }
 
 // GetHTTPClient 获取HTTP客户端（供RFQ客户端使用）
@@ -205,7 +288,7 @@ func (c *ClobClient) CreateLevel2HeadersInternal(method, path string, body inter
 		SerializedBody: &bodyStr,
 	}
 
-	return CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	return CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 }
 
 // GetHost 获取host（供RFQ客户端使用）
@@ -215,14 +298,14 @@ func (c *ClobClient) GetHost() string {
 
 // GetAPICreds 获取API Key（供RFQ客户端使用）
 func (c *ClobClient) GetAPICreds() string {
-	if c.creds != nil {
-		return c.creds.APIKey
+	if creds := c.apiCreds(); creds != nil {
+		return creds.APIKey
 	}
 	return ""
 }
 
 // CreateOrderForRFQ 为RFQ创建签名订单（供RFQ客户端使用，避免循环导入）
-func (c *ClobClient) CreateOrderForRFQ(args *rfq.OrderCreationArgs) (*rfq.SignedOrderData, error) {
+func (c *ClobClient) CreateOrderForRFQ(ctx context.Context, args *rfq.OrderCreationArgs) (*rfq.SignedOrderData, error) {
 	// 创建订单参数
 	orderArgs := &OrderArgs{
 		TokenID:    args.TokenID,
@@ -233,7 +316,7 @@ func (c *ClobClient) CreateOrderForRFQ(args *rfq.OrderCreationArgs) (*rfq.Signed
 	}
 
 	// 创建签名订单
-	signedOrder, err := c.CreateOrder(orderArgs, nil)
+	signedOrder, err := c.CreateOrder(ctx, orderArgs, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,34 +1,52 @@
 package polymarket
 
 import (
+	"context"
+	"errors"
 	"fmt"
 )
 
 // GetOK 健康检查：确认服务器是否运行
 // 不需要认证
-func (c *ClobClient) GetOK() (interface{}, error) {
-	return c.httpClient.Get("/", nil)
+func (c *ClobClient) GetOK(ctx context.Context) (interface{}, error) {
+	return c.httpClient.Get(ctx, "/", nil)
 }
 
 // GetServerTime 返回服务器当前时间戳
 // 不需要认证
-func (c *ClobClient) GetServerTime() (interface{}, error) {
-	return c.httpClient.Get(Time, nil)
+func (c *ClobClient) GetServerTime(ctx context.Context) (interface{}, error) {
+	return c.httpClient.Get(ctx, Time, nil)
 }
 
 // CreateAPIKey 创建新的CLOB API密钥
 // 需要L1认证
-func (c *ClobClient) CreateAPIKey(nonce *int) (*ApiCreds, error) {
+func (c *ClobClient) CreateAPIKey(ctx context.Context, nonce *int) (*ApiCreds, error) {
+	creds, err := c.createAPIKey(ctx, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	// 自动设置到客户端
+	c.SetAPICreds(creds)
+
+	if err := c.storeCreds(ctx, nonce, creds); err != nil {
+		return creds, err
+	}
+	return creds, nil
+}
+
+// createAPIKey 创建新的API密钥，不设置到客户端
+func (c *ClobClient) createAPIKey(ctx context.Context, nonce *int) (*ApiCreds, error) {
 	if err := c.assertLevel1Auth(); err != nil {
 		return nil, err
 	}
 
-	headers, err := CreateLevel1Headers(c.signer, nonce)
+	headers, err := CreateLevel1HeadersContext(ctx, c.signer, nonce)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.httpClient.Post(CreateAPIKey, headers, nil)
+	resp, err := c.httpClient.Post(ctx, CreateAPIKey, headers, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -38,31 +56,26 @@ func (c *ClobClient) CreateAPIKey(nonce *int) (*ApiCreds, error) {
 		return nil, fmt.Errorf("invalid response format")
 	}
 
-	creds := &ApiCreds{
+	return &ApiCreds{
 		APIKey:        getStringFromMap(respMap, "apiKey"),
 		APISecret:     getStringFromMap(respMap, "secret"),
 		APIPassphrase: getStringFromMap(respMap, "passphrase"),
-	}
-
-	// 自动设置到客户端
-	c.SetAPICreds(creds)
-
-	return creds, nil
+	}, nil
 }
 
 // DeriveAPIKey 派生已存在的CLOB API密钥
 // 需要L1认证
-func (c *ClobClient) DeriveAPIKey(nonce *int) (*ApiCreds, error) {
+func (c *ClobClient) DeriveAPIKey(ctx context.Context, nonce *int) (*ApiCreds, error) {
 	if err := c.assertLevel1Auth(); err != nil {
 		return nil, err
 	}
 
-	headers, err := CreateLevel1Headers(c.signer, nonce)
+	headers, err := CreateLevel1HeadersContext(ctx, c.signer, nonce)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.httpClient.Get(DeriveAPIKey, headers)
+	resp, err := c.httpClient.Get(ctx, DeriveAPIKey, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -90,23 +103,127 @@ func (c *ClobClient) DeriveAPIKey(nonce *int) (*ApiCreds, error) {
 	// 自动设置到客户端
 	c.SetAPICreds(creds)
 
+	if err := c.storeCreds(ctx, nonce, creds); err != nil {
+		return creds, err
+	}
 	return creds, nil
 }
 
 // CreateOrDeriveAPIKey 创建或派生API凭证
-// 先尝试创建，如果失败则派生
-func (c *ClobClient) CreateOrDeriveAPIKey(nonce *int) (*ApiCreds, error) {
-	creds, err := c.CreateAPIKey(nonce)
+// 设置了凭证存储时先读取已保存的凭证，并用 GetAPIKeys 验证；服务器拒绝（401/403）时视为过期凭证，
+// 从存储中删除后重新创建或派生。验证时的网络错误直接返回，不删除凭证。
+// 否则先尝试创建；仅当服务器拒绝创建（非重试类的 APIError，例如该 nonce 已有密钥）时改为派生，
+// 网络错误、限流和 5xx 直接返回。凭证写入存储失败时返回凭证和存储错误
+func (c *ClobClient) CreateOrDeriveAPIKey(ctx context.Context, nonce *int) (*ApiCreds, error) {
+	if c.credStore != nil {
+		if err := c.assertLevel1Auth(); err != nil {
+			return nil, err
+		}
+		creds, err := c.loadStoredCreds(ctx, nonce)
+		if err != nil {
+			return nil, err
+		}
+		if creds != nil {
+			c.SetAPICreds(creds)
+			return creds, nil
+		}
+	}
+
+	creds, err := c.CreateAPIKey(ctx, nonce)
+	if creds != nil || !isServerRejection(err) {
+		return creds, err
+	}
+	return c.DeriveAPIKey(ctx, nonce)
+}
+
+// loadStoredCreds 读取并验证已保存的凭证；不存在或已被服务器拒绝（并已删除）时返回 nil
+func (c *ClobClient) loadStoredCreds(ctx context.Context, nonce *int) (*ApiCreds, error) {
+	creds, err := c.credStore.Load(ctx, c.signer.Address(), nonceValue(nonce))
+	if errors.Is(err, ErrCredentialsNotFound) {
+		return nil, nil
+	}
 	if err != nil {
-		// 如果创建失败，尝试派生
-		return c.DeriveAPIKey(nonce)
+		return nil, fmt.Errorf("load stored credentials: %w", err)
 	}
-	return creds, nil
+
+	err = c.verifyAPICreds(ctx, creds)
+	if err == nil {
+		return creds, nil
+	}
+	if !IsAuthError(err) {
+		return nil, fmt.Errorf("verify stored credentials: %w", err)
+	}
+	if err := c.forgetCreds(ctx, nonce, creds); err != nil {
+		return nil, fmt.Errorf("stored credentials rejected: %w", err)
+	}
+	return nil, nil
+}
+
+// verifyAPICreds 用指定凭证执行一次 GetAPIKeys 请求，不修改客户端凭证
+func (c *ClobClient) verifyAPICreds(ctx context.Context, creds *ApiCreds) error {
+	requestArgs := &RequestArgs{
+		Method:      "GET",
+		RequestPath: GetAPIKeys,
+	}
+
+	headers, err := CreateLevel2Headers(c.signer, creds, requestArgs)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.httpClient.Get(ctx, GetAPIKeys, headers)
+	return err
+}
+
+// isServerRejection 服务器是否明确拒绝了请求（非重试类的 APIError）
+func isServerRejection(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && !apiErr.Retryable
+}
+
+// storeCreds 将凭证写入凭证存储（如果设置了）
+func (c *ClobClient) storeCreds(ctx context.Context, nonce *int, creds *ApiCreds) error {
+	if c.credStore == nil {
+		return nil
+	}
+	if err := c.credStore.Save(ctx, c.signer.Address(), nonceValue(nonce), creds); err != nil {
+		return fmt.Errorf("save credentials: %w", err)
+	}
+	return nil
+}
+
+// forgetCreds 从凭证存储中删除 nonce 对应的凭证，仅当保存的仍是 creds 时删除
+func (c *ClobClient) forgetCreds(ctx context.Context, nonce *int, creds *ApiCreds) error {
+	if c.credStore == nil {
+		return nil
+	}
+	stored, err := c.credStore.Load(ctx, c.signer.Address(), nonceValue(nonce))
+	if errors.Is(err, ErrCredentialsNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("load stored credentials: %w", err)
+	}
+	if stored.APIKey != creds.APIKey {
+		return nil
+	}
+	if err := c.credStore.Delete(ctx, c.signer.Address(), nonceValue(nonce)); err != nil {
+		return fmt.Errorf("delete stored credentials: %w", err)
+	}
+	return nil
+}
+
+// nonceValue nonce 为空时为0，与 CreateLevel1Headers 一致
+func nonceValue(nonce *int) int {
+	if nonce == nil {
+		return 0
+	}
+	return *nonce
 }
 
 // GetAPIKeys 获取可用的API密钥列表
 // 需要L2认证
-func (c *ClobClient) GetAPIKeys() (interface{}, error) {
+func (c *ClobClient) GetAPIKeys(ctx context.Context) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -116,17 +233,17 @@ func (c *ClobClient) GetAPIKeys() (interface{}, error) {
 		RequestPath: GetAPIKeys,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.httpClient.Get(GetAPIKeys, headers)
+	return c.httpClient.Get(ctx, GetAPIKeys, headers)
 }
 
 // GetClosedOnlyMode 获取closed only模式标志
 // 需要L2认证
-func (c *ClobClient) GetClosedOnlyMode() (interface{}, error) {
+func (c *ClobClient) GetClosedOnlyMode(ctx context.Context) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -136,57 +253,73 @@ func (c *ClobClient) GetClosedOnlyMode() (interface{}, error) {
 		RequestPath: ClosedOnly,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.httpClient.Get(ClosedOnly, headers)
+	return c.httpClient.Get(ctx, ClosedOnly, headers)
 }
 
 // DeleteAPIKey 删除API密钥
 // 需要L2认证
-func (c *ClobClient) DeleteAPIKey() (interface{}, error) {
+func (c *ClobClient) DeleteAPIKey(ctx context.Context) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
+	return c.deleteAPIKey(ctx, c.apiCreds())
+}
 
+// deleteAPIKey 使用指定凭证删除该凭证对应的API密钥
+func (c *ClobClient) deleteAPIKey(ctx context.Context, creds *ApiCreds) (interface{}, error) {
 	requestArgs := &RequestArgs{
 		Method:      "DELETE",
 		RequestPath: DeleteAPIKey,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, creds, requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.httpClient.Delete(DeleteAPIKey, headers, nil)
+	return c.httpClient.Delete(ctx, DeleteAPIKey, headers, nil)
 }
 
 // GetMidpoint 获取中点价格
-func (c *ClobClient) GetMidpoint(tokenID string) (interface{}, error) {
+func (c *ClobClient) GetMidpoint(ctx context.Context, tokenID string) (*MidpointResponse, error) {
 	path := fmt.Sprintf("%s?token_id=%s", MidPoint, tokenID)
-	return c.httpClient.Get(path, nil)
+	var result MidpointResponse
+	if err := c.httpClient.GetInto(ctx, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
 }
 
 // GetMidpoints 获取多个token的中点价格
-func (c *ClobClient) GetMidpoints(params []BookParams) (interface{}, error) {
+func (c *ClobClient) GetMidpoints(ctx context.Context, params []BookParams) (Midpoints, error) {
 	body := make([]map[string]string, len(params))
 	for i, p := range params {
 		body[i] = map[string]string{"token_id": p.TokenID}
 	}
-	return c.httpClient.Post(MidPoints, nil, body)
+	var result Midpoints
+	if err := c.httpClient.PostInto(ctx, MidPoints, nil, body, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
 // GetPrice 获取市场价格
-func (c *ClobClient) GetPrice(tokenID, side string) (interface{}, error) {
+func (c *ClobClient) GetPrice(ctx context.Context, tokenID, side string) (*PriceResponse, error) {
 	path := fmt.Sprintf("%s?token_id=%s&side=%s", Price, tokenID, side)
-	return c.httpClient.Get(path, nil)
+	var result PriceResponse
+	if err := c.httpClient.GetInto(ctx, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
 }
 
 // GetPrices 获取多个token的市场价格
-func (c *ClobClient) GetPrices(params []BookParams) (interface{}, error) {
+func (c *ClobClient) GetPrices(ctx context.Context, params []BookParams) (Prices, error) {
 	body := make([]map[string]string, len(params))
 	for i, p := range params {
 		body[i] = map[string]string{
@@ -194,26 +327,38 @@ func (c *ClobClient) GetPrices(params []BookParams) (interface{}, error) {
 			"side":     p.Side,
 		}
 	}
-	return c.httpClient.Post(GetPrices, nil, body)
+	var result Prices
+	if err := c.httpClient.PostInto(ctx, GetPrices, nil, body, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
 // GetSpread 获取价差
-func (c *ClobClient) GetSpread(tokenID string) (interface{}, error) {
+func (c *ClobClient) GetSpread(ctx context.Context, tokenID string) (*SpreadResponse, error) {
 	path := fmt.Sprintf("%s?token_id=%s", GetSpread, tokenID)
-	return c.httpClient.Get(path, nil)
+	var result SpreadResponse
+	if err := c.httpClient.GetInto(ctx, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
 }
 
 // GetSpreads 获取多个token的价差
-func (c *ClobClient) GetSpreads(params []BookParams) (interface{}, error) {
+func (c *ClobClient) GetSpreads(ctx context.Context, params []BookParams) (Spreads, error) {
 	body := make([]map[string]string, len(params))
 	for i, p := range params {
 		body[i] = map[string]string{"token_id": p.TokenID}
 	}
-	return c.httpClient.Post(GetSpreads, nil, body)
+	var result Spreads
+	if err := c.httpClient.PostInto(ctx, GetSpreads, nil, body, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
 // GetTickSize 获取tick size（带缓存）
-func (c *ClobClient) GetTickSize(tokenID string) (TickSize, error) {
+func (c *ClobClient) GetTickSize(ctx context.Context, tokenID string) (TickSize, error) {
 	c.mu.RLock()
 	if tickSize, ok := c.tickSizes[tokenID]; ok {
 		c.mu.RUnlock()
@@ -222,7 +367,7 @@ func (c *ClobClient) GetTickSize(tokenID string) (TickSize, error) {
 	c.mu.RUnlock()
 
 	path := fmt.Sprintf("%s?token_id=%s", GetTickSize, tokenID)
-	resp, err := c.httpClient.Get(path, nil)
+	resp, err := c.httpClient.Get(ctx, path, nil)
 	if err != nil {
 		return "", err
 	}
@@ -243,7 +388,7 @@ func (c *ClobClient) GetTickSize(tokenID string) (TickSize, error) {
 }
 
 // GetNegRisk 获取neg risk标志（带缓存）
-func (c *ClobClient) GetNegRisk(tokenID string) (bool, error) {
+func (c *ClobClient) GetNegRisk(ctx context.Context, tokenID string) (bool, error) {
 	c.mu.RLock()
 	if negRisk, ok := c.negRisk[tokenID]; ok {
 		c.mu.RUnlock()
@@ -252,7 +397,7 @@ func (c *ClobClient) GetNegRisk(tokenID string) (bool, error) {
 	c.mu.RUnlock()
 
 	path := fmt.Sprintf("%s?token_id=%s", GetNegRisk, tokenID)
-	resp, err := c.httpClient.Get(path, nil)
+	resp, err := c.httpClient.Get(ctx, path, nil)
 	if err != nil {
 		return false, err
 	}
@@ -272,7 +417,7 @@ func (c *ClobClient) GetNegRisk(tokenID string) (bool, error) {
 }
 
 // GetFeeRateBps 获取手续费率（基点）（带缓存）
-func (c *ClobClient) GetFeeRateBps(tokenID string) (int, error) {
+func (c *ClobClient) GetFeeRateBps(ctx context.Context, tokenID string) (int, error) {
 	c.mu.RLock()
 	if feeRate, ok := c.feeRates[tokenID]; ok {
 		c.mu.RUnlock()
@@ -281,7 +426,7 @@ func (c *ClobClient) GetFeeRateBps(tokenID string) (int, error) {
 	c.mu.RUnlock()
 
 	path := fmt.Sprintf("%s?token_id=%s", GetFeeRate, tokenID)
-	resp, err := c.httpClient.Get(path, nil)
+	resp, err := c.httpClient.Get(ctx, path, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -306,9 +451,9 @@ func (c *ClobClient) GetFeeRateBps(tokenID string) (int, error) {
 }
 
 // GetOrderBook 获取订单簿
-func (c *ClobClient) GetOrderBook(tokenID string) (*OrderBookSummary, error) {
+func (c *ClobClient) GetOrderBook(ctx context.Context, tokenID string) (*OrderBookSummary, error) {
 	path := fmt.Sprintf("%s?token_id=%s", GetOrderBook, tokenID)
-	resp, err := c.httpClient.Get(path, nil)
+	resp, err := c.httpClient.Get(ctx, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -322,13 +467,13 @@ func (c *ClobClient) GetOrderBook(tokenID string) (*OrderBookSummary, error) {
 }
 
 // GetOrderBooks 获取多个订单簿
-func (c *ClobClient) GetOrderBooks(params []BookParams) ([]*OrderBookSummary, error) {
+func (c *ClobClient) GetOrderBooks(ctx context.Context, params []BookParams) ([]*OrderBookSummary, error) {
 	body := make([]map[string]string, len(params))
 	for i, p := range params {
 		body[i] = map[string]string{"token_id": p.TokenID}
 	}
 
-	resp, err := c.httpClient.Post(GetOrderBooks, nil, body)
+	resp, err := c.httpClient.Post(ctx, GetOrderBooks, nil, body)
 	if err != nil {
 		return nil, err
 	}
@@ -353,18 +498,33 @@ func (c *ClobClient) GetOrderBooks(params []BookParams) ([]*OrderBookSummary, er
 }
 
 // GetLastTradePrice 获取最后成交价格
-func (c *ClobClient) GetLastTradePrice(tokenID string) (interface{}, error) {
+func (c *ClobClient) GetLastTradePrice(ctx context.Context, tokenID string) (*LastTradePrice, error) {
 	path := fmt.Sprintf("%s?token_id=%s", GetLastTradePrice, tokenID)
-	return c.httpClient.Get(path, nil)
+	var result LastTradePrice
+	if err := c.httpClient.GetInto(ctx, path, nil, &result); err != nil {
+		return nil, err
+	}
+	result.TokenID = tokenID
+	return &result, nil
 }
 
 // GetLastTradesPrices 获取多个token的最后成交价格
-func (c *ClobClient) GetLastTradesPrices(params []BookParams) (interface{}, error) {
+// API 返回数组，这里按 token ID 转换为 map
+func (c *ClobClient) GetLastTradesPrices(ctx context.Context, params []BookParams) (LastTradesPrices, error) {
 	body := make([]map[string]string, len(params))
 	for i, p := range params {
 		body[i] = map[string]string{"token_id": p.TokenID}
 	}
-	return c.httpClient.Post(GetLastTradesPrices, nil, body)
+	var items []LastTradePrice
+	if err := c.httpClient.PostInto(ctx, GetLastTradesPrices, nil, body, &items); err != nil {
+		return nil, err
+	}
+
+	result := make(LastTradesPrices, len(items))
+	for _, item := range items {
+		result[item.TokenID] = item
+	}
+	return result, nil
 }
 
 // 辅助函数
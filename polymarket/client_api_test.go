@@ -0,0 +1,233 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var testDerivedCreds = ApiCreds{APIKey: "derived-key", APISecret: "ZGVyaXZlZC1zZWNyZXQ=", APIPassphrase: "derived-pass"}
+
+// apiKeyServerStub 模拟创建、派生和列出 API 密钥的端点
+type apiKeyServerStub struct {
+	createStatus int             // 创建请求返回的状态码，0 表示成功返回 testNewCreds
+	validKeys    map[string]bool // GetAPIKeys 接受的密钥
+	keysStatus   int             // GetAPIKeys 固定返回的状态码（覆盖 validKeys）
+
+	creates, derives, lists int
+}
+
+func (s *apiKeyServerStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == CreateAPIKey:
+		s.creates++
+		if s.createStatus != 0 {
+			w.WriteHeader(s.createStatus)
+			w.Write([]byte(`{"error":"could not create api key"}`))
+			return
+		}
+		w.Write([]byte(`{"apiKey":"` + testNewCreds.APIKey + `","secret":"` + testNewCreds.APISecret + `","passphrase":"` + testNewCreds.APIPassphrase + `"}`))
+	case r.Method == http.MethodGet && r.URL.Path == DeriveAPIKey:
+		s.derives++
+		w.Write([]byte(`{"apiKey":"` + testDerivedCreds.APIKey + `","secret":"` + testDerivedCreds.APISecret + `","passphrase":"` + testDerivedCreds.APIPassphrase + `"}`))
+	case r.Method == http.MethodGet && r.URL.Path == GetAPIKeys:
+		s.lists++
+		if s.keysStatus != 0 {
+			w.WriteHeader(s.keysStatus)
+			w.Write([]byte(`{"error":"unavailable"}`))
+			return
+		}
+		if !s.validKeys[r.Header.Get(PolyAPIKey)] {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"Unauthorized/Invalid api key"}`))
+			return
+		}
+		w.Write([]byte(`{"apiKeys":["` + r.Header.Get(PolyAPIKey) + `"]}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// failingCredentialStore 写入总是失败的凭证存储
+type failingCredentialStore struct {
+	*MemoryCredentialStore
+}
+
+var errStoreWrite = errors.New("disk full")
+
+func (s failingCredentialStore) Save(context.Context, string, int, *ApiCreds) error {
+	return errStoreWrite
+}
+
+func newAPIKeyTestClient(t *testing.T, stub *apiKeyServerStub, store CredentialStore) *ClobClient {
+	t.Helper()
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+	opts := []ClientOption{WithRetryPolicy(NoRetryPolicy())}
+	if store != nil {
+		opts = append(opts, WithCredentialStore(store))
+	}
+	client, err := NewClobClientWithOptions(server.URL, 137, testPrivateKey, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestCreateOrDeriveAPIKeyFallback(t *testing.T) {
+	tests := []struct {
+		name         string
+		createStatus int
+		want         *ApiCreds
+		wantDerive   bool
+	}{
+		{"created", 0, &testNewCreds, false},
+		{"nonce already used", http.StatusBadRequest, &testDerivedCreds, true},
+		{"rate limited", http.StatusTooManyRequests, nil, false},
+		{"server error", http.StatusInternalServerError, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &apiKeyServerStub{createStatus: tt.createStatus}
+			client := newAPIKeyTestClient(t, stub, nil)
+			creds, err := client.CreateOrDeriveAPIKey(context.Background(), nil)
+			if tt.want == nil {
+				if err == nil {
+					t.Fatalf("CreateOrDeriveAPIKey = %+v, want error", creds)
+				}
+			} else if err != nil || *creds != *tt.want {
+				t.Fatalf("CreateOrDeriveAPIKey = %+v, %v, want %+v", creds, err, tt.want)
+			}
+			if (stub.derives > 0) != tt.wantDerive {
+				t.Errorf("derive calls = %d, want derive %v", stub.derives, tt.wantDerive)
+			}
+		})
+	}
+}
+
+func TestCreateOrDeriveAPIKeyNetworkError(t *testing.T) {
+	client, err := NewClobClientWithOptions("http://127.0.0.1:1", 137, testPrivateKey, WithRetryPolicy(NoRetryPolicy()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateOrDeriveAPIKey(context.Background(), nil); err == nil || isServerRejection(err) {
+		t.Fatalf("CreateOrDeriveAPIKey = %v, want network error", err)
+	}
+}
+
+func TestCreateOrDeriveAPIKeyStoreWriteError(t *testing.T) {
+	stub := &apiKeyServerStub{}
+	client := newAPIKeyTestClient(t, stub, failingCredentialStore{NewMemoryCredentialStore()})
+	creds, err := client.CreateOrDeriveAPIKey(context.Background(), nil)
+	if !errors.Is(err, errStoreWrite) {
+		t.Fatalf("err = %v, want store error", err)
+	}
+	if creds == nil || *creds != testNewCreds {
+		t.Errorf("creds = %+v, want created credentials", creds)
+	}
+	if stub.creates != 1 || stub.derives != 0 {
+		t.Errorf("creates/derives = %d/%d, want 1/0", stub.creates, stub.derives)
+	}
+}
+
+func TestCreateOrDeriveAPIKeyStoredCreds(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryCredentialStore()
+	stub := &apiKeyServerStub{validKeys: map[string]bool{testOldCreds.APIKey: true}}
+	client := newAPIKeyTestClient(t, stub, store)
+	old := testOldCreds
+	store.Save(ctx, client.signer.Address(), 0, &old)
+
+	creds, err := client.CreateOrDeriveAPIKey(ctx, nil)
+	if err != nil || *creds != testOldCreds {
+		t.Fatalf("CreateOrDeriveAPIKey = %+v, %v, want stored credentials", creds, err)
+	}
+	if stub.lists != 1 || stub.creates != 0 {
+		t.Errorf("lists/creates = %d/%d, want 1/0", stub.lists, stub.creates)
+	}
+}
+
+func TestCreateOrDeriveAPIKeyStaleStoredCreds(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryCredentialStore()
+	stub := &apiKeyServerStub{validKeys: map[string]bool{}}
+	client := newAPIKeyTestClient(t, stub, store)
+	address := client.signer.Address()
+	old := testOldCreds
+	store.Save(ctx, address, 0, &old)
+
+	creds, err := client.CreateOrDeriveAPIKey(ctx, nil)
+	if err != nil || *creds != testNewCreds {
+		t.Fatalf("CreateOrDeriveAPIKey = %+v, %v, want new credentials", creds, err)
+	}
+	if stored, err := store.Load(ctx, address, 0); err != nil || *stored != testNewCreds {
+		t.Errorf("stored = %+v, %v, want new credentials", stored, err)
+	}
+}
+
+func TestCreateOrDeriveAPIKeyStoredCredsVerifyError(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryCredentialStore()
+	stub := &apiKeyServerStub{keysStatus: http.StatusServiceUnavailable}
+	client := newAPIKeyTestClient(t, stub, store)
+	address := client.signer.Address()
+	old := testOldCreds
+	store.Save(ctx, address, 0, &old)
+
+	if _, err := client.CreateOrDeriveAPIKey(ctx, nil); !errors.Is(err, ErrServerError) {
+		t.Fatalf("CreateOrDeriveAPIKey = %v, want ErrServerError", err)
+	}
+	if stored, err := store.Load(ctx, address, 0); err != nil || *stored != testOldCreds {
+		t.Errorf("stored = %+v, %v, want entry kept", stored, err)
+	}
+	if stub.creates != 0 {
+		t.Errorf("creates = %d, want 0", stub.creates)
+	}
+}
+
+// blockingSigner 签名请求一直阻塞到 ctx 结束，模拟无响应的远程签名服务
+type blockingSigner struct {
+	*PrivateKeySigner
+}
+
+func (s blockingSigner) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestLevel1SigningHonoursContext(t *testing.T) {
+	key, err := NewPrivateKeySigner(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stub := &apiKeyServerStub{}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+	client, err := NewClobClientWithOptions(server.URL, 137, "", WithSigner(blockingSigner{key}), WithRetryPolicy(NoRetryPolicy()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.CreateOrDeriveAPIKey(ctx, nil)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("CreateOrDeriveAPIKey = %v, want context.DeadlineExceeded", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("CreateOrDeriveAPIKey did not return after ctx timeout")
+	}
+	if stub.creates+stub.derives != 0 {
+		t.Errorf("server received %d create and %d derive requests, want none", stub.creates, stub.derives)
+	}
+}
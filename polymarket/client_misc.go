@@ -1,13 +1,14 @@
 package polymarket
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
 
 // CreateReadonlyAPIKey 创建只读API密钥
 // 需要L2认证
-func (c *ClobClient) CreateReadonlyAPIKey() (*ReadonlyApiKeyResponse, error) {
+func (c *ClobClient) CreateReadonlyAPIKey(ctx context.Context) (*ReadonlyApiKeyResponse, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -17,12 +18,12 @@ func (c *ClobClient) CreateReadonlyAPIKey() (*ReadonlyApiKeyResponse, error) {
 		RequestPath: CreateReadonlyAPIKey,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.httpClient.Post(CreateReadonlyAPIKey, headers, nil)
+	resp, err := c.httpClient.Post(ctx, CreateReadonlyAPIKey, headers, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +40,7 @@ func (c *ClobClient) CreateReadonlyAPIKey() (*ReadonlyApiKeyResponse, error) {
 
 // GetReadonlyAPIKeys 获取只读API密钥列表
 // 需要L2认证
-func (c *ClobClient) GetReadonlyAPIKeys() (interface{}, error) {
+func (c *ClobClient) GetReadonlyAPIKeys(ctx context.Context) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -49,17 +50,17 @@ func (c *ClobClient) GetReadonlyAPIKeys() (interface{}, error) {
 		RequestPath: GetReadonlyAPIKeys,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.httpClient.Get(GetReadonlyAPIKeys, headers)
+	return c.httpClient.Get(ctx, GetReadonlyAPIKeys, headers)
 }
 
 // DeleteReadonlyAPIKey 删除只读API密钥
 // 需要L2认证
-func (c *ClobClient) DeleteReadonlyAPIKey(key string) (interface{}, error) {
+func (c *ClobClient) DeleteReadonlyAPIKey(ctx context.Context, key string) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -78,24 +79,24 @@ func (c *ClobClient) DeleteReadonlyAPIKey(key string) (interface{}, error) {
 		SerializedBody: &bodyStr,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.httpClient.Delete(DeleteReadonlyAPIKey, headers, bodyStr)
+	return c.httpClient.Delete(ctx, DeleteReadonlyAPIKey, headers, bodyStr)
 }
 
 // ValidateReadonlyAPIKey 验证只读API密钥
 // 公开端点，不需要认证
-func (c *ClobClient) ValidateReadonlyAPIKey(address, key string) (interface{}, error) {
+func (c *ClobClient) ValidateReadonlyAPIKey(ctx context.Context, address, key string) (interface{}, error) {
 	path := fmt.Sprintf("%s?address=%s&key=%s", ValidateReadonlyAPIKey, address, key)
-	return c.httpClient.Get(path, nil)
+	return c.httpClient.Get(ctx, path, nil)
 }
 
 // IsOrderScoring 检查订单是否正在评分
 // 需要L2认证
-func (c *ClobClient) IsOrderScoring(params *OrderScoringParams) (interface{}, error) {
+func (c *ClobClient) IsOrderScoring(ctx context.Context, params *OrderScoringParams) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -106,17 +107,17 @@ func (c *ClobClient) IsOrderScoring(params *OrderScoringParams) (interface{}, er
 		RequestPath: IsOrderScoring,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.httpClient.Get(url[len(c.host):], headers)
+	return c.httpClient.Get(ctx, url[len(c.host):], headers)
 }
 
 // AreOrdersScoring 检查多个订单是否正在评分
 // 需要L2认证
-func (c *ClobClient) AreOrdersScoring(params *OrdersScoringParams) (interface{}, error) {
+func (c *ClobClient) AreOrdersScoring(ctx context.Context, params *OrdersScoringParams) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -134,65 +135,62 @@ func (c *ClobClient) AreOrdersScoring(params *OrdersScoringParams) (interface{},
 		SerializedBody: &bodyStr,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.httpClient.Post(AreOrdersScoring, headers, bodyStr)
+	return c.httpClient.Post(ctx, AreOrdersScoring, headers, bodyStr)
 }
 
 // GetMarkets 获取市场列表
-func (c *ClobClient) GetMarkets(nextCursor string) (interface{}, error) {
-	if nextCursor == "" {
-		nextCursor = "MA=="
-	}
-	path := fmt.Sprintf("%s?next_cursor=%s", GetMarkets, nextCursor)
-	return c.httpClient.Get(path, nil)
+func (c *ClobClient) GetMarkets(ctx context.Context, nextCursor string) (*Page[Market], error) {
+	return getPage[Market](ctx, c, GetMarkets, nextCursor)
 }
 
 // GetSimplifiedMarkets 获取简化市场列表
-func (c *ClobClient) GetSimplifiedMarkets(nextCursor string) (interface{}, error) {
-	if nextCursor == "" {
-		nextCursor = "MA=="
-	}
-	path := fmt.Sprintf("%s?next_cursor=%s", GetSimplifiedMarkets, nextCursor)
-	return c.httpClient.Get(path, nil)
+func (c *ClobClient) GetSimplifiedMarkets(ctx context.Context, nextCursor string) (*Page[SimplifiedMarket], error) {
+	return getPage[SimplifiedMarket](ctx, c, GetSimplifiedMarkets, nextCursor)
 }
 
 // GetSamplingMarkets 获取采样市场列表
-func (c *ClobClient) GetSamplingMarkets(nextCursor string) (interface{}, error) {
-	if nextCursor == "" {
-		nextCursor = "MA=="
-	}
-	path := fmt.Sprintf("%s?next_cursor=%s", GetSamplingMarkets, nextCursor)
-	return c.httpClient.Get(path, nil)
+func (c *ClobClient) GetSamplingMarkets(ctx context.Context, nextCursor string) (*Page[Market], error) {
+	return getPage[Market](ctx, c, GetSamplingMarkets, nextCursor)
 }
 
 // GetSamplingSimplifiedMarkets 获取采样简化市场列表
-func (c *ClobClient) GetSamplingSimplifiedMarkets(nextCursor string) (interface{}, error) {
-	if nextCursor == "" {
-		nextCursor = "MA=="
-	}
-	path := fmt.Sprintf("%s?next_cursor=%s", GetSamplingSimplifiedMarkets, nextCursor)
-	return c.httpClient.Get(path, nil)
+func (c *ClobClient) GetSamplingSimplifiedMarkets(ctx context.Context, nextCursor string) (*Page[SimplifiedMarket], error) {
+	return getPage[SimplifiedMarket](ctx, c, GetSamplingSimplifiedMarkets, nextCursor)
 }
 
 // GetMarket 根据condition_id获取市场
-func (c *ClobClient) GetMarket(conditionID string) (interface{}, error) {
+func (c *ClobClient) GetMarket(ctx context.Context, conditionID string) (*Market, error) {
 	path := GetMarket + conditionID
-	return c.httpClient.Get(path, nil)
+	var market Market
+	if err := c.httpClient.GetInto(ctx, path, nil, &market); err != nil {
+		return nil, err
+	}
+	return &market, nil
+}
+
+// getPage 获取公开的游标分页端点的一页数据
+func getPage[T any](ctx context.Context, c *ClobClient, endpoint, nextCursor string) (*Page[T], error) {
+	if nextCursor == "" {
+		nextCursor = "MA=="
+	}
+	path := fmt.Sprintf("%s?next_cursor=%s", endpoint, nextCursor)
+	return fetchPage[T](ctx, c, path, nil)
 }
 
 // GetMarketTradesEvents 根据condition_id获取市场交易事件
-func (c *ClobClient) GetMarketTradesEvents(conditionID string) (interface{}, error) {
+func (c *ClobClient) GetMarketTradesEvents(ctx context.Context, conditionID string) (interface{}, error) {
 	path := GetMarketTradesEvents + conditionID
-	return c.httpClient.Get(path, nil)
+	return c.httpClient.Get(ctx, path, nil)
 }
 
 // UpdateBalanceAllowance 更新余额和授权
 // 需要L2认证
-func (c *ClobClient) UpdateBalanceAllowance(params *BalanceAllowanceParams) (interface{}, error) {
+func (c *ClobClient) UpdateBalanceAllowance(ctx context.Context, params *BalanceAllowanceParams) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -215,12 +213,12 @@ func (c *ClobClient) UpdateBalanceAllowance(params *BalanceAllowanceParams) (int
 		RequestPath: UpdateBalanceAllowance,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.httpClient.Get(url[len(c.host):], headers)
+	return c.httpClient.Get(ctx, url[len(c.host):], headers)
 }
 
 // GetOrderBookHash 获取订单簿哈希
@@ -229,52 +227,34 @@ func (c *ClobClient) GetOrderBookHash(orderbook *OrderBookSummary) string {
 }
 
 // GetBuilderTrades 获取Builder交易记录
+// 从 nextCursor 开始一直读取到最后一页；大量数据请使用 IterBuilderTrades / IterBuilderTradePages
 // 需要Builder认证
-func (c *ClobClient) GetBuilderTrades(params *TradeParams, nextCursor string) ([]interface{}, error) {
+func (c *ClobClient) GetBuilderTrades(ctx context.Context, params *TradeParams, nextCursor string) ([]BuilderTrade, error) {
+	return collectPages(c.IterBuilderTradePages(ctx, params, nextCursor))
+}
+
+// getBuilderTradesPage 获取一页Builder交易记录
+func (c *ClobClient) getBuilderTradesPage(ctx context.Context, params *TradeParams, cursor string) (*Page[BuilderTrade], error) {
 	// TODO: 实现Builder认证检查
 	// 目前使用L2认证作为替代
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
 
-	if nextCursor == "" {
-		nextCursor = "MA=="
-	}
-
 	requestArgs := &RequestArgs{
 		Method:      "GET",
 		RequestPath: GetBuilderTrades,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	var results []interface{}
-	for nextCursor != EndCursor {
-		url := AddQueryTradeParams(c.host+GetBuilderTrades, params, nextCursor)
-		resp, err := c.httpClient.Get(url[len(c.host):], headers)
-		if err != nil {
-			return nil, err
-		}
-
-		respMap, ok := resp.(map[string]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid response format")
-		}
-
-		if cursor, ok := respMap["next_cursor"].(string); ok {
-			nextCursor = cursor
-		} else {
-			nextCursor = EndCursor
-		}
-
-		if data, ok := respMap["data"].([]interface{}); ok {
-			results = append(results, data...)
-		}
+	if params == nil {
+		params = &TradeParams{}
 	}
-
-	return results, nil
+	url := AddQueryTradeParams(c.host+GetBuilderTrades, params, cursor)
+	return fetchPage[BuilderTrade](ctx, c, url[len(c.host):], headers)
 }
 
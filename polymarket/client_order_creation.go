@@ -1,17 +1,19 @@
 package polymarket
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"strconv"
 
 	obuilder "github.com/0xNetuser/Polymarket-golang/polymarket/order_builder"
 	"github.com/polymarket/go-order-utils/pkg/model"
+	"github.com/shopspring/decimal"
 )
 
 // ResolveTickSize 解析tick size
-func (c *ClobClient) resolveTickSize(tokenID string, tickSize *TickSize) (TickSize, error) {
-	minTickSize, err := c.GetTickSize(tokenID)
+func (c *ClobClient) resolveTickSize(ctx context.Context, tokenID string, tickSize *TickSize) (TickSize, error) {
+	minTickSize, err := c.GetTickSize(ctx, tokenID)
 	if err != nil {
 		return "", err
 	}
@@ -27,8 +29,8 @@ func (c *ClobClient) resolveTickSize(tokenID string, tickSize *TickSize) (TickSi
 }
 
 // ResolveFeeRate 解析手续费率
-func (c *ClobClient) resolveFeeRate(tokenID string, userFeeRate int) (int, error) {
-	marketFeeRateBps, err := c.GetFeeRateBps(tokenID)
+func (c *ClobClient) resolveFeeRate(ctx context.Context, tokenID string, userFeeRate int) (int, error) {
+	marketFeeRateBps, err := c.GetFeeRateBps(ctx, tokenID)
 	if err != nil {
 		return 0, err
 	}
@@ -41,10 +43,19 @@ func (c *ClobClient) resolveFeeRate(tokenID string, userFeeRate int) (int, error
 	return marketFeeRateBps, nil
 }
 
+// validatePrice 验证价格在 tick size 允许的范围内
+func validatePrice(price decimal.Decimal, tickSize TickSize) error {
+	if !priceInRange(price, tickSize) {
+		tick, _ := decimal.NewFromString(string(tickSize))
+		return fmt.Errorf("price (%s), min: %s - max: %s", price, tickSize, decimal.NewFromInt(1).Sub(tick))
+	}
+	return nil
+}
+
 // CreateOrder 创建并签名订单（限价订单）
 // 需要L1认证
 // options.RawOrder = true 时跳过从服务器获取 tick_size，但必须通过 options.TickSize 提供
-func (c *ClobClient) CreateOrder(orderArgs *OrderArgs, options *PartialCreateOrderOptions) (*SignedOrder, error) {
+func (c *ClobClient) CreateOrder(ctx context.Context, orderArgs *OrderArgs, options *PartialCreateOrderOptions) (*SignedOrder, error) {
 	if err := c.assertLevel1Auth(); err != nil {
 		return nil, err
 	}
@@ -76,7 +87,7 @@ func (c *ClobClient) CreateOrder(orderArgs *OrderArgs, options *PartialCreateOrd
 		if options != nil && options.TickSize != nil {
 			tickSizePtr = options.TickSize
 		}
-		tickSize, err = c.resolveTickSize(orderArgs.TokenID, tickSizePtr)
+		tickSize, err = c.resolveTickSize(ctx, orderArgs.TokenID, tickSizePtr)
 		if err != nil {
 			return nil, err
 		}
@@ -85,24 +96,33 @@ func (c *ClobClient) CreateOrder(orderArgs *OrderArgs, options *PartialCreateOrd
 		if options != nil && options.NegRisk != nil {
 			negRisk = *options.NegRisk
 		} else {
-			negRisk, err = c.GetNegRisk(orderArgs.TokenID)
+			negRisk, err = c.GetNegRisk(ctx, orderArgs.TokenID)
 			if err != nil {
 				return nil, err
 			}
 		}
 
 		// 解析手续费率
-		feeRateBps, err := c.resolveFeeRate(orderArgs.TokenID, orderArgs.FeeRateBps)
+		feeRateBps, err := c.resolveFeeRate(ctx, orderArgs.TokenID, orderArgs.FeeRateBps)
 		if err != nil {
 			return nil, err
 		}
 		orderArgs.FeeRateBps = feeRateBps
 	}
 
+	// 解析精确价格和数量
+	price, err := exactDecimal("price", orderArgs.PriceStr, orderArgs.Price)
+	if err != nil {
+		return nil, err
+	}
+	size, err := exactDecimal("size", orderArgs.SizeStr, orderArgs.Size)
+	if err != nil {
+		return nil, err
+	}
+
 	// 验证价格
-	if !PriceValid(orderArgs.Price, tickSize) {
-		tickSizeFloat, _ := strconv.ParseFloat(string(tickSize), 64)
-		return nil, fmt.Errorf("price (%.6f), min: %s - max: %.6f", orderArgs.Price, tickSize, 1.0-tickSizeFloat)
+	if err := validatePrice(price, tickSize); err != nil {
+		return nil, err
 	}
 
 	// 获取舍入配置
@@ -112,10 +132,10 @@ func (c *ClobClient) CreateOrder(orderArgs *OrderArgs, options *PartialCreateOrd
 	}
 
 	// 获取订单金额（带舍入）
-	side, makerAmount, takerAmount, err = c.builder.GetOrderAmounts(
+	side, makerAmount, takerAmount, err = c.builder.GetOrderAmountsDecimal(
 		orderArgs.Side,
-		orderArgs.Size,
-		orderArgs.Price,
+		size,
+		price,
 		roundConfig,
 	)
 	if err != nil {
@@ -146,7 +166,7 @@ func (c *ClobClient) CreateOrder(orderArgs *OrderArgs, options *PartialCreateOrd
 	contractConfig := getContractConfig(c.chainID, negRisk)
 
 	// 构建并签名订单
-	signedOrder, err := c.builder.BuildSignedOrder(orderData, contractConfig.Exchange, c.chainID, negRisk)
+	signedOrder, err := c.builder.BuildSignedOrder(ctx, orderData, contractConfig.Exchange, c.chainID, negRisk)
 	if err != nil {
 		return nil, err
 	}
@@ -156,7 +176,7 @@ func (c *ClobClient) CreateOrder(orderArgs *OrderArgs, options *PartialCreateOrd
 
 // CreateMarketOrder 创建并签名市价订单
 // 需要L1认证
-func (c *ClobClient) CreateMarketOrder(orderArgs *MarketOrderArgs, options *PartialCreateOrderOptions) (*SignedOrder, error) {
+func (c *ClobClient) CreateMarketOrder(ctx context.Context, orderArgs *MarketOrderArgs, options *PartialCreateOrderOptions) (*SignedOrder, error) {
 	if err := c.assertLevel1Auth(); err != nil {
 		return nil, err
 	}
@@ -166,24 +186,38 @@ func (c *ClobClient) CreateMarketOrder(orderArgs *MarketOrderArgs, options *Part
 	if options != nil && options.TickSize != nil {
 		tickSizePtr = options.TickSize
 	}
-	tickSize, err := c.resolveTickSize(orderArgs.TokenID, tickSizePtr)
+	tickSize, err := c.resolveTickSize(ctx, orderArgs.TokenID, tickSizePtr)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析精确金额和价格
+	amount, err := exactDecimal("amount", orderArgs.AmountStr, orderArgs.Amount)
+	if err != nil {
+		return nil, err
+	}
+	price, err := exactDecimal("price", orderArgs.PriceStr, orderArgs.Price)
 	if err != nil {
 		return nil, err
 	}
 
 	// 如果价格未设置或为0，计算市价
-	if orderArgs.Price <= 0 {
-		price, err := c.CalculateMarketPrice(orderArgs.TokenID, orderArgs.Side, orderArgs.Amount, orderArgs.OrderType)
+	if price.Sign() <= 0 {
+		book, err := c.GetOrderBook(ctx, orderArgs.TokenID)
+		if err != nil {
+			return nil, fmt.Errorf("no orderbook: %w", err)
+		}
+		est, err := EstimateMarketFill(book, orderArgs.Side, amount, orderArgs.OrderType)
 		if err != nil {
 			return nil, err
 		}
-		orderArgs.Price = price
+		price = est.WorstPrice
+		orderArgs.Price = price.InexactFloat64()
 	}
 
 	// 验证价格
-	if !PriceValid(orderArgs.Price, tickSize) {
-		tickSizeFloat, _ := strconv.ParseFloat(string(tickSize), 64)
-		return nil, fmt.Errorf("price (%.6f), min: %s - max: %.6f", orderArgs.Price, tickSize, 1.0-tickSizeFloat)
+	if err := validatePrice(price, tickSize); err != nil {
+		return nil, err
 	}
 
 	// 解析neg risk
@@ -191,14 +225,14 @@ func (c *ClobClient) CreateMarketOrder(orderArgs *MarketOrderArgs, options *Part
 	if options != nil && options.NegRisk != nil {
 		negRisk = *options.NegRisk
 	} else {
-		negRisk, err = c.GetNegRisk(orderArgs.TokenID)
+		negRisk, err = c.GetNegRisk(ctx, orderArgs.TokenID)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	// 解析手续费率
-	feeRateBps, err := c.resolveFeeRate(orderArgs.TokenID, orderArgs.FeeRateBps)
+	feeRateBps, err := c.resolveFeeRate(ctx, orderArgs.TokenID, orderArgs.FeeRateBps)
 	if err != nil {
 		return nil, err
 	}
@@ -211,10 +245,10 @@ func (c *ClobClient) CreateMarketOrder(orderArgs *MarketOrderArgs, options *Part
 	}
 
 	// 获取订单金额
-	side, makerAmount, takerAmount, err := c.builder.GetMarketOrderAmounts(
+	side, makerAmount, takerAmount, err := c.builder.GetMarketOrderAmountsDecimal(
 		orderArgs.Side,
-		orderArgs.Amount,
-		orderArgs.Price,
+		amount,
+		price,
 		roundConfig,
 	)
 	if err != nil {
@@ -245,7 +279,7 @@ func (c *ClobClient) CreateMarketOrder(orderArgs *MarketOrderArgs, options *Part
 	contractConfig := getContractConfig(c.chainID, negRisk)
 
 	// 构建并签名订单
-	signedOrder, err := c.builder.BuildSignedOrder(orderData, contractConfig.Exchange, c.chainID, negRisk)
+	signedOrder, err := c.builder.BuildSignedOrder(ctx, orderData, contractConfig.Exchange, c.chainID, negRisk)
 	if err != nil {
 		return nil, err
 	}
@@ -255,9 +289,16 @@ func (c *ClobClient) CreateMarketOrder(orderArgs *MarketOrderArgs, options *Part
 
 // CreateAndPostOrder 创建并提交订单（便捷方法）
 // 支持通过 options.OrderType 指定订单类型：GTC, FOK, GTD, FAK（默认 GTC）
+// 提交前先通过 ValidateOrder 校验（options.SkipValidation 可跳过），校验失败返回 ValidationErrors
 // 返回 PostOrderResult，包含原始 Payload 和 API 响应
-func (c *ClobClient) CreateAndPostOrder(orderArgs *OrderArgs, options *PartialCreateOrderOptions) (*PostOrderResult, error) {
-	order, err := c.CreateOrder(orderArgs, options)
+func (c *ClobClient) CreateAndPostOrder(ctx context.Context, orderArgs *OrderArgs, options *PartialCreateOrderOptions) (*PostOrderResult, error) {
+	if options == nil || !options.SkipValidation {
+		if err := c.ValidateOrder(ctx, orderArgs, options); err != nil {
+			return nil, err
+		}
+	}
+
+	order, err := c.CreateOrder(ctx, orderArgs, options)
 	if err != nil {
 		return nil, err
 	}
@@ -268,27 +309,22 @@ func (c *ClobClient) CreateAndPostOrder(orderArgs *OrderArgs, options *PartialCr
 		orderType = *options.OrderType
 	}
 
-	return c.PostOrder(order, orderType)
+	return c.PostOrder(ctx, order, orderType)
 }
 
-// CalculateMarketPrice 计算市价
-func (c *ClobClient) CalculateMarketPrice(tokenID, side string, amount float64, orderType OrderType) (float64, error) {
-	book, err := c.GetOrderBook(tokenID)
+// CalculateMarketPrice 计算市价（吃到的最差价位）
+// 非 FOK 订单深度不足时返回整个对手盘的最差价位；需要均价、滑点等完整信息时使用 EstimateMarketOrder
+func (c *ClobClient) CalculateMarketPrice(ctx context.Context, tokenID, side string, amount float64, orderType OrderType) (float64, error) {
+	book, err := c.GetOrderBook(ctx, tokenID)
 	if err != nil {
 		return 0, fmt.Errorf("no orderbook: %w", err)
 	}
 
-	if side == BUY {
-		if len(book.Asks) == 0 {
-			return 0, fmt.Errorf("no match")
-		}
-		return c.builder.CalculateBuyMarketPrice(ConvertOrderSummaries(book.Asks), amount, string(orderType))
-	} else {
-		if len(book.Bids) == 0 {
-			return 0, fmt.Errorf("no match")
-		}
-		return c.builder.CalculateSellMarketPrice(ConvertOrderSummaries(book.Bids), amount, string(orderType))
+	est, err := EstimateMarketFill(book, side, decimal.NewFromFloat(amount), orderType)
+	if err != nil {
+		return 0, err
 	}
+	return est.WorstPrice.InexactFloat64(), nil
 }
 
 // ConvertOrderSummaries 转换OrderSummary为order_builder.OrderSummary接口（导出函数）
@@ -0,0 +1,99 @@
+package polymarket
+
+import (
+	"context"
+	"testing"
+)
+
+// 测试用私钥（Hardhat 默认账户0，仅用于测试）
+const testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+// newOfflineClient 创建不访问网络的客户端：tick size、neg risk 和手续费率预先写入缓存
+func newOfflineClient(t *testing.T, tokenID string, tickSize TickSize) *ClobClient {
+	t.Helper()
+	client, err := NewClobClient("http://127.0.0.1:0", 137, testPrivateKey, nil, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.tickSizes[tokenID] = tickSize
+	client.negRisk[tokenID] = false
+	client.feeRates[tokenID] = 0
+	return client
+}
+
+func TestCreateOrderDecimalStrings(t *testing.T) {
+	tests := []struct {
+		name     string
+		tickSize TickSize
+		args     OrderArgs
+		maker    string
+		taker    string
+	}{
+		{"strings buy", "0.01", OrderArgs{Side: BUY, PriceStr: "0.57", SizeStr: "100"}, "57000000", "100000000"},
+		{"strings sell", "0.01", OrderArgs{Side: SELL, PriceStr: "0.78", SizeStr: "12.8205"}, "12820000", "9999600"},
+		{"strings override floats", "0.001", OrderArgs{Side: BUY, Price: 0.9, Size: 1, PriceStr: "0.056", SizeStr: "21.04"}, "1178240", "21040000"},
+		{"floats", "0.01", OrderArgs{Side: BUY, Price: 0.58, Size: 18233.33}, "10575331400", "18233330000"},
+		{"mixed", "0.0001", OrderArgs{Side: SELL, Price: 0.0056, SizeStr: "21.04"}, "21040000", "117824"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newOfflineClient(t, "123", tt.tickSize)
+			args := tt.args
+			args.TokenID = "123"
+			negRisk := false
+			order, err := client.CreateOrder(context.Background(), &args, &PartialCreateOrderOptions{TickSize: &tt.tickSize, NegRisk: &negRisk, RawOrder: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if order.MakerAmount.String() != tt.maker || order.TakerAmount.String() != tt.taker {
+				t.Errorf("maker/taker = %s/%s, want %s/%s", order.MakerAmount, order.TakerAmount, tt.maker, tt.taker)
+			}
+		})
+	}
+}
+
+func TestCreateOrderInvalidDecimalString(t *testing.T) {
+	client := newOfflineClient(t, "123", "0.01")
+	tickSize, negRisk := TickSize("0.01"), false
+	opts := &PartialCreateOrderOptions{TickSize: &tickSize, NegRisk: &negRisk, RawOrder: true}
+	for _, args := range []OrderArgs{
+		{TokenID: "123", Side: BUY, PriceStr: "0.5x", SizeStr: "1"},
+		{TokenID: "123", Side: BUY, PriceStr: "0.5", SizeStr: "one"},
+	} {
+		if _, err := client.CreateOrder(context.Background(), &args, opts); err == nil {
+			t.Errorf("CreateOrder(%+v): want error", args)
+		}
+	}
+}
+
+func TestCreateMarketOrderDecimalStrings(t *testing.T) {
+	tests := []struct {
+		name     string
+		tickSize TickSize
+		args     MarketOrderArgs
+		maker    string
+		taker    string
+	}{
+		{"buy strings", "0.01", MarketOrderArgs{Side: BUY, AmountStr: "100", PriceStr: "0.56"}, "100000000", "178571400"},
+		{"buy strings 0.0001", "0.0001", MarketOrderArgs{Side: BUY, AmountStr: "100", PriceStr: "0.0056"}, "100000000", "17857142857"},
+		{"sell strings", "0.01", MarketOrderArgs{Side: SELL, AmountStr: "100", PriceStr: "0.57"}, "100000000", "57000000"},
+		{"strings override floats", "0.1", MarketOrderArgs{Side: BUY, Amount: 1, Price: 0.9, AmountStr: "100", PriceStr: "0.5"}, "100000000", "200000000"},
+		{"floats", "0.001", MarketOrderArgs{Side: BUY, Amount: 100, Price: 0.056}, "100000000", "1785714280"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newOfflineClient(t, "123", tt.tickSize)
+			args := tt.args
+			args.TokenID = "123"
+			args.OrderType = OrderTypeFOK
+			negRisk := false
+			order, err := client.CreateMarketOrder(context.Background(), &args, &PartialCreateOrderOptions{TickSize: &tt.tickSize, NegRisk: &negRisk})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if order.MakerAmount.String() != tt.maker || order.TakerAmount.String() != tt.taker {
+				t.Errorf("maker/taker = %s/%s, want %s/%s", order.MakerAmount, order.TakerAmount, tt.maker, tt.taker)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package polymarket
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -8,12 +9,13 @@ import (
 // PostOrder 提交订单
 // 需要L2认证
 // 返回 PostOrderResult，包含原始 Payload 和 API 响应
-func (c *ClobClient) PostOrder(order *SignedOrder, orderType OrderType) (*PostOrderResult, error) {
+func (c *ClobClient) PostOrder(ctx context.Context, order *SignedOrder, orderType OrderType) (*PostOrderResult, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
 
-	body := OrderToJSON(order, c.creds.APIKey, orderType)
+	creds := c.apiCreds()
+	body := OrderToJSON(order, creds.APIKey, orderType)
 	bodyJSON, err := json.Marshal(body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal order: %w", err)
@@ -27,12 +29,12 @@ func (c *ClobClient) PostOrder(order *SignedOrder, orderType OrderType) (*PostOr
 		SerializedBody: &bodyStr,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, creds, requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.httpClient.Post(PostOrder, headers, bodyStr)
+	resp, err := c.httpClient.Post(ctx, PostOrder, headers, bodyStr)
 	if err != nil {
 		return nil, err
 	}
@@ -46,14 +48,15 @@ func (c *ClobClient) PostOrder(order *SignedOrder, orderType OrderType) (*PostOr
 // PostOrders 批量提交订单
 // 需要L2认证
 // 返回 PostOrdersResult，包含原始 Payload 和 API 响应
-func (c *ClobClient) PostOrders(args []PostOrdersArgs) (*PostOrdersResult, error) {
+func (c *ClobClient) PostOrders(ctx context.Context, args []PostOrdersArgs) (*PostOrdersResult, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
 
+	creds := c.apiCreds()
 	body := make([]map[string]interface{}, len(args))
 	for i, arg := range args {
-		body[i] = OrderToJSON(arg.Order, c.creds.APIKey, arg.OrderType)
+		body[i] = OrderToJSON(arg.Order, creds.APIKey, arg.OrderType)
 	}
 
 	bodyJSON, err := json.Marshal(body)
@@ -69,12 +72,12 @@ func (c *ClobClient) PostOrders(args []PostOrdersArgs) (*PostOrdersResult, error
 		SerializedBody: &bodyStr,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, creds, requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.httpClient.Post(PostOrders, headers, bodyStr)
+	resp, err := c.httpClient.Post(ctx, PostOrders, headers, bodyStr)
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +90,7 @@ func (c *ClobClient) PostOrders(args []PostOrdersArgs) (*PostOrdersResult, error
 
 // Cancel 取消订单
 // 需要L2认证
-func (c *ClobClient) Cancel(orderID string) (interface{}, error) {
+func (c *ClobClient) Cancel(ctx context.Context, orderID string) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -106,17 +109,17 @@ func (c *ClobClient) Cancel(orderID string) (interface{}, error) {
 		SerializedBody: &bodyStr,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.httpClient.Delete(Cancel, headers, bodyStr)
+	return c.httpClient.Delete(ctx, Cancel, headers, bodyStr)
 }
 
 // CancelOrders 批量取消订单
 // 需要L2认证
-func (c *ClobClient) CancelOrders(orderIDs []string) (interface{}, error) {
+func (c *ClobClient) CancelOrders(ctx context.Context, orderIDs []string) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -134,17 +137,17 @@ func (c *ClobClient) CancelOrders(orderIDs []string) (interface{}, error) {
 		SerializedBody: &bodyStr,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.httpClient.Delete(CancelOrders, headers, bodyStr)
+	return c.httpClient.Delete(ctx, CancelOrders, headers, bodyStr)
 }
 
 // CancelAll 取消所有订单
 // 需要L2认证
-func (c *ClobClient) CancelAll() (interface{}, error) {
+func (c *ClobClient) CancelAll(ctx context.Context) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -154,17 +157,17 @@ func (c *ClobClient) CancelAll() (interface{}, error) {
 		RequestPath: CancelAll,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.httpClient.Delete(CancelAll, headers, nil)
+	return c.httpClient.Delete(ctx, CancelAll, headers, nil)
 }
 
 // CancelMarketOrders 取消市场订单
 // 需要L2认证
-func (c *ClobClient) CancelMarketOrders(market, assetID string) (interface{}, error) {
+func (c *ClobClient) CancelMarketOrders(ctx context.Context, market, assetID string) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -186,65 +189,48 @@ func (c *ClobClient) CancelMarketOrders(market, assetID string) (interface{}, er
 		SerializedBody: &bodyStr,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.httpClient.Delete(CancelMarketOrders, headers, bodyStr)
+	return c.httpClient.Delete(ctx, CancelMarketOrders, headers, bodyStr)
 }
 
 // GetOrders 获取订单列表
+// 从 nextCursor 开始一直读取到最后一页；大量数据请使用 IterOrders / IterOrderPages
 // 需要L2认证
-func (c *ClobClient) GetOrders(params *OpenOrderParams, nextCursor string) ([]interface{}, error) {
+func (c *ClobClient) GetOrders(ctx context.Context, params *OpenOrderParams, nextCursor string) ([]OpenOrder, error) {
+	return collectPages(c.IterOrderPages(ctx, params, nextCursor))
+}
+
+// getOrdersPage 获取一页订单
+func (c *ClobClient) getOrdersPage(ctx context.Context, params *OpenOrderParams, cursor string) (*Page[OpenOrder], error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
 
-	if nextCursor == "" {
-		nextCursor = "MA=="
-	}
-
 	requestArgs := &RequestArgs{
 		Method:      "GET",
 		RequestPath: Orders,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	// 每页重新生成headers，避免长时间迭代时时间戳过期
+	headers, err := CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	var results []interface{}
-	for nextCursor != EndCursor {
-		url := AddQueryOpenOrdersParams(c.host+Orders, params, nextCursor)
-		resp, err := c.httpClient.Get(url[len(c.host):], headers)
-		if err != nil {
-			return nil, err
-		}
-
-		respMap, ok := resp.(map[string]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid response format")
-		}
-
-		if cursor, ok := respMap["next_cursor"].(string); ok {
-			nextCursor = cursor
-		} else {
-			nextCursor = EndCursor
-		}
-
-		if data, ok := respMap["data"].([]interface{}); ok {
-			results = append(results, data...)
-		}
+	if params == nil {
+		params = &OpenOrderParams{}
 	}
-
-	return results, nil
+	url := AddQueryOpenOrdersParams(c.host+Orders, params, cursor)
+	return fetchPage[OpenOrder](ctx, c, url[len(c.host):], headers)
 }
 
 // GetOrder 获取单个订单
 // 需要L2认证
-func (c *ClobClient) GetOrder(orderID string) (interface{}, error) {
+func (c *ClobClient) GetOrder(ctx context.Context, orderID string) (*OpenOrder, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -255,65 +241,55 @@ func (c *ClobClient) GetOrder(orderID string) (interface{}, error) {
 		RequestPath: endpoint,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.httpClient.Get(endpoint, headers)
+	var order *OpenOrder
+	if err := c.httpClient.GetInto(ctx, endpoint, headers, &order); err != nil {
+		return nil, err
+	}
+	// 订单不存在时服务器返回 null
+	if order == nil {
+		return nil, fmt.Errorf("order %s: %w", orderID, ErrNotFound)
+	}
+	return order, nil
 }
 
 // GetTrades 获取交易历史
+// 从 nextCursor 开始一直读取到最后一页；大量数据请使用 IterTrades / IterTradePages
 // 需要L2认证
-func (c *ClobClient) GetTrades(params *TradeParams, nextCursor string) ([]interface{}, error) {
+func (c *ClobClient) GetTrades(ctx context.Context, params *TradeParams, nextCursor string) ([]Trade, error) {
+	return collectPages(c.IterTradePages(ctx, params, nextCursor))
+}
+
+// getTradesPage 获取一页成交记录
+func (c *ClobClient) getTradesPage(ctx context.Context, params *TradeParams, cursor string) (*Page[Trade], error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
 
-	if nextCursor == "" {
-		nextCursor = "MA=="
-	}
-
 	requestArgs := &RequestArgs{
 		Method:      "GET",
 		RequestPath: Trades,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	var results []interface{}
-	for nextCursor != EndCursor {
-		url := AddQueryTradeParams(c.host+Trades, params, nextCursor)
-		resp, err := c.httpClient.Get(url[len(c.host):], headers)
-		if err != nil {
-			return nil, err
-		}
-
-		respMap, ok := resp.(map[string]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid response format")
-		}
-
-		if cursor, ok := respMap["next_cursor"].(string); ok {
-			nextCursor = cursor
-		} else {
-			nextCursor = EndCursor
-		}
-
-		if data, ok := respMap["data"].([]interface{}); ok {
-			results = append(results, data...)
-		}
+	if params == nil {
+		params = &TradeParams{}
 	}
-
-	return results, nil
+	url := AddQueryTradeParams(c.host+Trades, params, cursor)
+	return fetchPage[Trade](ctx, c, url[len(c.host):], headers)
 }
 
 // GetBalanceAllowance 获取余额和授权
 // 需要L2认证
-func (c *ClobClient) GetBalanceAllowance(params *BalanceAllowanceParams) (map[string]interface{}, error) {
+func (c *ClobClient) GetBalanceAllowance(ctx context.Context, params *BalanceAllowanceParams) (map[string]interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -336,12 +312,12 @@ func (c *ClobClient) GetBalanceAllowance(params *BalanceAllowanceParams) (map[st
 		RequestPath: GetBalanceAllowance,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.httpClient.Get(url[len(c.host):], headers)
+	resp, err := c.httpClient.Get(ctx, url[len(c.host):], headers)
 	if err != nil {
 		return nil, err
 	}
@@ -356,7 +332,7 @@ func (c *ClobClient) GetBalanceAllowance(params *BalanceAllowanceParams) (map[st
 
 // GetNotifications 获取通知
 // 需要L2认证
-func (c *ClobClient) GetNotifications() (interface{}, error) {
+func (c *ClobClient) GetNotifications(ctx context.Context) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -372,17 +348,17 @@ func (c *ClobClient) GetNotifications() (interface{}, error) {
 		RequestPath: GetNotifications,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.httpClient.Get(url, headers)
+	return c.httpClient.Get(ctx, url, headers)
 }
 
 // DropNotifications 删除通知
 // 需要L2认证
-func (c *ClobClient) DropNotifications(params *DropNotificationParams) (interface{}, error) {
+func (c *ClobClient) DropNotifications(ctx context.Context, params *DropNotificationParams) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -393,11 +369,11 @@ func (c *ClobClient) DropNotifications(params *DropNotificationParams) (interfac
 		RequestPath: DropNotifications,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	headers, err := CreateLevel2Headers(c.signer, c.apiCreds(), requestArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.httpClient.Delete(url[len(c.host):], headers, nil)
+	return c.httpClient.Delete(ctx, url[len(c.host):], headers, nil)
 }
 
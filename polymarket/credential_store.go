@@ -0,0 +1,304 @@
+package polymarket
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"golang.org/x/crypto/scrypt"
+)
+
+// ErrCredentialsNotFound 凭证存储中没有对应的凭证
+var ErrCredentialsNotFound = errors.New("credentials not found")
+
+// CredentialStore API凭证存储，按签名者地址和 nonce 保存 L2 凭证
+// CreateAPIKey / DeriveAPIKey 成功后写入，CreateOrDeriveAPIKey 优先从中读取，
+// 重启后可以复用同一组凭证而不必每次重新创建或派生
+type CredentialStore interface {
+	// Load 读取凭证，不存在时返回 ErrCredentialsNotFound
+	Load(ctx context.Context, address string, nonce int) (*ApiCreds, error)
+	// Save 保存凭证（覆盖已有的同名凭证）
+	Save(ctx context.Context, address string, nonce int, creds *ApiCreds) error
+	// Delete 删除凭证，不存在时不返回错误
+	Delete(ctx context.Context, address string, nonce int) error
+}
+
+// credentialKey 存储键：小写地址 + nonce
+func credentialKey(address string, nonce int) string {
+	return strings.ToLower(address) + ":" + strconv.Itoa(nonce)
+}
+
+// MemoryCredentialStore 进程内的凭证存储（不持久化，适合测试或短期进程）
+type MemoryCredentialStore struct {
+	mu    sync.RWMutex
+	creds map[string]ApiCreds
+}
+
+// NewMemoryCredentialStore 创建内存凭证存储
+func NewMemoryCredentialStore() *MemoryCredentialStore {
+	return &MemoryCredentialStore{creds: make(map[string]ApiCreds)}
+}
+
+// Load 读取凭证
+func (s *MemoryCredentialStore) Load(_ context.Context, address string, nonce int) (*ApiCreds, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	creds, ok := s.creds[credentialKey(address, nonce)]
+	if !ok {
+		return nil, ErrCredentialsNotFound
+	}
+	return &creds, nil
+}
+
+// Save 保存凭证
+func (s *MemoryCredentialStore) Save(_ context.Context, address string, nonce int, creds *ApiCreds) error {
+	if creds == nil {
+		return fmt.Errorf("credentials are nil")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.creds[credentialKey(address, nonce)] = *creds
+	return nil
+}
+
+// Delete 删除凭证
+func (s *MemoryCredentialStore) Delete(_ context.Context, address string, nonce int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.creds, credentialKey(address, nonce))
+	return nil
+}
+
+// 文件存储的 scrypt 参数
+const (
+	credentialScryptN = 1 << 15
+	credentialScryptR = 8
+	credentialScryptP = 1
+)
+
+// encryptedCredentialFile 加密凭证文件的格式
+type encryptedCredentialFile struct {
+	Version    int           `json:"version"`
+	KDF        string        `json:"kdf"`
+	N          int           `json:"n"`
+	R          int           `json:"r"`
+	P          int           `json:"p"`
+	Salt       hexutil.Bytes `json:"salt"`
+	Nonce      hexutil.Bytes `json:"nonce"`
+	Ciphertext hexutil.Bytes `json:"ciphertext"`
+}
+
+// FileCredentialStore 加密的文件凭证存储
+// 所有凭证保存在一个文件中，使用 scrypt 从密码派生密钥、AES-256-GCM 加密；
+// 文件权限为 0600，写入时先写临时文件再重命名，避免中途崩溃损坏已有凭证
+type FileCredentialStore struct {
+	path       string
+	passphrase []byte
+
+	mu   sync.Mutex
+	salt []byte
+	key  []byte
+}
+
+// NewFileCredentialStore 创建加密文件凭证存储
+// 文件不存在时在第一次 Save 时创建；文件已存在时会立即用密码解密校验
+func NewFileCredentialStore(path, passphrase string) (*FileCredentialStore, error) {
+	if path == "" {
+		return nil, fmt.Errorf("credential store path is required")
+	}
+	if passphrase == "" {
+		return nil, fmt.Errorf("credential store passphrase is required")
+	}
+	s := &FileCredentialStore{path: path, passphrase: []byte(passphrase)}
+	if _, err := s.readAll(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Load 读取凭证
+func (s *FileCredentialStore) Load(_ context.Context, address string, nonce int) (*ApiCreds, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all, err := s.readAll()
+	if err != nil {
+		return nil, err
+	}
+	creds, ok := all[credentialKey(address, nonce)]
+	if !ok {
+		return nil, ErrCredentialsNotFound
+	}
+	return &creds, nil
+}
+
+// Save 保存凭证
+func (s *FileCredentialStore) Save(_ context.Context, address string, nonce int, creds *ApiCreds) error {
+	if creds == nil {
+		return fmt.Errorf("credentials are nil")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all, err := s.readAll()
+	if err != nil {
+		return err
+	}
+	all[credentialKey(address, nonce)] = *creds
+	return s.writeAll(all)
+}
+
+// Delete 删除凭证
+func (s *FileCredentialStore) Delete(_ context.Context, address string, nonce int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all, err := s.readAll()
+	if err != nil {
+		return err
+	}
+	key := credentialKey(address, nonce)
+	if _, ok := all[key]; !ok {
+		return nil
+	}
+	delete(all, key)
+	return s.writeAll(all)
+}
+
+// readAll 读取并解密全部凭证，文件不存在时返回空集合
+func (s *FileCredentialStore) readAll() (map[string]ApiCreds, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return make(map[string]ApiCreds), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credential store: %w", err)
+	}
+
+	var file encryptedCredentialFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid credential store %s: %w", s.path, err)
+	}
+	if file.Version != 1 || file.KDF != "scrypt" {
+		return nil, fmt.Errorf("unsupported credential store version %d (kdf %q)", file.Version, file.KDF)
+	}
+
+	key, err := s.deriveKey(file.Salt, file.N, file.R, file.P)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid credential store nonce")
+	}
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt credential store (wrong passphrase?): %w", err)
+	}
+
+	all := make(map[string]ApiCreds)
+	if err := json.Unmarshal(plaintext, &all); err != nil {
+		return nil, fmt.Errorf("invalid credential store contents: %w", err)
+	}
+	return all, nil
+}
+
+// writeAll 加密并原子写入全部凭证
+func (s *FileCredentialStore) writeAll(all map[string]ApiCreds) error {
+	if s.key == nil {
+		salt := make([]byte, 32)
+		if _, err := rand.Read(salt); err != nil {
+			return err
+		}
+		if _, err := s.deriveKey(salt, credentialScryptN, credentialScryptR, credentialScryptP); err != nil {
+			return err
+		}
+	}
+
+	plaintext, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(s.key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(encryptedCredentialFile{
+		Version:    1,
+		KDF:        "scrypt",
+		N:          credentialScryptN,
+		R:          credentialScryptR,
+		P:          credentialScryptP,
+		Salt:       s.salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to write credential store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write credential store: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write credential store: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write credential store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write credential store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write credential store: %w", err)
+	}
+	return nil
+}
+
+// deriveKey 从密码派生加密密钥，同一个 salt 只计算一次
+func (s *FileCredentialStore) deriveKey(salt []byte, n, r, p int) ([]byte, error) {
+	if s.key != nil && string(s.salt) == string(salt) {
+		return s.key, nil
+	}
+	if n != credentialScryptN || r != credentialScryptR || p != credentialScryptP {
+		return nil, fmt.Errorf("unsupported scrypt parameters n=%d r=%d p=%d", n, r, p)
+	}
+	key, err := scrypt.Key(s.passphrase, salt, n, r, p, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive credential store key: %w", err)
+	}
+	s.salt, s.key = salt, key
+	return key, nil
+}
+
+// newGCM 创建 AES-256-GCM
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,196 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const testStoreAddress = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+
+func newTestFileStore(t *testing.T, path, passphrase string) *FileCredentialStore {
+	t.Helper()
+	store, err := NewFileCredentialStore(path, passphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestFileCredentialStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "creds.json")
+	store := newTestFileStore(t, path, "correct horse")
+
+	if _, err := store.Load(ctx, testStoreAddress, 0); !errors.Is(err, ErrCredentialsNotFound) {
+		t.Fatalf("Load from missing file = %v, want ErrCredentialsNotFound", err)
+	}
+
+	creds := testOldCreds
+	if err := store.Save(ctx, testStoreAddress, 0, &creds); err != nil {
+		t.Fatal(err)
+	}
+	next := testNewCreds
+	if err := store.Save(ctx, testStoreAddress, 1, &next); err != nil {
+		t.Fatal(err)
+	}
+
+	// 新实例从文件解密读取；地址大小写不敏感
+	reopened := newTestFileStore(t, path, "correct horse")
+	got, err := reopened.Load(ctx, strings.ToLower(testStoreAddress), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *got != testOldCreds {
+		t.Errorf("Load(nonce 0) = %+v, want %+v", *got, testOldCreds)
+	}
+	if got, err := reopened.Load(ctx, testStoreAddress, 1); err != nil || *got != testNewCreds {
+		t.Errorf("Load(nonce 1) = %+v, %v, want %+v", got, err, testNewCreds)
+	}
+
+	// 文件中不包含明文凭证
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{testOldCreds.APIKey, testOldCreds.APISecret, testOldCreds.APIPassphrase} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("store file contains plaintext %q", secret)
+		}
+	}
+}
+
+func TestFileCredentialStoreWrongPassphrase(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "creds.json")
+	creds := testOldCreds
+	if err := newTestFileStore(t, path, "correct horse").Save(ctx, testStoreAddress, 0, &creds); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewFileCredentialStore(path, "battery staple")
+	if err == nil || store != nil {
+		t.Fatalf("NewFileCredentialStore with wrong passphrase = %v, %v, want error", store, err)
+	}
+	if !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Errorf("error = %v, want wrong passphrase hint", err)
+	}
+}
+
+func TestFileCredentialStorePermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not enforced on Windows")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "creds.json")
+	creds := testOldCreds
+	if err := newTestFileStore(t, path, "correct horse").Save(context.Background(), testStoreAddress, 0, &creds); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o600 {
+		t.Errorf("file mode = %o, want 600", mode)
+	}
+	// 临时文件已被重命名或清理
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory contains %d entries, want only the store file", len(entries))
+	}
+}
+
+func TestFileCredentialStoreCorruptFile(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	garbage := filepath.Join(dir, "garbage.json")
+	if err := os.WriteFile(garbage, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileCredentialStore(garbage, "correct horse"); err == nil {
+		t.Error("NewFileCredentialStore with non-JSON file: want error")
+	}
+
+	// 密文被篡改：GCM 认证失败
+	path := filepath.Join(dir, "creds.json")
+	store := newTestFileStore(t, path, "correct horse")
+	creds := testOldCreds
+	if err := store.Save(ctx, testStoreAddress, 0, &creds); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := strings.Index(string(data), `"ciphertext": "0x`) + len(`"ciphertext": "0x`)
+	flipped := byte('0')
+	if data[i] == '0' {
+		flipped = '1'
+	}
+	data[i] = flipped
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := store.Load(ctx, testStoreAddress, 0); err == nil {
+		t.Errorf("Load from tampered file = %+v, want error", got)
+	}
+	if err := store.Save(ctx, testStoreAddress, 1, &creds); err == nil {
+		t.Error("Save over tampered file: want error instead of overwriting")
+	}
+
+	unsupported := filepath.Join(dir, "v2.json")
+	if err := os.WriteFile(unsupported, []byte(`{"version":2,"kdf":"scrypt"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileCredentialStore(unsupported, "correct horse"); err == nil {
+		t.Error("NewFileCredentialStore with unsupported version: want error")
+	}
+}
+
+func TestFileCredentialStoreDelete(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "creds.json")
+	store := newTestFileStore(t, path, "correct horse")
+	creds, next := testOldCreds, testNewCreds
+	if err := store.Save(ctx, testStoreAddress, 0, &creds); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(ctx, testStoreAddress, 1, &next); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Delete(ctx, testStoreAddress, 0); err != nil {
+		t.Fatal(err)
+	}
+	// 删除不存在的凭证不报错
+	if err := store.Delete(ctx, testStoreAddress, 7); err != nil {
+		t.Errorf("Delete missing entry = %v, want nil", err)
+	}
+
+	reopened := newTestFileStore(t, path, "correct horse")
+	if _, err := reopened.Load(ctx, testStoreAddress, 0); !errors.Is(err, ErrCredentialsNotFound) {
+		t.Errorf("Load deleted entry = %v, want ErrCredentialsNotFound", err)
+	}
+	if got, err := reopened.Load(ctx, testStoreAddress, 1); err != nil || *got != testNewCreds {
+		t.Errorf("Load remaining entry = %+v, %v, want %+v", got, err, testNewCreds)
+	}
+}
+
+func TestNewFileCredentialStoreArguments(t *testing.T) {
+	if _, err := NewFileCredentialStore("", "pass"); err == nil {
+		t.Error("empty path: want error")
+	}
+	if _, err := NewFileCredentialStore(filepath.Join(t.TempDir(), "creds.json"), ""); err == nil {
+		t.Error("empty passphrase: want error")
+	}
+}
@@ -0,0 +1,126 @@
+package polymarket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// 错误分类哨兵，配合 errors.Is 使用
+var (
+	ErrRateLimited         = errors.New("rate limited")
+	ErrInsufficientBalance = errors.New("insufficient balance or allowance")
+	ErrAuth                = errors.New("authentication failed")
+	ErrNotFound            = errors.New("not found")
+	ErrServerError         = errors.New("server error")
+)
+
+// APIError API错误
+// 由 HTTPClient（CLOB、RFQ）以及 web3 包中的中继请求返回
+type APIError struct {
+	StatusCode int           // HTTP状态码
+	Message    string        // 从JSON响应体中解析出的错误信息
+	Body       string        // 原始响应体
+	Method     string        // 请求方法
+	Path       string        // 请求路径
+	Retryable  bool          // 是否可以重试（429 和 5xx）
+	RetryAfter time.Duration // 服务器返回的 Retry-After（如果有）
+}
+
+// NewAPIError 根据HTTP响应创建APIError
+func NewAPIError(method, path string, statusCode int, body []byte) *APIError {
+	return &APIError{
+		StatusCode: statusCode,
+		Message:    parseErrorMessage(body),
+		Body:       string(body),
+		Method:     method,
+		Path:       path,
+		Retryable:  isRetryableStatus(statusCode),
+	}
+}
+
+// Error 实现 error 接口
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = e.Body
+	}
+	return fmt.Sprintf("%s %s: API returned status %d: %s", e.Method, e.Path, e.StatusCode, msg)
+}
+
+// Is 将错误映射到分类哨兵，使 errors.Is(err, ErrRateLimited) 等判断可用
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrInsufficientBalance:
+		return e.StatusCode == http.StatusBadRequest && isInsufficientBalanceMessage(e.Message)
+	case ErrAuth:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrServerError:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// IsRateLimited 是否被限流（429）
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// IsInsufficientBalance 是否余额或授权不足
+func IsInsufficientBalance(err error) bool {
+	return errors.Is(err, ErrInsufficientBalance)
+}
+
+// IsAuthError 是否认证失败（401/403）
+func IsAuthError(err error) bool {
+	return errors.Is(err, ErrAuth)
+}
+
+// IsNotFound 是否资源不存在（404）
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsRetryable 错误是否可以重试
+func IsRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable
+	}
+	return false
+}
+
+// parseErrorMessage 从JSON响应体中提取错误信息
+// CLOB 返回 {"error": "..."}，中继和部分端点使用 errorMsg/message
+func parseErrorMessage(body []byte) string {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return strings.TrimSpace(string(body))
+	}
+	for _, key := range []string{"error", "errorMsg", "message"} {
+		if v, ok := payload[key].(string); ok && v != "" {
+			return v
+		}
+	}
+	return strings.TrimSpace(string(body))
+}
+
+// isRetryableStatus 429 和 5xx 被视为临时错误
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// isInsufficientBalanceMessage 匹配CLOB返回的余额/授权不足错误信息
+func isInsufficientBalanceMessage(msg string) bool {
+	msg = strings.ToLower(msg)
+	return strings.Contains(msg, "not enough balance") ||
+		strings.Contains(msg, "allowance") ||
+		strings.Contains(msg, "insufficient")
+}
@@ -0,0 +1,96 @@
+package polymarket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	sentinels := []error{ErrRateLimited, ErrInsufficientBalance, ErrAuth, ErrNotFound, ErrServerError}
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		want      error // nil 表示不匹配任何哨兵
+		retryable bool
+	}{
+		{"rate limited", http.StatusTooManyRequests, `{"error":"Too Many Requests"}`, ErrRateLimited, true},
+		{"not enough balance", http.StatusBadRequest, `{"error":"not enough balance / allowance"}`, ErrInsufficientBalance, false},
+		{"allowance", http.StatusBadRequest, `{"error":"invalid allowance for spender"}`, ErrInsufficientBalance, false},
+		{"insufficient", http.StatusBadRequest, `{"errorMsg":"Insufficient funds"}`, ErrInsufficientBalance, false},
+		{"plain text balance", http.StatusBadRequest, "not enough balance", ErrInsufficientBalance, false},
+		{"other bad request", http.StatusBadRequest, `{"error":"invalid order"}`, nil, false},
+		{"balance message on 500", http.StatusInternalServerError, `{"error":"insufficient"}`, ErrServerError, true},
+		{"unauthorized", http.StatusUnauthorized, `{"error":"Unauthorized/Invalid api key"}`, ErrAuth, false},
+		{"forbidden", http.StatusForbidden, `{"message":"forbidden"}`, ErrAuth, false},
+		{"not found", http.StatusNotFound, `{"error":"market not found"}`, ErrNotFound, false},
+		{"internal", http.StatusInternalServerError, `{"error":"internal"}`, ErrServerError, true},
+		{"bad gateway", http.StatusBadGateway, "<html>bad gateway</html>", ErrServerError, true},
+		{"unavailable", http.StatusServiceUnavailable, "", ErrServerError, true},
+		{"conflict", http.StatusConflict, `{"error":"conflict"}`, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := NewAPIError(http.MethodPost, PostOrder, tt.status, []byte(tt.body))
+			if apiErr.Retryable != tt.retryable {
+				t.Errorf("Retryable = %v, want %v", apiErr.Retryable, tt.retryable)
+			}
+			// 包装后同样可以匹配
+			err := fmt.Errorf("post order: %w", apiErr)
+			for _, sentinel := range sentinels {
+				if got := errors.Is(err, sentinel); got != (sentinel == tt.want) {
+					t.Errorf("errors.Is(%v) = %v, want %v", sentinel, got, sentinel == tt.want)
+				}
+			}
+			if IsRetryable(err) != tt.retryable {
+				t.Errorf("IsRetryable = %v, want %v", IsRetryable(err), tt.retryable)
+			}
+		})
+	}
+}
+
+func TestAPIErrorHelpers(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", NewAPIError(http.MethodGet, GetOrderBook, http.StatusTooManyRequests, nil))
+	if !IsRateLimited(err) || IsAuthError(err) || IsNotFound(err) || IsInsufficientBalance(err) {
+		t.Errorf("429 helpers: rate=%v auth=%v notFound=%v balance=%v", IsRateLimited(err), IsAuthError(err), IsNotFound(err), IsInsufficientBalance(err))
+	}
+	if !IsAuthError(NewAPIError(http.MethodGet, GetAPIKeys, http.StatusUnauthorized, nil)) {
+		t.Error("IsAuthError(401) = false")
+	}
+	if !IsNotFound(NewAPIError(http.MethodGet, GetOrder+"1", http.StatusNotFound, nil)) {
+		t.Error("IsNotFound(404) = false")
+	}
+	if !IsInsufficientBalance(NewAPIError(http.MethodPost, PostOrder, http.StatusBadRequest, []byte(`{"error":"not enough balance"}`))) {
+		t.Error("IsInsufficientBalance(400 balance) = false")
+	}
+	if IsRetryable(errors.New("plain")) || IsRateLimited(nil) {
+		t.Error("non-API errors must not match")
+	}
+}
+
+func TestNewAPIErrorMessage(t *testing.T) {
+	tests := []struct {
+		body    string
+		message string
+	}{
+		{`{"error":"invalid signature"}`, "invalid signature"},
+		{`{"errorMsg":"relayer rejected"}`, "relayer rejected"},
+		{`{"message":"bad nonce"}`, "bad nonce"},
+		{`{"error":"","message":"fallback"}`, "fallback"},
+		{`{"code":1}`, `{"code":1}`},
+		{"  upstream timeout\n", "upstream timeout"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := NewAPIError(http.MethodGet, Time, http.StatusBadRequest, []byte(tt.body)).Message; got != tt.message {
+			t.Errorf("Message for %q = %q, want %q", tt.body, got, tt.message)
+		}
+	}
+
+	err := NewAPIError(http.MethodDelete, CancelAll, http.StatusBadRequest, []byte(`{"error":"nothing to cancel"}`))
+	if want := "DELETE /cancel-all: API returned status 400: nothing to cancel"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
@@ -2,6 +2,7 @@ package polymarket
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,10 +10,16 @@ import (
 	"time"
 )
 
+// DefaultUserAgent 默认 User-Agent
+const DefaultUserAgent = "polymarket-sdk-go"
+
 // HTTPClient HTTP客户端
 type HTTPClient struct {
-	client  *http.Client
-	baseURL string
+	client      *http.Client
+	baseURL     string
+	retryPolicy *RetryPolicy
+	rateLimiter RateLimiter // 默认为 nil，不限流
+	userAgent   string
 }
 
 // NewHTTPClient 创建新的HTTP客户端
@@ -21,19 +28,66 @@ func NewHTTPClient(baseURL string) *HTTPClient {
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		baseURL: baseURL,
+		baseURL:     baseURL,
+		retryPolicy: DefaultRetryPolicy(),
+		userAgent:   DefaultUserAgent,
 	}
 }
 
+// SetRetryPolicy 设置重试策略（nil 表示不重试）
+func (c *HTTPClient) SetRetryPolicy(policy *RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+// SetRateLimiter 设置限流器（nil 表示不限流）
+func (c *HTTPClient) SetRateLimiter(limiter RateLimiter) {
+	c.rateLimiter = limiter
+}
+
+// RateLimiter 返回当前的限流器
+func (c *HTTPClient) RateLimiter() RateLimiter {
+	return c.rateLimiter
+}
+
 // Request 发送HTTP请求
-func (c *HTTPClient) Request(method, path string, headers map[string]string, body interface{}) (interface{}, error) {
-	url := c.baseURL + path
+// ctx 用于控制单次请求的超时和取消
+// 临时错误（429/5xx、连接重置）按照重试策略进行重试
+func (c *HTTPClient) Request(ctx context.Context, method, path string, headers map[string]string, body interface{}) (interface{}, error) {
+	respBody, err := c.requestRaw(ctx, method, path, headers, body)
+	if err != nil {
+		return nil, err
+	}
 
-	var reqBody io.Reader
+	// 尝试解析JSON
+	var jsonData interface{}
+	if err := json.Unmarshal(respBody, &jsonData); err != nil {
+		// 如果不是JSON，返回原始字符串
+		return string(respBody), nil
+	}
+
+	return jsonData, nil
+}
+
+// RequestInto 发送HTTP请求并将JSON响应体直接解码到 out
+// 与 Request 不同，响应不经过 interface{} 中转，数字按原始文本解码到目标字段，不会因 float64 丢失精度
+func (c *HTTPClient) RequestInto(ctx context.Context, method, path string, headers map[string]string, body interface{}, out interface{}) error {
+	respBody, err := c.requestRaw(ctx, method, path, headers, body)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("invalid response format: %w", err)
+	}
+	return nil
+}
+
+// requestRaw 发送HTTP请求（按重试策略重试），返回原始响应体
+func (c *HTTPClient) requestRaw(ctx context.Context, method, path string, headers map[string]string, body interface{}) ([]byte, error) {
+	var bodyBytes []byte
 	if body != nil {
 		if bodyStr, ok := body.(string); ok {
 			// 预序列化的body（用于HMAC签名，保持一致性）
-			reqBody = bytes.NewBufferString(bodyStr)
+			bodyBytes = []byte(bodyStr)
 		} else {
 			// JSON序列化（紧凑格式，无空格）
 			// 参考: https://github.com/Polymarket/py-clob-client/issues/164
@@ -42,17 +96,63 @@ func (c *HTTPClient) Request(method, path string, headers map[string]string, bod
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal body: %w", err)
 			}
-			reqBody = bytes.NewBuffer(jsonData)
+			bodyBytes = jsonData
 		}
 	}
 
-	req, err := http.NewRequest(method, url, reqBody)
+	policy := c.retryPolicy
+	if !policy.canRetry(method, path) {
+		return c.doRequest(ctx, method, path, headers, body != nil, bodyBytes)
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			delay, ok := policy.backoff(attempt-1, retryAfterOf(lastErr))
+			if !ok {
+				// 服务器要求等待的时间超过上限，交给调用方处理
+				break
+			}
+			if err := sleepContext(ctx, delay); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := c.doRequest(ctx, method, path, headers, body != nil, bodyBytes)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		if !isRetryableError(ctx, err) {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
+// doRequest 发送单次HTTP请求，返回原始响应体
+func (c *HTTPClient) doRequest(ctx context.Context, method, path string, headers map[string]string, hasBody bool, bodyBytes []byte) ([]byte, error) {
+	// 客户端限流（每次尝试都需要令牌，包括重试）
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx, ClassifyEndpoint(method, path)); err != nil {
+			return nil, err
+		}
+	}
+
+	url := c.baseURL + path
+
+	var reqBody io.Reader
+	if hasBody {
+		reqBody = bytes.NewReader(bodyBytes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// 设置默认headers
-	req.Header.Set("User-Agent", "polymarket-sdk-go")
+	req.Header.Set("User-Agent", c.userAgent)
 	req.Header.Set("Accept", "*/*")
 	req.Header.Set("Connection", "keep-alive")
 	req.Header.Set("Content-Type", "application/json")
@@ -75,35 +175,40 @@ func (c *HTTPClient) Request(method, path string, headers map[string]string, bod
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(respBody))
-	}
-
-	// 尝试解析JSON
-	var jsonData interface{}
-	if err := json.Unmarshal(respBody, &jsonData); err != nil {
-		// 如果不是JSON，返回原始字符串
-		return string(respBody), nil
+		apiErr := NewAPIError(method, path, resp.StatusCode, respBody)
+		apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		return nil, apiErr
 	}
 
-	return jsonData, nil
+	return respBody, nil
 }
 
 // Get 发送GET请求
-func (c *HTTPClient) Get(path string, headers map[string]string) (interface{}, error) {
-	return c.Request("GET", path, headers, nil)
+func (c *HTTPClient) Get(ctx context.Context, path string, headers map[string]string) (interface{}, error) {
+	return c.Request(ctx, "GET", path, headers, nil)
 }
 
 // Post 发送POST请求
-func (c *HTTPClient) Post(path string, headers map[string]string, body interface{}) (interface{}, error) {
-	return c.Request("POST", path, headers, body)
+func (c *HTTPClient) Post(ctx context.Context, path string, headers map[string]string, body interface{}) (interface{}, error) {
+	return c.Request(ctx, "POST", path, headers, body)
+}
+
+// GetInto 发送GET请求并将响应直接解码到 out
+func (c *HTTPClient) GetInto(ctx context.Context, path string, headers map[string]string, out interface{}) error {
+	return c.RequestInto(ctx, "GET", path, headers, nil, out)
+}
+
+// PostInto 发送POST请求并将响应直接解码到 out
+func (c *HTTPClient) PostInto(ctx context.Context, path string, headers map[string]string, body interface{}, out interface{}) error {
+	return c.RequestInto(ctx, "POST", path, headers, body, out)
 }
 
 // Delete 发送DELETE请求
-func (c *HTTPClient) Delete(path string, headers map[string]string, body interface{}) (interface{}, error) {
-	return c.Request("DELETE", path, headers, body)
+func (c *HTTPClient) Delete(ctx context.Context, path string, headers map[string]string, body interface{}) (interface{}, error) {
+	return c.Request(ctx, "DELETE", path, headers, body)
 }
 
 // Put 发送PUT请求
-func (c *HTTPClient) Put(path string, headers map[string]string, body interface{}) (interface{}, error) {
-	return c.Request("PUT", path, headers, body)
+func (c *HTTPClient) Put(ctx context.Context, path string, headers map[string]string, body interface{}) (interface{}, error) {
+	return c.Request(ctx, "PUT", path, headers, body)
 }
@@ -0,0 +1,61 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newStaticClient 创建访问固定响应服务器的客户端
+func newStaticClient(t *testing.T, body string) *ClobClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	client, err := NewClobClientWithOptions(server.URL, 137, testPrivateKey, WithRetryPolicy(NoRetryPolicy()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestGetIntoKeepsNumberPrecision(t *testing.T) {
+	// 超出 float64 精度的数字，经过 interface{} 中转会被截断
+	client := newStaticClient(t, `{"mid": 0.12345678901234567890123}`)
+	mid, err := client.GetMidpoint(context.Background(), "123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mid.Mid.String(); got != "0.12345678901234567890123" {
+		t.Errorf("mid = %s, want 0.12345678901234567890123", got)
+	}
+
+	client = newStaticClient(t, `{"123": "0.5", "456": 98765432109876543210.5}`)
+	mids, err := client.GetMidpoints(context.Background(), []BookParams{{TokenID: "123"}, {TokenID: "456"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mids["123"].String() != "0.5" || mids["456"].String() != "98765432109876543210.5" {
+		t.Errorf("midpoints = %v", mids)
+	}
+}
+
+func TestGetIntoInvalidResponse(t *testing.T) {
+	client := newStaticClient(t, `not json`)
+	if _, err := client.GetMidpoint(context.Background(), "123"); err == nil {
+		t.Fatal("GetMidpoint with non-JSON body: want error")
+	}
+}
+
+func TestGetOrderNull(t *testing.T) {
+	old := testOldCreds
+	client := newStaticClient(t, `null`)
+	client.SetAPICreds(&old)
+	if _, err := client.GetOrder(context.Background(), "0xabc"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetOrder = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,190 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrNoMatch 订单簿深度不足以成交（FOK 无法全部成交，或对手盘为空）
+var ErrNoMatch = errors.New("no match")
+
+// FillLevel 市价单在某个价位上的成交
+type FillLevel struct {
+	Price decimal.Decimal
+	Size  decimal.Decimal // 在该价位成交的份额
+	Cost  decimal.Decimal // Price * Size（USDC）
+}
+
+// FillEstimate 市价单按订单簿逐档成交的估算结果
+type FillEstimate struct {
+	Side      string
+	OrderType OrderType
+	Requested decimal.Decimal // 请求数量，BUY 为 USDC 金额，SELL 为份额
+
+	Levels       []FillLevel     // 吃掉的价位，从最优价开始
+	FilledSize   decimal.Decimal // 成交份额
+	TotalCost    decimal.Decimal // 成交金额（USDC），BUY 为支付金额，SELL 为获得金额
+	AveragePrice decimal.Decimal // 成交均价 TotalCost / FilledSize
+	WorstPrice   decimal.Decimal // 吃到的最差价位，可作为市价单的限价
+
+	Mid         decimal.NullDecimal // 下单前的中点价格，任一侧为空时无效
+	Slippage    decimal.NullDecimal // 均价相对中点的不利偏移（BUY: 均价-中点，SELL: 中点-均价）
+	SlippageBps decimal.NullDecimal // Slippage / Mid，单位基点
+
+	Partial bool // 深度不足，只能部分成交（仅 FAK/GTC 等非 FOK 订单）
+}
+
+// Unfilled 未成交的数量，单位与 Requested 相同
+func (e *FillEstimate) Unfilled() decimal.Decimal {
+	filled := e.FilledSize
+	if strings.EqualFold(e.Side, BUY) {
+		filled = e.TotalCost
+	}
+	if remaining := e.Requested.Sub(filled); remaining.Sign() > 0 {
+		return remaining
+	}
+	return decimal.Zero
+}
+
+// EstimateMarketFill 在订单簿上模拟市价单成交
+// BUY 时 amount 为 USDC 金额，依次吃卖盘；SELL 时 amount 为份额，依次吃买盘。
+// FOK 深度不足时返回 ErrNoMatch；其他订单类型返回部分成交结果（Partial 为 true）
+func EstimateMarketFill(book *OrderBookSummary, side string, amount decimal.Decimal, orderType OrderType) (*FillEstimate, error) {
+	if amount.Sign() <= 0 {
+		return nil, fmt.Errorf("amount must be positive, got %s", amount)
+	}
+	isBuy := strings.EqualFold(side, BUY)
+	if !isBuy && !strings.EqualFold(side, SELL) {
+		return nil, fmt.Errorf("invalid side: %s", side)
+	}
+
+	bids, err := bookLevels(book.Bids, true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bids: %w", err)
+	}
+	asks, err := bookLevels(book.Asks, false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid asks: %w", err)
+	}
+	levels := bids
+	if isBuy {
+		levels = asks
+	}
+	if len(levels) == 0 {
+		return nil, fmt.Errorf("%w: empty %s side", ErrNoMatch, oppositeSideName(isBuy))
+	}
+
+	est := &FillEstimate{
+		Side:      strings.ToUpper(side),
+		OrderType: orderType,
+		Requested: amount,
+	}
+
+	remaining := amount
+	for _, level := range levels {
+		if remaining.Sign() <= 0 {
+			break
+		}
+		size := level.Size
+		cost := size.Mul(level.Price)
+		switch {
+		case isBuy && remaining.LessThan(cost):
+			// 剩余金额在该价位用完：花掉全部剩余金额，避免 Div 的舍入误差留下残余
+			cost = remaining
+			size = remaining.Div(level.Price)
+			remaining = decimal.Zero
+		case isBuy:
+			remaining = remaining.Sub(cost)
+		case remaining.LessThan(size):
+			size = remaining
+			cost = size.Mul(level.Price)
+			remaining = decimal.Zero
+		default:
+			remaining = remaining.Sub(size)
+		}
+
+		est.Levels = append(est.Levels, FillLevel{Price: level.Price, Size: size, Cost: cost})
+		est.FilledSize = est.FilledSize.Add(size)
+		est.TotalCost = est.TotalCost.Add(cost)
+		est.WorstPrice = level.Price
+	}
+
+	if remaining.Sign() > 0 {
+		if orderType == OrderTypeFOK {
+			return nil, fmt.Errorf("%w: FOK order needs %s but book only fills %s", ErrNoMatch, amount, amount.Sub(remaining))
+		}
+		est.Partial = true
+	}
+	est.AveragePrice = est.TotalCost.Div(est.FilledSize)
+
+	if len(bids) > 0 && len(asks) > 0 {
+		mid := bids[0].Price.Add(asks[0].Price).Div(decimal.NewFromInt(2))
+		slippage := est.AveragePrice.Sub(mid)
+		if !isBuy {
+			slippage = slippage.Neg()
+		}
+		est.Mid = decimal.NewNullDecimal(mid)
+		est.Slippage = decimal.NewNullDecimal(slippage)
+		est.SlippageBps = decimal.NewNullDecimal(slippage.Div(mid).Mul(decimal.NewFromInt(10000)))
+	}
+	return est, nil
+}
+
+// EstimateMarketOrder 获取最新订单簿并估算市价单的成交情况，可在 CreateMarketOrder 之前展示实际成本
+func (c *ClobClient) EstimateMarketOrder(ctx context.Context, orderArgs *MarketOrderArgs) (*FillEstimate, error) {
+	amount, err := exactDecimal("amount", orderArgs.AmountStr, orderArgs.Amount)
+	if err != nil {
+		return nil, err
+	}
+	book, err := c.GetOrderBook(ctx, orderArgs.TokenID)
+	if err != nil {
+		return nil, fmt.Errorf("no orderbook: %w", err)
+	}
+	orderType := orderArgs.OrderType
+	if orderType == "" {
+		orderType = OrderTypeFOK
+	}
+	return EstimateMarketFill(book, orderArgs.Side, amount, orderType)
+}
+
+// EstimateMarketFill 在本地订单簿上模拟市价单成交，参见 EstimateMarketFill
+func (b *OrderBook) EstimateMarketFill(side string, amount decimal.Decimal, orderType OrderType) (*FillEstimate, error) {
+	return EstimateMarketFill(b.Summary(), side, amount, orderType)
+}
+
+// bookLevels 解析价位并按从优到劣排序（买盘价格降序，卖盘价格升序）
+func bookLevels(summaries []OrderSummary, bids bool) ([]PriceLevel, error) {
+	levels := make([]PriceLevel, 0, len(summaries))
+	for _, s := range summaries {
+		price, err := decimal.NewFromString(s.Price)
+		if err != nil {
+			return nil, fmt.Errorf("price %q: %w", s.Price, err)
+		}
+		size, err := decimal.NewFromString(s.Size)
+		if err != nil {
+			return nil, fmt.Errorf("size %q: %w", s.Size, err)
+		}
+		if price.Sign() > 0 && size.Sign() > 0 {
+			levels = append(levels, PriceLevel{Price: price, Size: size})
+		}
+	}
+	sort.SliceStable(levels, func(i, j int) bool {
+		if bids {
+			return levels[i].Price.GreaterThan(levels[j].Price)
+		}
+		return levels[i].Price.LessThan(levels[j].Price)
+	})
+	return levels, nil
+}
+
+func oppositeSideName(isBuy bool) string {
+	if isBuy {
+		return "ask"
+	}
+	return "bid"
+}
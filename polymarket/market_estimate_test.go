@@ -0,0 +1,112 @@
+package polymarket
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func testBook(bids, asks [][2]string) *OrderBookSummary {
+	book := &OrderBookSummary{}
+	for _, l := range bids {
+		book.Bids = append(book.Bids, OrderSummary{Price: l[0], Size: l[1]})
+	}
+	for _, l := range asks {
+		book.Asks = append(book.Asks, OrderSummary{Price: l[0], Size: l[1]})
+	}
+	return book
+}
+
+func TestEstimateMarketFill(t *testing.T) {
+	bids := [][2]string{{"0.25", "10"}, {"0.28", "5"}}
+	asks := [][2]string{{"0.90", "100"}, {"0.30", "10"}}
+
+	tests := []struct {
+		name      string
+		book      *OrderBookSummary
+		side      string
+		amount    string
+		orderType OrderType
+
+		wantErr    error
+		wantFilled string
+		wantCost   string
+		wantWorst  string
+		wantLevels int
+		partial    bool
+	}{
+		// BUY：amount 为 USDC
+		{name: "buy FOK within first level", book: testBook(bids, asks), side: BUY, amount: "1", orderType: OrderTypeFOK,
+			wantFilled: "3.3333333333333333", wantCost: "1", wantWorst: "0.3", wantLevels: 1},
+		{name: "buy FOK single level book", book: testBook(nil, [][2]string{{"0.30", "10"}}), side: BUY, amount: "1", orderType: OrderTypeFOK,
+			wantFilled: "3.3333333333333333", wantCost: "1", wantWorst: "0.3", wantLevels: 1},
+		{name: "buy FOK exact level", book: testBook(bids, asks), side: BUY, amount: "3", orderType: OrderTypeFOK,
+			wantFilled: "10", wantCost: "3", wantWorst: "0.3", wantLevels: 1},
+		{name: "buy FOK across levels", book: testBook(bids, asks), side: BUY, amount: "12", orderType: OrderTypeFOK,
+			wantFilled: "20", wantCost: "12", wantWorst: "0.9", wantLevels: 2},
+		{name: "buy FOK exact book", book: testBook(bids, asks), side: BUY, amount: "93", orderType: OrderTypeFOK,
+			wantFilled: "110", wantCost: "93", wantWorst: "0.9", wantLevels: 2},
+		{name: "buy FOK insufficient", book: testBook(bids, asks), side: BUY, amount: "94", orderType: OrderTypeFOK,
+			wantErr: ErrNoMatch},
+		{name: "buy FAK partial", book: testBook(bids, asks), side: BUY, amount: "94", orderType: OrderTypeFAK,
+			wantFilled: "110", wantCost: "93", wantWorst: "0.9", wantLevels: 2, partial: true},
+		{name: "buy FAK exact level", book: testBook(bids, asks), side: BUY, amount: "3", orderType: OrderTypeFAK,
+			wantFilled: "10", wantCost: "3", wantWorst: "0.3", wantLevels: 1},
+		{name: "buy FOK empty book", book: testBook(bids, nil), side: BUY, amount: "1", orderType: OrderTypeFOK,
+			wantErr: ErrNoMatch},
+		{name: "buy FAK empty book", book: testBook(bids, nil), side: BUY, amount: "1", orderType: OrderTypeFAK,
+			wantErr: ErrNoMatch},
+
+		// SELL：amount 为份额
+		{name: "sell FOK within first level", book: testBook(bids, asks), side: SELL, amount: "2", orderType: OrderTypeFOK,
+			wantFilled: "2", wantCost: "0.56", wantWorst: "0.28", wantLevels: 1},
+		{name: "sell FOK exact level", book: testBook(bids, asks), side: SELL, amount: "5", orderType: OrderTypeFOK,
+			wantFilled: "5", wantCost: "1.4", wantWorst: "0.28", wantLevels: 1},
+		{name: "sell FOK exact book", book: testBook(bids, asks), side: SELL, amount: "15", orderType: OrderTypeFOK,
+			wantFilled: "15", wantCost: "3.9", wantWorst: "0.25", wantLevels: 2},
+		{name: "sell FOK insufficient", book: testBook(bids, asks), side: SELL, amount: "16", orderType: OrderTypeFOK,
+			wantErr: ErrNoMatch},
+		{name: "sell FAK partial", book: testBook(bids, asks), side: SELL, amount: "16", orderType: OrderTypeFAK,
+			wantFilled: "15", wantCost: "3.9", wantWorst: "0.25", wantLevels: 2, partial: true},
+		{name: "sell FAK exact level", book: testBook(bids, asks), side: SELL, amount: "5", orderType: OrderTypeFAK,
+			wantFilled: "5", wantCost: "1.4", wantWorst: "0.28", wantLevels: 1},
+		{name: "sell FOK empty book", book: testBook(nil, asks), side: SELL, amount: "1", orderType: OrderTypeFOK,
+			wantErr: ErrNoMatch},
+		{name: "sell FAK empty book", book: testBook(nil, asks), side: SELL, amount: "1", orderType: OrderTypeFAK,
+			wantErr: ErrNoMatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			est, err := EstimateMarketFill(tt.book, tt.side, decimal.RequireFromString(tt.amount), tt.orderType)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !est.FilledSize.Equal(decimal.RequireFromString(tt.wantFilled)) {
+				t.Errorf("FilledSize = %s, want %s", est.FilledSize, tt.wantFilled)
+			}
+			if !est.TotalCost.Equal(decimal.RequireFromString(tt.wantCost)) {
+				t.Errorf("TotalCost = %s, want %s", est.TotalCost, tt.wantCost)
+			}
+			if !est.WorstPrice.Equal(decimal.RequireFromString(tt.wantWorst)) {
+				t.Errorf("WorstPrice = %s, want %s", est.WorstPrice, tt.wantWorst)
+			}
+			if len(est.Levels) != tt.wantLevels {
+				t.Errorf("len(Levels) = %d, want %d", len(est.Levels), tt.wantLevels)
+			}
+			if est.Partial != tt.partial {
+				t.Errorf("Partial = %v, want %v", est.Partial, tt.partial)
+			}
+			if !tt.partial && est.Unfilled().Sign() != 0 {
+				t.Errorf("Unfilled = %s, want 0", est.Unfilled())
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package polymarket
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// MidpointResponse 中点价格（GET /midpoint）
+type MidpointResponse struct {
+	Mid decimal.Decimal `json:"mid"`
+}
+
+// Midpoints 多个token的中点价格，按 token ID 索引（POST /midpoints）
+type Midpoints map[string]decimal.Decimal
+
+// PriceResponse 单边市场价格（GET /price）
+type PriceResponse struct {
+	Price decimal.Decimal `json:"price"`
+}
+
+// SidePrices 单个token的买卖价格
+// 只请求了一边时，另一边的 Valid 为 false
+type SidePrices struct {
+	Buy  decimal.NullDecimal `json:"BUY"`
+	Sell decimal.NullDecimal `json:"SELL"`
+}
+
+// Prices 多个token的市场价格，按 token ID 索引（POST /prices）
+type Prices map[string]SidePrices
+
+// SpreadResponse 价差（GET /spread）
+type SpreadResponse struct {
+	Spread decimal.Decimal `json:"spread"`
+}
+
+// Spreads 多个token的价差，按 token ID 索引（POST /spreads）
+type Spreads map[string]decimal.Decimal
+
+// LastTradePrice 最后成交价格（GET /last-trade-price）
+type LastTradePrice struct {
+	TokenID string          `json:"token_id,omitempty"`
+	Price   decimal.Decimal `json:"price"`
+	Side    string          `json:"side"`
+}
+
+// LastTradesPrices 多个token的最后成交价格，按 token ID 索引（POST /last-trades-prices）
+type LastTradesPrices map[string]LastTradePrice
+
+// Page 游标分页结果
+type Page[T any] struct {
+	Data       []T    `json:"data"`
+	NextCursor string `json:"next_cursor"` // 下一页游标，EndCursor 表示没有更多数据
+	Limit      int    `json:"limit"`
+	Count      int    `json:"count"`
+}
+
+// HasNext 是否还有下一页
+func (p *Page[T]) HasNext() bool {
+	return p.NextCursor != "" && p.NextCursor != EndCursor
+}
+
+// Token 市场中的结果代币
+type Token struct {
+	TokenID string          `json:"token_id"`
+	Outcome string          `json:"outcome"`
+	Price   decimal.Decimal `json:"price"`
+	Winner  bool            `json:"winner"`
+}
+
+// RewardRate 流动性奖励费率
+type RewardRate struct {
+	AssetAddress     string          `json:"asset_address"`
+	RewardsDailyRate decimal.Decimal `json:"rewards_daily_rate"`
+}
+
+// Rewards 流动性奖励配置
+type Rewards struct {
+	Rates     []RewardRate    `json:"rates"`
+	MinSize   decimal.Decimal `json:"min_size"`
+	MaxSpread decimal.Decimal `json:"max_spread"`
+}
+
+// Market 市场（GetMarkets / GetSamplingMarkets / GetMarket）
+type Market struct {
+	ConditionID             string          `json:"condition_id"`
+	QuestionID              string          `json:"question_id"`
+	Question                string          `json:"question"`
+	Description             string          `json:"description"`
+	MarketSlug              string          `json:"market_slug"`
+	Tokens                  []Token         `json:"tokens"`
+	Rewards                 Rewards         `json:"rewards"`
+	MinimumOrderSize        decimal.Decimal `json:"minimum_order_size"`
+	MinimumTickSize         decimal.Decimal `json:"minimum_tick_size"`
+	MakerBaseFee            decimal.Decimal `json:"maker_base_fee"`
+	TakerBaseFee            decimal.Decimal `json:"taker_base_fee"`
+	NegRisk                 bool            `json:"neg_risk"`
+	NegRiskMarketID         string          `json:"neg_risk_market_id"`
+	NegRiskRequestID        string          `json:"neg_risk_request_id"`
+	EndDate                 Timestamp       `json:"end_date_iso"`
+	GameStartTime           Timestamp       `json:"game_start_time"`
+	AcceptingOrderTimestamp Timestamp       `json:"accepting_order_timestamp"`
+	SecondsDelay            int             `json:"seconds_delay"`
+	FPMM                    string          `json:"fpmm"`
+	Active                  bool            `json:"active"`
+	Closed                  bool            `json:"closed"`
+	Archived                bool            `json:"archived"`
+	AcceptingOrders         bool            `json:"accepting_orders"`
+	EnableOrderBook         bool            `json:"enable_order_book"`
+	NotificationsEnabled    bool            `json:"notifications_enabled"`
+	Is5050Outcome           bool            `json:"is_50_50_outcome"`
+	Icon                    string          `json:"icon"`
+	Image                   string          `json:"image"`
+	Tags                    []string        `json:"tags"`
+}
+
+// TickSize 返回 TickSize 类型的最小价格变动
+func (m *Market) TickSize() TickSize {
+	return TickSize(m.MinimumTickSize.String())
+}
+
+// SimplifiedMarket 简化市场（GetSimplifiedMarkets / GetSamplingSimplifiedMarkets）
+type SimplifiedMarket struct {
+	ConditionID     string  `json:"condition_id"`
+	Tokens          []Token `json:"tokens"`
+	Rewards         Rewards `json:"rewards"`
+	Active          bool    `json:"active"`
+	Closed          bool    `json:"closed"`
+	Archived        bool    `json:"archived"`
+	AcceptingOrders bool    `json:"accepting_orders"`
+}
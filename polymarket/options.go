@@ -0,0 +1,140 @@
+package polymarket
+
+import (
+	"net/http"
+	"time"
+)
+
+// ClientOption ClobClient 构造选项
+type ClientOption func(*clientOptions)
+
+// clientOptions 构造选项集合
+type clientOptions struct {
+	creds         *ApiCreds
+	signatureType *int
+	funder        string
+	signer        SignerBackend
+	credStore     CredentialStore
+
+	httpClient *http.Client
+	transport  http.RoundTripper
+	timeout    *time.Duration
+	userAgent  string
+
+	retryPolicy    *RetryPolicy
+	retryPolicySet bool
+	rateLimiter    RateLimiter
+	rateLimiterSet bool
+}
+
+// WithAPICreds 设置API凭证（L2认证）
+func WithAPICreds(creds *ApiCreds) ClientOption {
+	return func(o *clientOptions) {
+		o.creds = creds
+	}
+}
+
+// WithSignatureType 设置签名类型（0=EOA, 1=Email/Magic, 2=Browser proxy）
+func WithSignatureType(signatureType int) ClientOption {
+	return func(o *clientOptions) {
+		o.signatureType = &signatureType
+	}
+}
+
+// WithFunder 设置资金持有者地址（用于代理钱包）
+func WithFunder(funder string) ClientOption {
+	return func(o *clientOptions) {
+		o.funder = funder
+	}
+}
+
+// WithSigner 使用自定义签名后端（如 RemoteSigner）代替私钥，此时 privateKey 参数必须为空
+func WithSigner(backend SignerBackend) ClientOption {
+	return func(o *clientOptions) {
+		o.signer = backend
+	}
+}
+
+// WithCredentialStore 设置API凭证存储
+// CreateAPIKey / DeriveAPIKey 成功后把凭证写入存储，CreateOrDeriveAPIKey 优先读取已保存的凭证
+func WithCredentialStore(store CredentialStore) ClientOption {
+	return func(o *clientOptions) {
+		o.credStore = store
+	}
+}
+
+// WithHTTPClient 使用自定义的 http.Client（代理、mTLS、连接池等）
+// 与 WithTransport/WithTimeout 同时使用时，会在该客户端的副本上修改，不影响调用方的实例
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(o *clientOptions) {
+		o.httpClient = client
+	}
+}
+
+// WithTransport 设置底层的 http.RoundTripper
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(o *clientOptions) {
+		o.transport = transport
+	}
+}
+
+// WithTimeout 设置HTTP请求超时（默认30秒，0 表示不超时）
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.timeout = &timeout
+	}
+}
+
+// WithUserAgent 设置 User-Agent 请求头
+func WithUserAgent(userAgent string) ClientOption {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithRetryPolicy 设置重试策略（nil 表示不重试）
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(o *clientOptions) {
+		o.retryPolicy = policy
+		o.retryPolicySet = true
+	}
+}
+
+// WithRateLimiter 设置客户端限流器（默认不限流）
+func WithRateLimiter(limiter RateLimiter) ClientOption {
+	return func(o *clientOptions) {
+		o.rateLimiter = limiter
+		o.rateLimiterSet = true
+	}
+}
+
+// buildHTTPClient 根据选项创建 HTTPClient
+func (o *clientOptions) buildHTTPClient(baseURL string) *HTTPClient {
+	c := NewHTTPClient(baseURL)
+
+	if o.httpClient != nil {
+		if o.transport != nil || o.timeout != nil {
+			clone := *o.httpClient
+			c.client = &clone
+		} else {
+			c.client = o.httpClient
+		}
+	}
+	if o.transport != nil {
+		c.client.Transport = o.transport
+	}
+	if o.timeout != nil {
+		c.client.Timeout = *o.timeout
+	}
+	if o.userAgent != "" {
+		c.userAgent = o.userAgent
+	}
+	if o.retryPolicySet {
+		c.retryPolicy = o.retryPolicy
+	}
+	if o.rateLimiterSet {
+		c.rateLimiter = o.rateLimiter
+	}
+
+	return c
+}
@@ -0,0 +1,221 @@
+package polymarket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewClobClientWithOptions(t *testing.T) {
+	var userAgent atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent.Store(r.Header.Get("User-Agent"))
+		w.Write([]byte(`"OK"`))
+	}))
+	defer server.Close()
+
+	creds := testOldCreds
+	store := NewMemoryCredentialStore()
+	policy := &RetryPolicy{MaxAttempts: 7}
+	limiter := &recordingLimiter{}
+	transport := &http.Transport{}
+	client, err := NewClobClientWithOptions(server.URL+"/", 137, testPrivateKey,
+		WithAPICreds(&creds),
+		WithSignatureType(SignatureTypeBrowser),
+		WithFunder(testFunder),
+		WithCredentialStore(store),
+		WithTransport(transport),
+		WithTimeout(5*time.Second),
+		WithUserAgent("options-test/1.0"),
+		WithRetryPolicy(policy),
+		WithRateLimiter(limiter),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if client.GetHost() != server.URL {
+		t.Errorf("host = %s, want %s without trailing slash", client.GetHost(), server.URL)
+	}
+	if client.authMode() != L2 || client.apiCreds() != &creds {
+		t.Errorf("mode = %d, creds = %p, want L2 with %p", client.authMode(), client.apiCreds(), &creds)
+	}
+	if client.GetAddress() != testSignerAddress {
+		t.Errorf("address = %s, want %s", client.GetAddress(), testSignerAddress)
+	}
+	if client.builder.GetSigType() != SignatureTypeBrowser || client.builder.GetFunder() != testFunder {
+		t.Errorf("builder sigType/funder = %d/%s, want %d/%s", client.builder.GetSigType(), client.builder.GetFunder(), SignatureTypeBrowser, testFunder)
+	}
+	if client.credStore != store {
+		t.Error("credential store not applied")
+	}
+	hc := client.httpClient
+	if hc.client.Transport != transport || hc.client.Timeout != 5*time.Second {
+		t.Errorf("transport/timeout = %v/%s, want custom transport and 5s", hc.client.Transport, hc.client.Timeout)
+	}
+	if hc.retryPolicy != policy || hc.RateLimiter() != limiter {
+		t.Error("retry policy or rate limiter not applied")
+	}
+
+	if _, err := client.GetOK(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := userAgent.Load(); got != "options-test/1.0" {
+		t.Errorf("User-Agent = %v, want options-test/1.0", got)
+	}
+	if len(limiter.groups) != 1 {
+		t.Errorf("rate limiter called %d times, want 1", len(limiter.groups))
+	}
+}
+
+func TestNewClobClientWithOptionsDefaults(t *testing.T) {
+	client, err := NewClobClientWithOptions("http://127.0.0.1:0", 137, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.authMode() != L0 || client.signer != nil || client.builder != nil {
+		t.Errorf("mode = %d, want L0 without signer", client.authMode())
+	}
+	hc := client.httpClient
+	if hc.userAgent != DefaultUserAgent || hc.client.Timeout != 30*time.Second || hc.RateLimiter() != nil {
+		t.Errorf("defaults: userAgent=%s timeout=%s limiter=%v", hc.userAgent, hc.client.Timeout, hc.RateLimiter())
+	}
+	if hc.retryPolicy == nil {
+		t.Error("default retry policy not set")
+	}
+
+	// 显式传入 nil 关闭重试
+	client, err = NewClobClientWithOptions("http://127.0.0.1:0", 137, "", WithRetryPolicy(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.httpClient.retryPolicy != nil {
+		t.Error("WithRetryPolicy(nil) did not disable retries")
+	}
+}
+
+func TestNewClobClientWithOptionsSigner(t *testing.T) {
+	backend, err := NewPrivateKeySigner(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := NewClobClientWithOptions("http://127.0.0.1:0", 137, "", WithSigner(backend))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.authMode() != L1 || client.GetAddress() != testSignerAddress {
+		t.Errorf("mode = %d, address = %s, want L1 with %s", client.authMode(), client.GetAddress(), testSignerAddress)
+	}
+	// 未设置 funder 时使用签名地址
+	if client.builder.GetSigType() != SignatureTypeEOA || client.builder.GetFunder() != testSignerAddress {
+		t.Errorf("builder sigType/funder = %d/%s", client.builder.GetSigType(), client.builder.GetFunder())
+	}
+
+	if _, err := NewClobClientWithOptions("http://127.0.0.1:0", 137, testPrivateKey, WithSigner(backend)); err == nil {
+		t.Error("privateKey together with WithSigner: want error")
+	}
+}
+
+func TestWithHTTPClientIsNotModified(t *testing.T) {
+	custom := &http.Client{Timeout: time.Minute}
+	client, err := NewClobClientWithOptions("http://127.0.0.1:0", 137, "", WithHTTPClient(custom))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.httpClient.client != custom {
+		t.Error("WithHTTPClient alone: want the caller's client")
+	}
+
+	// 与 WithTimeout 同时使用时修改副本
+	client, err = NewClobClientWithOptions("http://127.0.0.1:0", 137, "", WithHTTPClient(custom), WithTimeout(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.httpClient.client == custom || client.httpClient.client.Timeout != time.Second || custom.Timeout != time.Minute {
+		t.Errorf("timeout = %s, caller timeout = %s, want a modified copy", client.httpClient.client.Timeout, custom.Timeout)
+	}
+}
+
+func TestAPICredsConcurrentSwap(t *testing.T) {
+	client, err := NewClobClientWithOptions("http://127.0.0.1:0", 137, testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldCreds, newCreds := testOldCreds, testNewCreds
+	client.SetAPICreds(&oldCreds)
+
+	const workers = 8
+	var wg sync.WaitGroup
+	var swapped atomic.Int32
+	stop := make(chan struct{})
+
+	// 读取方始终看到一致的凭证和模式
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				creds := client.apiCreds()
+				if creds != &oldCreds && creds != &newCreds {
+					t.Errorf("apiCreds = %p, want old or new creds", creds)
+					return
+				}
+				if key := client.GetAPICreds(); key != oldCreds.APIKey && key != newCreds.APIKey {
+					t.Errorf("GetAPICreds = %q", key)
+					return
+				}
+				if mode := client.authMode(); mode != L2 {
+					t.Errorf("authMode = %d, want L2", mode)
+					return
+				}
+			}
+		}()
+	}
+
+	// 多个轮换同时从旧凭证替换，只有一个成功
+	var swappers sync.WaitGroup
+	for range workers {
+		swappers.Add(1)
+		go func() {
+			defer swappers.Done()
+			if client.swapAPICreds(&oldCreds, &newCreds) {
+				swapped.Add(1)
+			}
+		}()
+	}
+	swappers.Wait()
+	if n := swapped.Load(); n != 1 {
+		t.Errorf("%d swaps succeeded, want exactly 1", n)
+	}
+	if client.apiCreds() != &newCreds {
+		t.Error("creds not swapped to new")
+	}
+
+	// 来回替换与读取并发进行
+	for i := range 1000 {
+		from, to := &newCreds, &oldCreds
+		if i%2 == 1 {
+			from, to = to, from
+		}
+		if !client.swapAPICreds(from, to) {
+			t.Errorf("swap %d failed", i)
+			break
+		}
+	}
+	close(stop)
+	wg.Wait()
+
+	client.SetAPICreds(nil)
+	if client.authMode() != L1 || client.apiCreds() != nil {
+		t.Errorf("after SetAPICreds(nil): mode = %d, want L1", client.authMode())
+	}
+}
@@ -1,9 +1,10 @@
 package order_builder
 
 import (
-	"math"
-	"strconv"
+	"math/big"
 	"strings"
+
+	"github.com/shopspring/decimal"
 )
 
 // RoundConfig 舍入配置
@@ -21,37 +22,94 @@ var RoundingConfig = map[string]RoundConfig{
 	"0.0001": {Price: 4, Size: 2, Amount: 6},
 }
 
+// tokenDecimals 代币精度（USDC 和条件代币均为6位）
+const tokenDecimals = 6
+
+// RoundDownDecimal 向下舍入到 sigDigits 位小数
+func RoundDownDecimal(x decimal.Decimal, sigDigits int) decimal.Decimal {
+	return x.RoundFloor(int32(sigDigits))
+}
+
+// RoundNormalDecimal 四舍六入五取偶（与 Python round() 一致）
+func RoundNormalDecimal(x decimal.Decimal, sigDigits int) decimal.Decimal {
+	return x.RoundBank(int32(sigDigits))
+}
+
+// RoundUpDecimal 向上舍入到 sigDigits 位小数
+func RoundUpDecimal(x decimal.Decimal, sigDigits int) decimal.Decimal {
+	return x.RoundCeil(int32(sigDigits))
+}
+
+// ToTokenDecimalsDecimal 转换为代币最小单位（6位小数）
+func ToTokenDecimalsDecimal(x decimal.Decimal) *big.Int {
+	f := x.Shift(tokenDecimals)
+	if DecimalPlacesDecimal(f) > 0 {
+		f = RoundNormalDecimal(f, 0)
+	}
+	return f.BigInt()
+}
+
+// DecimalPlacesDecimal 计算小数位数（忽略末尾的0）
+func DecimalPlacesDecimal(x decimal.Decimal) int {
+	s := x.String()
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
 // RoundDown 向下舍入
+//
+// Deprecated: float64 无法精确表示大部分小数，请使用 RoundDownDecimal
 func RoundDown(x float64, sigDigits int) float64 {
-	return math.Floor(x*math.Pow(10, float64(sigDigits))) / math.Pow(10, float64(sigDigits))
+	return RoundDownDecimal(decimal.NewFromFloat(x), sigDigits).InexactFloat64()
 }
 
 // RoundNormal 正常舍入
+//
+// Deprecated: float64 无法精确表示大部分小数，请使用 RoundNormalDecimal
 func RoundNormal(x float64, sigDigits int) float64 {
-	return math.Round(x*math.Pow(10, float64(sigDigits))) / math.Pow(10, float64(sigDigits))
+	return RoundNormalDecimal(decimal.NewFromFloat(x), sigDigits).InexactFloat64()
 }
 
 // RoundUp 向上舍入
+//
+// Deprecated: float64 无法精确表示大部分小数，请使用 RoundUpDecimal
 func RoundUp(x float64, sigDigits int) float64 {
-	return math.Ceil(x*math.Pow(10, float64(sigDigits))) / math.Pow(10, float64(sigDigits))
+	return RoundUpDecimal(decimal.NewFromFloat(x), sigDigits).InexactFloat64()
 }
 
 // ToTokenDecimals 转换为代币小数位（6位）
+//
+// Deprecated: 结果可能超出 int64 范围，请使用 ToTokenDecimalsDecimal
 func ToTokenDecimals(x float64) int64 {
-	f := (math.Pow(10, 6)) * x
-	if DecimalPlaces(f) > 0 {
-		f = RoundNormal(f, 0)
-	}
-	return int64(f)
+	return ToTokenDecimalsDecimal(decimal.NewFromFloat(x)).Int64()
 }
 
 // DecimalPlaces 计算小数位数
+//
+// Deprecated: 请使用 DecimalPlacesDecimal
 func DecimalPlaces(x float64) int {
-	s := strconv.FormatFloat(x, 'f', -1, 64)
-	if strings.Contains(s, ".") {
-		parts := strings.Split(s, ".")
-		return len(parts[1])
+	return DecimalPlacesDecimal(decimal.NewFromFloat(x))
+}
+
+// fitAmount 将金额限制在 amountDigits 位小数以内
+// 与 Python 客户端一致：先向上舍入到 amountDigits+4 位，仍超出则向下截断
+func fitAmount(x decimal.Decimal, amountDigits int) decimal.Decimal {
+	if DecimalPlacesDecimal(x) > amountDigits {
+		x = RoundUpDecimal(x, amountDigits+4)
+		if DecimalPlacesDecimal(x) > amountDigits {
+			x = RoundDownDecimal(x, amountDigits)
+		}
 	}
-	return 0
+	return x
 }
 
+// divCeil 计算 a/b 并精确地向上舍入到 sigDigits 位小数
+func divCeil(a, b decimal.Decimal, sigDigits int) decimal.Decimal {
+	q, r := a.QuoRem(b, int32(sigDigits))
+	if r.Sign() != 0 && q.Sign() >= 0 {
+		q = q.Add(decimal.New(1, int32(-sigDigits)))
+	}
+	return q
+}
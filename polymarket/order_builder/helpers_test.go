@@ -0,0 +1,178 @@
+package order_builder
+
+import (
+	"testing"
+
+	"github.com/polymarket/go-order-utils/pkg/model"
+	"github.com/shopspring/decimal"
+)
+
+// 期望值来自 py-clob-client（tests/order_builder/test_builder.py）的 makerAmount/takerAmount，
+// 以及按其 round_down / round_up / round_normal 步骤计算的结果
+
+func TestGetOrderAmounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		tickSize string
+		side     string
+		price    string
+		size     string
+		maker    string
+		taker    string
+	}{
+		// 每种 tick size
+		{"buy 0.1", "0.1", "BUY", "0.5", "21.04", "10520000", "21040000"},
+		{"buy 0.01", "0.01", "BUY", "0.56", "21.04", "11782400", "21040000"},
+		{"buy 0.001", "0.001", "BUY", "0.056", "21.04", "1178240", "21040000"},
+		{"buy 0.0001", "0.0001", "BUY", "0.0056", "21.04", "117824", "21040000"},
+		{"sell 0.1", "0.1", "SELL", "0.5", "21.04", "21040000", "10520000"},
+		{"sell 0.01", "0.01", "SELL", "0.56", "21.04", "21040000", "11782400"},
+		{"sell 0.001", "0.001", "SELL", "0.056", "21.04", "21040000", "1178240"},
+		{"sell 0.0001", "0.0001", "SELL", "0.0056", "21.04", "21040000", "117824"},
+
+		// decimal accuracy 用例
+		{"buy 0.24x15", "0.01", "BUY", "0.24", "15", "3600000", "15000000"},
+		{"sell 0.24x15", "0.01", "SELL", "0.24", "15", "15000000", "3600000"},
+		{"buy 0.82x101", "0.01", "BUY", "0.82", "101", "82820000", "101000000"},
+		{"sell 0.82x101", "0.01", "SELL", "0.82", "101", "101000000", "82820000"},
+		{"buy 0.78x12.8205", "0.01", "BUY", "0.78", "12.8205", "9999600", "12820000"},
+		{"sell 0.78x12.8205", "0.01", "SELL", "0.78", "12.8205", "12820000", "9999600"},
+		{"buy 0.39x2435.89", "0.01", "BUY", "0.39", "2435.89", "949997100", "2435890000"},
+		{"sell 0.39x2435.89", "0.01", "SELL", "0.39", "2435.89", "2435890000", "949997100"},
+		{"buy 0.43x19.1", "0.01", "BUY", "0.43", "19.1", "8213000", "19100000"},
+		{"sell 0.43x19.1", "0.01", "SELL", "0.43", "19.1", "19100000", "8213000"},
+		{"buy 0.58x18233.33", "0.01", "BUY", "0.58", "18233.33", "10575331400", "18233330000"},
+		{"sell 0.58x18233.33", "0.01", "SELL", "0.58", "18233.33", "18233330000", "10575331400"},
+
+		// float64 下差一个单位的用例（0.57*100 = 56.99999999999999，0.29*100 = 28.999999999999996）
+		{"buy 0.57x100", "0.01", "BUY", "0.57", "100", "57000000", "100000000"},
+		{"sell 0.57x100", "0.01", "SELL", "0.57", "100", "100000000", "57000000"},
+		{"buy 0.29x100", "0.01", "BUY", "0.29", "100", "29000000", "100000000"},
+		{"sell 0.29x100", "0.01", "SELL", "0.29", "100", "100000000", "29000000"},
+		{"buy 0.07x100", "0.01", "BUY", "0.07", "100", "7000000", "100000000"},
+	}
+
+	ob := &OrderBuilder{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			side, maker, taker, err := ob.GetOrderAmountsDecimal(tt.side, decimal.RequireFromString(tt.size), decimal.RequireFromString(tt.price), RoundingConfig[tt.tickSize])
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantSide := model.BUY
+			if tt.side == "SELL" {
+				wantSide = model.SELL
+			}
+			if side != wantSide {
+				t.Errorf("side = %v, want %v", side, wantSide)
+			}
+			if maker.String() != tt.maker || taker.String() != tt.taker {
+				t.Errorf("maker/taker = %s/%s, want %s/%s", maker, taker, tt.maker, tt.taker)
+			}
+		})
+	}
+}
+
+func TestGetMarketOrderAmounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		tickSize string
+		side     string
+		price    string
+		amount   string
+		maker    string
+		taker    string
+	}{
+		// BUY：amount 为 USDC
+		{"buy 0.1", "0.1", "BUY", "0.5", "100", "100000000", "200000000"},
+		{"buy 0.01", "0.01", "BUY", "0.56", "100", "100000000", "178571400"},
+		{"buy 0.001", "0.001", "BUY", "0.056", "100", "100000000", "1785714280"},
+		{"buy 0.0001", "0.0001", "BUY", "0.0056", "100", "100000000", "17857142857"},
+		{"buy 0.3x1", "0.01", "BUY", "0.3", "1", "1000000", "3333300"},
+		{"buy 0.57x57", "0.01", "BUY", "0.57", "57", "57000000", "100000000"},
+
+		// SELL：amount 为份额
+		{"sell 0.1", "0.1", "SELL", "0.5", "100", "100000000", "50000000"},
+		{"sell 0.01", "0.01", "SELL", "0.56", "100", "100000000", "56000000"},
+		{"sell 0.001", "0.001", "SELL", "0.056", "100", "100000000", "5600000"},
+		{"sell 0.0001", "0.0001", "SELL", "0.0056", "100", "100000000", "560000"},
+		{"sell 0.57x100", "0.01", "SELL", "0.57", "100", "100000000", "57000000"},
+		{"sell amount rounded down", "0.01", "SELL", "0.5", "10.129", "10120000", "5060000"},
+	}
+
+	ob := &OrderBuilder{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, maker, taker, err := ob.GetMarketOrderAmountsDecimal(tt.side, decimal.RequireFromString(tt.amount), decimal.RequireFromString(tt.price), RoundingConfig[tt.tickSize])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if maker.String() != tt.maker || taker.String() != tt.taker {
+				t.Errorf("maker/taker = %s/%s, want %s/%s", maker, taker, tt.maker, tt.taker)
+			}
+		})
+	}
+}
+
+func TestGetOrderAmountsInvalidSide(t *testing.T) {
+	ob := &OrderBuilder{}
+	if _, _, _, err := ob.GetOrderAmountsDecimal("HOLD", decimal.NewFromInt(1), decimal.RequireFromString("0.5"), RoundingConfig["0.01"]); err == nil {
+		t.Error("GetOrderAmountsDecimal with invalid side: want error")
+	}
+	if _, _, _, err := ob.GetMarketOrderAmountsDecimal("BUY", decimal.NewFromInt(1), decimal.Zero, RoundingConfig["0.01"]); err == nil {
+		t.Error("GetMarketOrderAmountsDecimal BUY with zero price: want error")
+	}
+}
+
+func TestFitAmount(t *testing.T) {
+	tests := []struct {
+		in     string
+		digits int
+		want   string
+	}{
+		{"3.6", 4, "3.6"},
+		{"178.571428571428571", 4, "178.5714"},
+		{"56.99999999999999", 4, "57"},
+		{"1.00000000001", 4, "1"},
+	}
+	for _, tt := range tests {
+		if got := fitAmount(decimal.RequireFromString(tt.in), tt.digits); !got.Equal(decimal.RequireFromString(tt.want)) {
+			t.Errorf("fitAmount(%s, %d) = %s, want %s", tt.in, tt.digits, got, tt.want)
+		}
+	}
+}
+
+func TestDeprecatedFloatHelpers(t *testing.T) {
+	if got := RoundDown(0.129, 2); got != 0.12 {
+		t.Errorf("RoundDown(0.129, 2) = %v, want 0.12", got)
+	}
+	if got := RoundUp(0.121, 2); got != 0.13 {
+		t.Errorf("RoundUp(0.121, 2) = %v, want 0.13", got)
+	}
+	if got := RoundNormal(0.125, 2); got != 0.12 {
+		t.Errorf("RoundNormal(0.125, 2) = %v, want 0.12", got)
+	}
+	if got := DecimalPlaces(21.04); got != 2 {
+		t.Errorf("DecimalPlaces(21.04) = %d, want 2", got)
+	}
+	if got := ToTokenDecimals(0.57 * 100); got != 57000000 {
+		t.Errorf("ToTokenDecimals(0.57*100) = %d, want 57000000", got)
+	}
+
+	// float64 包装与 Decimal 版本结果一致
+	ob := &OrderBuilder{}
+	_, maker, taker, err := ob.GetOrderAmounts("BUY", 100, 0.57, RoundingConfig["0.01"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if maker.String() != "57000000" || taker.String() != "100000000" {
+		t.Errorf("GetOrderAmounts maker/taker = %s/%s, want 57000000/100000000", maker, taker)
+	}
+	_, maker, taker, err = ob.GetMarketOrderAmounts("SELL", 21.04, 0.56, RoundingConfig["0.01"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if maker.String() != "21040000" || taker.String() != "11782400" {
+		t.Errorf("GetMarketOrderAmounts maker/taker = %s/%s, want 21040000/11782400", maker, taker)
+	}
+}
@@ -1,20 +1,23 @@
 package order_builder
 
 import (
+	"context"
 	"fmt"
 	"math/big"
-	"strconv"
+	"sort"
 
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/polymarket/go-order-utils/pkg/builder"
 	"github.com/polymarket/go-order-utils/pkg/model"
+	"github.com/polymarket/go-order-utils/pkg/signer"
+	"github.com/shopspring/decimal"
 )
 
 // Signer 签名器接口（避免循环导入）
 type Signer interface {
 	Address() string
 	GetChainID() int
-	GetPrivateKey() string
+	// SignHash 对32字节哈希签名，返回 v 为 27/28 的65字节签名
+	SignHash(ctx context.Context, hash []byte) ([]byte, error)
 }
 
 // OrderBuilder 订单构建器
@@ -41,82 +44,62 @@ func NewOrderBuilder(signer Signer, sigType int, funder string) (*OrderBuilder,
 	}, nil
 }
 
-// GetOrderAmounts 获取订单金额（限价订单）
-func (ob *OrderBuilder) GetOrderAmounts(side string, size, price float64, roundConfig RoundConfig) (model.Side, *big.Int, *big.Int, error) {
-	rawPrice := RoundNormal(price, roundConfig.Price)
+// GetOrderAmountsDecimal 获取订单金额（限价订单）
+// 使用精确的十进制运算，返回以代币最小单位（6位小数）表示的 maker/taker 金额
+func (ob *OrderBuilder) GetOrderAmountsDecimal(side string, size, price decimal.Decimal, roundConfig RoundConfig) (model.Side, *big.Int, *big.Int, error) {
+	rawPrice := RoundNormalDecimal(price, roundConfig.Price)
 
 	if side == "BUY" {
-		rawTakerAmt := RoundDown(size, roundConfig.Size)
-		rawMakerAmt := rawTakerAmt * rawPrice
-
-		if DecimalPlaces(rawMakerAmt) > roundConfig.Amount {
-			rawMakerAmt = RoundUp(rawMakerAmt, roundConfig.Amount+4)
-			if DecimalPlaces(rawMakerAmt) > roundConfig.Amount {
-				rawMakerAmt = RoundDown(rawMakerAmt, roundConfig.Amount)
-			}
-		}
-
-		makerAmount := big.NewInt(ToTokenDecimals(rawMakerAmt))
-		takerAmount := big.NewInt(ToTokenDecimals(rawTakerAmt))
+		rawTakerAmt := RoundDownDecimal(size, roundConfig.Size)
+		rawMakerAmt := fitAmount(rawTakerAmt.Mul(rawPrice), roundConfig.Amount)
 
-		return model.BUY, makerAmount, takerAmount, nil
+		return model.BUY, ToTokenDecimalsDecimal(rawMakerAmt), ToTokenDecimalsDecimal(rawTakerAmt), nil
 	} else if side == "SELL" {
-		rawMakerAmt := RoundDown(size, roundConfig.Size)
-		rawTakerAmt := rawMakerAmt * rawPrice
-
-		if DecimalPlaces(rawTakerAmt) > roundConfig.Amount {
-			rawTakerAmt = RoundUp(rawTakerAmt, roundConfig.Amount+4)
-			if DecimalPlaces(rawTakerAmt) > roundConfig.Amount {
-				rawTakerAmt = RoundDown(rawTakerAmt, roundConfig.Amount)
-			}
-		}
-
-		makerAmount := big.NewInt(ToTokenDecimals(rawMakerAmt))
-		takerAmount := big.NewInt(ToTokenDecimals(rawTakerAmt))
+		rawMakerAmt := RoundDownDecimal(size, roundConfig.Size)
+		rawTakerAmt := fitAmount(rawMakerAmt.Mul(rawPrice), roundConfig.Amount)
 
-		return model.SELL, makerAmount, takerAmount, nil
+		return model.SELL, ToTokenDecimalsDecimal(rawMakerAmt), ToTokenDecimalsDecimal(rawTakerAmt), nil
 	}
 
-		return 0, nil, nil, fmt.Errorf("order_args.side must be 'BUY' or 'SELL'")
+	return 0, nil, nil, fmt.Errorf("order_args.side must be 'BUY' or 'SELL'")
 }
 
-// GetMarketOrderAmounts 获取市价订单金额
-func (ob *OrderBuilder) GetMarketOrderAmounts(side string, amount, price float64, roundConfig RoundConfig) (model.Side, *big.Int, *big.Int, error) {
-	rawPrice := RoundNormal(price, roundConfig.Price)
+// GetMarketOrderAmountsDecimal 获取市价订单金额
+// BUY 时 amount 为美元金额，SELL 时为份额数量
+func (ob *OrderBuilder) GetMarketOrderAmountsDecimal(side string, amount, price decimal.Decimal, roundConfig RoundConfig) (model.Side, *big.Int, *big.Int, error) {
+	rawPrice := RoundNormalDecimal(price, roundConfig.Price)
 
 	if side == "BUY" {
-		rawMakerAmt := RoundDown(amount, roundConfig.Size)
-		rawTakerAmt := rawMakerAmt / rawPrice
-
-		if DecimalPlaces(rawTakerAmt) > roundConfig.Amount {
-			rawTakerAmt = RoundUp(rawTakerAmt, roundConfig.Amount+4)
-			if DecimalPlaces(rawTakerAmt) > roundConfig.Amount {
-				rawTakerAmt = RoundDown(rawTakerAmt, roundConfig.Amount)
-			}
+		if rawPrice.Sign() <= 0 {
+			return 0, nil, nil, fmt.Errorf("price must be positive")
 		}
+		rawMakerAmt := RoundDownDecimal(amount, roundConfig.Size)
+		// 除法结果可能是无限小数，先精确地向上舍入到 Amount+4 位，再交给 fitAmount 截断
+		rawTakerAmt := fitAmount(divCeil(rawMakerAmt, rawPrice, roundConfig.Amount+4), roundConfig.Amount)
 
-		makerAmount := big.NewInt(ToTokenDecimals(rawMakerAmt))
-		takerAmount := big.NewInt(ToTokenDecimals(rawTakerAmt))
-
-		return model.BUY, makerAmount, takerAmount, nil
+		return model.BUY, ToTokenDecimalsDecimal(rawMakerAmt), ToTokenDecimalsDecimal(rawTakerAmt), nil
 	} else if side == "SELL" {
-		rawMakerAmt := RoundDown(amount, roundConfig.Size)
-		rawTakerAmt := rawMakerAmt * rawPrice
-
-		if DecimalPlaces(rawTakerAmt) > roundConfig.Amount {
-			rawTakerAmt = RoundUp(rawTakerAmt, roundConfig.Amount+4)
-			if DecimalPlaces(rawTakerAmt) > roundConfig.Amount {
-				rawTakerAmt = RoundDown(rawTakerAmt, roundConfig.Amount)
-			}
-		}
+		rawMakerAmt := RoundDownDecimal(amount, roundConfig.Size)
+		rawTakerAmt := fitAmount(rawMakerAmt.Mul(rawPrice), roundConfig.Amount)
 
-		makerAmount := big.NewInt(ToTokenDecimals(rawMakerAmt))
-		takerAmount := big.NewInt(ToTokenDecimals(rawTakerAmt))
-
-		return model.SELL, makerAmount, takerAmount, nil
+		return model.SELL, ToTokenDecimalsDecimal(rawMakerAmt), ToTokenDecimalsDecimal(rawTakerAmt), nil
 	}
 
-		return 0, nil, nil, fmt.Errorf("order_args.side must be 'BUY' or 'SELL'")
+	return 0, nil, nil, fmt.Errorf("order_args.side must be 'BUY' or 'SELL'")
+}
+
+// GetOrderAmounts 获取订单金额（限价订单）
+//
+// Deprecated: float64 无法精确表示大部分价格和数量，请使用 GetOrderAmountsDecimal
+func (ob *OrderBuilder) GetOrderAmounts(side string, size, price float64, roundConfig RoundConfig) (model.Side, *big.Int, *big.Int, error) {
+	return ob.GetOrderAmountsDecimal(side, decimal.NewFromFloat(size), decimal.NewFromFloat(price), roundConfig)
+}
+
+// GetMarketOrderAmounts 获取市价订单金额
+//
+// Deprecated: float64 无法精确表示大部分价格和金额，请使用 GetMarketOrderAmountsDecimal
+func (ob *OrderBuilder) GetMarketOrderAmounts(side string, amount, price float64, roundConfig RoundConfig) (model.Side, *big.Int, *big.Int, error) {
+	return ob.GetMarketOrderAmountsDecimal(side, decimal.NewFromFloat(amount), decimal.NewFromFloat(price), roundConfig)
 }
 
 // CreateOrder 创建并签名订单（限价订单）
@@ -139,90 +122,82 @@ type OrderSummary interface {
 	GetSize() string
 }
 
-// CalculateBuyMarketPrice 计算买入市价
+// CalculateBuyMarketPrice 计算买入市价：从最低卖价开始累加金额（price*size），返回达到 amountToMatch 的价位
+// 非 FOK 订单深度不足时返回吃到的最差价位；价格或数量无法解析时返回错误
+//
+// Deprecated: 使用 polymarket.EstimateMarketFill，它同时返回均价、滑点和部分成交信息
 func (ob *OrderBuilder) CalculateBuyMarketPrice(positions []interface{}, amountToMatch float64, orderType string) (float64, error) {
-	if len(positions) == 0 {
-		return 0, fmt.Errorf("no match")
-	}
+	return calculateMarketPrice(positions, amountToMatch, orderType, true)
+}
 
-	sum := 0.0
-	for i := len(positions) - 1; i >= 0; i-- {
-		pos, ok := positions[i].(OrderSummary)
-		if !ok {
-			continue
-		}
+// CalculateSellMarketPrice 计算卖出市价：从最高买价开始累加份额，返回达到 amountToMatch 的价位
+// 非 FOK 订单深度不足时返回吃到的最差价位；价格或数量无法解析时返回错误
+//
+// Deprecated: 使用 polymarket.EstimateMarketFill，它同时返回均价、滑点和部分成交信息
+func (ob *OrderBuilder) CalculateSellMarketPrice(positions []interface{}, amountToMatch float64, orderType string) (float64, error) {
+	return calculateMarketPrice(positions, amountToMatch, orderType, false)
+}
 
-		price, _ := strconv.ParseFloat(pos.GetPrice(), 64)
-		size, _ := strconv.ParseFloat(pos.GetSize(), 64)
-		sum += size * price
+// marketLevel 订单簿的一个价位
+type marketLevel struct {
+	price decimal.Decimal
+	size  decimal.Decimal
+}
 
-		if sum >= amountToMatch {
-			return price, nil
+// calculateMarketPrice 按最优价开始逐档累加，不依赖 positions 的顺序
+func calculateMarketPrice(positions []interface{}, amountToMatch float64, orderType string, isBuy bool) (float64, error) {
+	levels := make([]marketLevel, 0, len(positions))
+	for i, p := range positions {
+		pos, ok := p.(OrderSummary)
+		if !ok {
+			return 0, fmt.Errorf("invalid position %d: %T does not implement OrderSummary", i, p)
+		}
+		price, err := decimal.NewFromString(pos.GetPrice())
+		if err != nil {
+			return 0, fmt.Errorf("invalid price %q at position %d: %w", pos.GetPrice(), i, err)
+		}
+		size, err := decimal.NewFromString(pos.GetSize())
+		if err != nil {
+			return 0, fmt.Errorf("invalid size %q at position %d: %w", pos.GetSize(), i, err)
+		}
+		if size.Sign() > 0 {
+			levels = append(levels, marketLevel{price: price, size: size})
 		}
 	}
-
-	if orderType == "FOK" {
-		return 0, fmt.Errorf("no match")
-	}
-
-	// 返回第一个价格
-	if pos, ok := positions[0].(OrderSummary); ok {
-		price, _ := strconv.ParseFloat(pos.GetPrice(), 64)
-		return price, nil
-	}
-
-	return 0, fmt.Errorf("invalid position format")
-}
-
-// CalculateSellMarketPrice 计算卖出市价
-func (ob *OrderBuilder) CalculateSellMarketPrice(positions []interface{}, amountToMatch float64, orderType string) (float64, error) {
-	if len(positions) == 0 {
+	if len(levels) == 0 {
 		return 0, fmt.Errorf("no match")
 	}
 
-	sum := 0.0
-	for i := len(positions) - 1; i >= 0; i-- {
-		pos, ok := positions[i].(OrderSummary)
-		if !ok {
-			continue
+	// BUY 从最低卖价开始，SELL 从最高买价开始
+	sort.SliceStable(levels, func(i, j int) bool {
+		if isBuy {
+			return levels[i].price.LessThan(levels[j].price)
 		}
-
-		size, _ := strconv.ParseFloat(pos.GetSize(), 64)
-		sum += size
-
-		if sum >= amountToMatch {
-			price, _ := strconv.ParseFloat(pos.GetPrice(), 64)
-			return price, nil
+		return levels[i].price.GreaterThan(levels[j].price)
+	})
+
+	target := decimal.NewFromFloat(amountToMatch)
+	sum := decimal.Zero
+	for _, level := range levels {
+		if isBuy {
+			sum = sum.Add(level.price.Mul(level.size))
+		} else {
+			sum = sum.Add(level.size)
+		}
+		if sum.GreaterThanOrEqual(target) {
+			return level.price.InexactFloat64(), nil
 		}
 	}
 
 	if orderType == "FOK" {
 		return 0, fmt.Errorf("no match")
 	}
-
-	// 返回第一个价格
-	if pos, ok := positions[0].(OrderSummary); ok {
-		price, _ := strconv.ParseFloat(pos.GetPrice(), 64)
-		return price, nil
-	}
-
-	return 0, fmt.Errorf("invalid position format")
+	return levels[len(levels)-1].price.InexactFloat64(), nil
 }
 
 // BuildSignedOrder 构建已签名订单（导出方法，供主包使用）
-func (ob *OrderBuilder) BuildSignedOrder(orderData *model.OrderData, exchangeAddr string, chainID int, negRisk bool) (*model.SignedOrder, error) {
-	// 解析私钥
-	privateKeyHex := ob.signer.GetPrivateKey()
-	// 移除0x前缀（如果有）
-	if len(privateKeyHex) > 2 && privateKeyHex[:2] == "0x" {
-		privateKeyHex = privateKeyHex[2:]
-	}
-	
-	privateKey, err := crypto.HexToECDSA(privateKeyHex)
-	if err != nil {
-		return nil, fmt.Errorf("invalid private key: %w", err)
-	}
-
+// 订单哈希与 go-order-utils 一致，签名通过 Signer.SignHash 完成，私钥不需要进入本进程
+func (ob *OrderBuilder) BuildSignedOrder(ctx context.Context, orderData *model.OrderData, exchangeAddr string, chainID int, negRisk bool) (*model.SignedOrder, error) {
 	// 创建订单构建器
 	chainIDBig := big.NewInt(int64(chainID))
 	orderBuilder := builder.NewExchangeOrderBuilderImpl(chainIDBig, nil)
@@ -235,13 +210,33 @@ func (ob *OrderBuilder) BuildSignedOrder(orderData *model.OrderData, exchangeAdd
 		contract = model.CTFExchange
 	}
 
-	// 构建并签名订单
-	signedOrder, err := orderBuilder.BuildSignedOrder(privateKey, orderData, contract)
+	// 构建订单并计算哈希
+	order, err := orderBuilder.BuildOrder(orderData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build signed order: %w", err)
+	}
+	orderHash, err := orderBuilder.BuildOrderHash(order, contract)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build signed order: %w", err)
+	}
+
+	// 签名
+	signature, err := ob.signer.SignHash(ctx, orderHash.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to build signed order: %w", err)
+	}
+	ok, err := signer.ValidateSignature(order.Signer, orderHash, signature)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build signed order: %w", err)
 	}
+	if !ok {
+		return nil, fmt.Errorf("failed to build signed order: signature does not match order signer %s", order.Signer.Hex())
+	}
 
-	return signedOrder, nil
+	return &model.SignedOrder{
+		Order:     *order,
+		Signature: signature,
+	}, nil
 }
 
 // GetSigType 获取签名类型
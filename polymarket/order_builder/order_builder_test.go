@@ -0,0 +1,75 @@
+package order_builder
+
+import "testing"
+
+// testSummary 测试用的 OrderSummary
+type testSummary struct {
+	price, size string
+}
+
+func (s testSummary) GetPrice() string { return s.price }
+func (s testSummary) GetSize() string  { return s.size }
+
+func summaries(levels ...testSummary) []interface{} {
+	result := make([]interface{}, len(levels))
+	for i, l := range levels {
+		result[i] = l
+	}
+	return result
+}
+
+func TestCalculateMarketPrice(t *testing.T) {
+	// 服务器顺序：卖盘价格从高到低，买盘价格从低到高（最优价在最后）
+	asks := summaries(testSummary{"0.60", "100"}, testSummary{"0.55", "10"}, testSummary{"0.50", "10"})
+	bids := summaries(testSummary{"0.30", "100"}, testSummary{"0.40", "10"}, testSummary{"0.45", "10"})
+	shuffledAsks := summaries(testSummary{"0.55", "10"}, testSummary{"0.60", "100"}, testSummary{"0.50", "10"})
+
+	tests := []struct {
+		name      string
+		buy       bool
+		positions []interface{}
+		amount    float64
+		orderType string
+		want      float64
+		wantErr   bool
+	}{
+		{"buy best level", true, asks, 5, "FOK", 0.50, false},
+		{"buy exact level", true, asks, 5, "FAK", 0.50, false},
+		{"buy second level", true, asks, 10.5, "FOK", 0.55, false},
+		{"buy any order", true, shuffledAsks, 10.5, "FOK", 0.55, false},
+		{"buy FOK too deep", true, asks, 1000, "FOK", 0, true},
+		{"buy FAK too deep", true, asks, 1000, "FAK", 0.60, false},
+		{"sell best level", false, bids, 10, "FOK", 0.45, false},
+		{"sell second level", false, bids, 15, "FOK", 0.40, false},
+		{"sell FOK too deep", false, bids, 500, "FOK", 0, true},
+		{"sell FAK too deep", false, bids, 500, "FAK", 0.30, false},
+		{"empty", true, nil, 1, "FAK", 0, true},
+		{"zero size only", true, summaries(testSummary{"0.5", "0"}), 1, "FAK", 0, true},
+		{"invalid price", true, summaries(testSummary{"abc", "1"}), 1, "FAK", 0, true},
+		{"invalid size", false, summaries(testSummary{"0.5", ""}), 1, "FAK", 0, true},
+		{"invalid position", true, []interface{}{"0.5"}, 1, "FAK", 0, true},
+	}
+
+	ob := &OrderBuilder{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calc := ob.CalculateSellMarketPrice
+			if tt.buy {
+				calc = ob.CalculateBuyMarketPrice
+			}
+			got, err := calc(tt.positions, tt.amount, tt.orderType)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("price = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package polymarket
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/polymarket/go-order-utils/pkg/builder"
+	"github.com/polymarket/go-order-utils/pkg/model"
+)
+
+// ErrInvalidSignature 订单签名无效（无法恢复签名者，或签名者、maker 不符合预期）
+var ErrInvalidSignature = errors.New("invalid order signature")
+
+// OrderHash 计算订单的 EIP-712 哈希（0x 前缀的十六进制），与服务器返回的订单ID一致
+// 使用与 OrderBuilder.BuildSignedOrder 相同的域：按 negRisk 选择 CTF Exchange 或 Neg Risk CTF Exchange
+func OrderHash(order *SignedOrder, chainID int, negRisk bool) (string, error) {
+	hash, err := orderHash(order, chainID, negRisk)
+	if err != nil {
+		return "", err
+	}
+	return hash.Hex(), nil
+}
+
+// RecoverOrderSigner 从订单签名中恢复签名者地址
+func RecoverOrderSigner(order *SignedOrder, chainID int, negRisk bool) (string, error) {
+	hash, err := orderHash(order, chainID, negRisk)
+	if err != nil {
+		return "", err
+	}
+	signer, err := recoverSigner(hash, order.Signature)
+	if err != nil {
+		return "", err
+	}
+	return signer.Hex(), nil
+}
+
+// VerifyOrderSignature 验证订单签名
+// 检查签名恢复出的地址等于 order.Signer；expectedSigner 不为空时还要求等于该地址。
+// EOA 订单（类型0）要求 maker 等于 signer；代理钱包（类型1）和 Safe（类型2）订单由 EOA 签名、maker 为钱包地址，
+// expectedMaker 不为空时检查 maker（可通过 web3 客户端的 GetPolyProxyAddress / GetSafeProxyAddress 获取）
+func VerifyOrderSignature(order *SignedOrder, chainID int, negRisk bool, expectedSigner, expectedMaker string) error {
+	if order == nil {
+		return fmt.Errorf("%w: order is nil", ErrInvalidSignature)
+	}
+	hash, err := orderHash(order, chainID, negRisk)
+	if err != nil {
+		return err
+	}
+	recovered, err := recoverSigner(hash, order.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	if recovered != order.Signer {
+		return fmt.Errorf("%w: recovered %s, order signer is %s (wrong chain ID or negRisk?)", ErrInvalidSignature, recovered.Hex(), order.Signer.Hex())
+	}
+	if expectedSigner != "" && !sameAddress(order.Signer, expectedSigner) {
+		return fmt.Errorf("%w: signer %s, expected %s", ErrInvalidSignature, order.Signer.Hex(), expectedSigner)
+	}
+
+	sigType := -1
+	if order.SignatureType != nil {
+		sigType = int(order.SignatureType.Int64())
+	}
+	switch sigType {
+	case SignatureTypeEOA:
+		if order.Maker != order.Signer {
+			return fmt.Errorf("%w: EOA order maker %s differs from signer %s", ErrInvalidSignature, order.Maker.Hex(), order.Signer.Hex())
+		}
+	case SignatureTypeEmail, SignatureTypeBrowser:
+		// 代理钱包 / Safe：maker 为钱包地址，由 signer 控制
+	default:
+		return fmt.Errorf("%w: unsupported signature type %d", ErrInvalidSignature, sigType)
+	}
+	if expectedMaker != "" && !sameAddress(order.Maker, expectedMaker) {
+		return fmt.Errorf("%w: maker %s, expected %s", ErrInvalidSignature, order.Maker.Hex(), expectedMaker)
+	}
+	return nil
+}
+
+// OrderHash 使用客户端的链ID计算订单哈希
+func (c *ClobClient) OrderHash(order *SignedOrder, negRisk bool) (string, error) {
+	return OrderHash(order, c.chainID, negRisk)
+}
+
+// VerifyOrderSignature 验证订单由当前签名器签名，且 maker 为当前的 funder
+func (c *ClobClient) VerifyOrderSignature(order *SignedOrder, negRisk bool) error {
+	if err := c.assertLevel1Auth(); err != nil {
+		return err
+	}
+	return VerifyOrderSignature(order, c.chainID, negRisk, c.signer.Address(), c.builder.GetFunder())
+}
+
+// orderHash 计算订单的 EIP-712 哈希
+func orderHash(order *SignedOrder, chainID int, negRisk bool) (common.Hash, error) {
+	if order == nil {
+		return common.Hash{}, fmt.Errorf("order is nil")
+	}
+	contract := model.CTFExchange
+	if negRisk {
+		contract = model.NegRiskCTFExchange
+	}
+	hash, err := builder.NewExchangeOrderBuilderImpl(big.NewInt(int64(chainID)), nil).BuildOrderHash(&order.Order, contract)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to hash order: %w", err)
+	}
+	return hash, nil
+}
+
+// recoverSigner 从65字节签名中恢复地址，兼容 v 为 27/28 或 0/1
+func recoverSigner(hash common.Hash, signature []byte) (common.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("signature must be %d bytes, got %d", crypto.SignatureLength, len(signature))
+	}
+	sig := make([]byte, len(signature))
+	copy(sig, signature)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	pub, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+func sameAddress(addr common.Address, other string) bool {
+	return common.IsHexAddress(other) && addr == common.HexToAddress(other)
+}
@@ -0,0 +1,167 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+const (
+	// testSignerAddress testPrivateKey 对应的地址
+	testSignerAddress = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+	// testFunder 代理钱包 / Safe 订单的 maker 地址（任意地址即可）
+	testFunder = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+)
+
+// signTestOrder 通过 CreateOrder（BuildSignedOrder）创建已签名订单
+func signTestOrder(t *testing.T, sigType int, negRisk bool) *SignedOrder {
+	t.Helper()
+	funder := ""
+	if sigType != SignatureTypeEOA {
+		funder = testFunder
+	}
+	client, err := NewClobClient("http://127.0.0.1:0", 137, testPrivateKey, nil, &sigType, funder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tickSize := TickSize("0.01")
+	order, err := client.CreateOrder(context.Background(),
+		&OrderArgs{TokenID: "123", Side: BUY, Price: 0.5, Size: 10},
+		&PartialCreateOrderOptions{TickSize: &tickSize, NegRisk: &negRisk, RawOrder: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return order
+}
+
+func TestVerifyOrderSignatureRoundTrip(t *testing.T) {
+	for _, sigType := range []int{SignatureTypeEOA, SignatureTypeEmail, SignatureTypeBrowser} {
+		for _, negRisk := range []bool{false, true} {
+			order := signTestOrder(t, sigType, negRisk)
+			expectedMaker := testSignerAddress
+			if sigType != SignatureTypeEOA {
+				expectedMaker = testFunder
+			}
+
+			if err := VerifyOrderSignature(order, 137, negRisk, testSignerAddress, expectedMaker); err != nil {
+				t.Errorf("sigType %d negRisk %v: VerifyOrderSignature = %v", sigType, negRisk, err)
+			}
+			signer, err := RecoverOrderSigner(order, 137, negRisk)
+			if err != nil || signer != testSignerAddress {
+				t.Errorf("sigType %d negRisk %v: RecoverOrderSigner = %s, %v", sigType, negRisk, signer, err)
+			}
+
+			// 另一个交易所域下签名无效
+			if err := VerifyOrderSignature(order, 137, !negRisk, "", ""); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("sigType %d negRisk %v: verify with wrong exchange = %v, want ErrInvalidSignature", sigType, negRisk, err)
+			}
+		}
+	}
+}
+
+func TestOrderHashDependsOnDomain(t *testing.T) {
+	order := signTestOrder(t, SignatureTypeEOA, false)
+	ctf, err := OrderHash(order, 137, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	negRisk, _ := OrderHash(order, 137, true)
+	amoy, _ := OrderHash(order, 80002, false)
+	if ctf == negRisk || ctf == amoy {
+		t.Errorf("order hash does not depend on exchange / chain: %s %s %s", ctf, negRisk, amoy)
+	}
+	if again, _ := OrderHash(order, 137, false); again != ctf {
+		t.Errorf("OrderHash not deterministic: %s != %s", again, ctf)
+	}
+}
+
+func TestVerifyOrderSignatureRejectsTampering(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(o *SignedOrder)
+	}{
+		{"maker amount", func(o *SignedOrder) { o.MakerAmount = new(big.Int).Add(o.MakerAmount, big.NewInt(1)) }},
+		{"taker amount", func(o *SignedOrder) { o.TakerAmount = big.NewInt(1) }},
+		{"token id", func(o *SignedOrder) { o.TokenId = big.NewInt(456) }},
+		{"side", func(o *SignedOrder) { o.Side = big.NewInt(1) }},
+		{"signature byte", func(o *SignedOrder) { o.Signature[10] ^= 0xff }},
+		{"truncated signature", func(o *SignedOrder) { o.Signature = o.Signature[:64] }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := signTestOrder(t, SignatureTypeEOA, false)
+			tt.tamper(order)
+			if err := VerifyOrderSignature(order, 137, false, "", ""); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("VerifyOrderSignature = %v, want ErrInvalidSignature", err)
+			}
+		})
+	}
+
+	if err := VerifyOrderSignature(signTestOrder(t, SignatureTypeEOA, false), 80002, false, "", ""); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("verify with wrong chain ID = %v, want ErrInvalidSignature", err)
+	}
+	if err := VerifyOrderSignature(nil, 137, false, "", ""); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("verify nil order = %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestVerifyOrderSignatureRecoveryID(t *testing.T) {
+	order := signTestOrder(t, SignatureTypeEOA, false)
+	v := order.Signature[64]
+	if v != 27 && v != 28 {
+		t.Fatalf("signed order v = %d, want 27 or 28", v)
+	}
+
+	// v 为 0/1 的签名同样有效
+	order.Signature[64] = v - 27
+	if err := VerifyOrderSignature(order, 137, false, testSignerAddress, ""); err != nil {
+		t.Errorf("v = %d: %v", order.Signature[64], err)
+	}
+
+	// 翻转恢复ID会恢复出其他地址
+	order.Signature[64] = 27 + (28 - v)
+	if err := VerifyOrderSignature(order, 137, false, "", ""); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("flipped v: %v, want ErrInvalidSignature", err)
+	}
+
+	// 无效的恢复ID
+	order.Signature[64] = 5
+	if err := VerifyOrderSignature(order, 137, false, "", ""); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("v = 5: %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestVerifyOrderSignatureExpectations(t *testing.T) {
+	eoa := signTestOrder(t, SignatureTypeEOA, false)
+	if err := VerifyOrderSignature(eoa, 137, false, testFunder, ""); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("unexpected signer: %v, want ErrInvalidSignature", err)
+	}
+
+	proxy := signTestOrder(t, SignatureTypeEmail, false)
+	if err := VerifyOrderSignature(proxy, 137, false, testSignerAddress, testSignerAddress); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("unexpected maker: %v, want ErrInvalidSignature", err)
+	}
+
+	// 签名类型参与订单哈希，修改后同样被拒绝
+	proxy.SignatureType = big.NewInt(3)
+	if err := VerifyOrderSignature(proxy, 137, false, "", ""); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("unsupported signature type: %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestClientVerifyOrderSignature(t *testing.T) {
+	sigType := SignatureTypeBrowser
+	client, err := NewClobClient("http://127.0.0.1:0", 137, testPrivateKey, nil, &sigType, testFunder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := signTestOrder(t, SignatureTypeBrowser, true)
+	if err := client.VerifyOrderSignature(order, true); err != nil {
+		t.Errorf("VerifyOrderSignature = %v", err)
+	}
+	hash, err := client.OrderHash(order, true)
+	if want, _ := OrderHash(order, 137, true); err != nil || hash != want {
+		t.Errorf("OrderHash = %s, %v, want %s", hash, err, want)
+	}
+}
@@ -0,0 +1,198 @@
+package polymarket
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// OrderStatus 订单状态
+type OrderStatus string
+
+const (
+	OrderStatusLive      OrderStatus = "LIVE"      // 挂单中
+	OrderStatusMatched   OrderStatus = "MATCHED"   // 已撮合
+	OrderStatusDelayed   OrderStatus = "DELAYED"   // 延迟撮合
+	OrderStatusUnmatched OrderStatus = "UNMATCHED" // 延迟后未撮合
+	OrderStatusCanceled  OrderStatus = "CANCELED"  // 已取消
+)
+
+// UnmarshalJSON 兼容 "ORDER_STATUS_LIVE" 和 "live" 等写法
+func (s *OrderStatus) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*s = OrderStatus(strings.TrimPrefix(strings.ToUpper(raw), "ORDER_STATUS_"))
+	return nil
+}
+
+// TradeStatus 成交状态
+type TradeStatus string
+
+const (
+	TradeStatusMatched   TradeStatus = "MATCHED"   // 已撮合，等待上链
+	TradeStatusMined     TradeStatus = "MINED"     // 已打包
+	TradeStatusConfirmed TradeStatus = "CONFIRMED" // 已确认（终态）
+	TradeStatusRetrying  TradeStatus = "RETRYING"  // 上链失败，重试中
+	TradeStatusFailed    TradeStatus = "FAILED"    // 失败（终态）
+)
+
+// UnmarshalJSON 兼容 "TRADE_STATUS_CONFIRMED" 和小写写法
+func (s *TradeStatus) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*s = TradeStatus(strings.TrimPrefix(strings.ToUpper(raw), "TRADE_STATUS_"))
+	return nil
+}
+
+// TraderSide 成交中的角色
+type TraderSide string
+
+const (
+	TraderSideTaker TraderSide = "TAKER"
+	TraderSideMaker TraderSide = "MAKER"
+)
+
+// Timestamp API返回的时间戳
+// 兼容Unix秒（数字或字符串）、Unix毫秒、RFC3339和日期格式，空值和0解析为零值；
+// 无法识别的格式也解析为零值（不会导致整个响应或事件解析失败），原始值可通过 Raw 获取
+type Timestamp struct {
+	time.Time
+	raw string
+}
+
+// Raw 服务器下发的原始值（去掉引号），未从JSON解析时为空
+func (t Timestamp) Raw() string {
+	return t.raw
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，不会返回错误
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	raw := string(bytes.Trim(data, `"`))
+	t.Time = parseTimestamp(raw)
+	t.raw = raw
+	return nil
+}
+
+// parseTimestamp 解析时间戳，无法识别时返回零值
+func parseTimestamp(raw string) time.Time {
+	if raw == "" || raw == "null" || raw == "0" {
+		return time.Time{}
+	}
+
+	if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		// 13位及以上视为毫秒
+		if n >= 1e12 {
+			return time.UnixMilli(n).UTC()
+		}
+		return time.Unix(n, 0).UTC()
+	}
+
+	for _, layout := range []string{time.RFC3339Nano, time.DateOnly} {
+		if parsed, err := time.Parse(layout, raw); err == nil {
+			return parsed
+		}
+	}
+	return time.Time{}
+}
+
+// MarshalJSON 以Unix秒输出，零值输出0
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("0"), nil
+	}
+	return []byte(strconv.FormatInt(t.Unix(), 10)), nil
+}
+
+// OpenOrder 订单（GetOrders / GetOrder）
+type OpenOrder struct {
+	ID              string          `json:"id"`
+	Status          OrderStatus     `json:"status"`
+	Owner           string          `json:"owner"`            // API key
+	MakerAddress    string          `json:"maker_address"`    // 资金持有者地址
+	Market          string          `json:"market"`           // condition ID
+	AssetID         string          `json:"asset_id"`         // token ID
+	Side            string          `json:"side"`             // BUY 或 SELL
+	OriginalSize    decimal.Decimal `json:"original_size"`    // 下单数量
+	SizeMatched     decimal.Decimal `json:"size_matched"`     // 已成交数量
+	Price           decimal.Decimal `json:"price"`            // 限价
+	Outcome         string          `json:"outcome"`          // 结果名称（如 Yes/No）
+	OrderType       OrderType       `json:"type"`             // GTC, GTD, FOK, FAK
+	Expiration      Timestamp       `json:"expiration"`       // 过期时间，零值表示不过期
+	CreatedAt       Timestamp       `json:"created_at"`       // 创建时间
+	AssociateTrades []string        `json:"associate_trades"` // 关联的成交ID
+}
+
+// RemainingSize 剩余未成交数量
+func (o *OpenOrder) RemainingSize() decimal.Decimal {
+	return o.OriginalSize.Sub(o.SizeMatched)
+}
+
+// MakerOrder 成交中的 maker 订单
+type MakerOrder struct {
+	OrderID       string          `json:"order_id"`
+	Owner         string          `json:"owner"`
+	MakerAddress  string          `json:"maker_address"`
+	MatchedAmount decimal.Decimal `json:"matched_amount"`
+	Price         decimal.Decimal `json:"price"`
+	FeeRateBps    decimal.Decimal `json:"fee_rate_bps"`
+	AssetID       string          `json:"asset_id"`
+	Outcome       string          `json:"outcome"`
+	Side          string          `json:"side"`
+}
+
+// Trade 成交记录（GetTrades）
+type Trade struct {
+	ID              string          `json:"id"`
+	TakerOrderID    string          `json:"taker_order_id"`
+	Market          string          `json:"market"`   // condition ID
+	AssetID         string          `json:"asset_id"` // token ID
+	Side            string          `json:"side"`     // taker 方向
+	Size            decimal.Decimal `json:"size"`
+	FeeRateBps      decimal.Decimal `json:"fee_rate_bps"`
+	Price           decimal.Decimal `json:"price"`
+	Status          TradeStatus     `json:"status"`
+	MatchTime       Timestamp       `json:"match_time"`
+	LastUpdate      Timestamp       `json:"last_update"`
+	Outcome         string          `json:"outcome"`
+	BucketIndex     int             `json:"bucket_index"`
+	Owner           string          `json:"owner"`
+	MakerAddress    string          `json:"maker_address"`
+	TransactionHash string          `json:"transaction_hash"`
+	TraderSide      TraderSide      `json:"trader_side"` // 当前用户在该成交中的角色
+	MakerOrders     []MakerOrder    `json:"maker_orders"`
+}
+
+// BuilderTrade Builder 归属的成交记录（GetBuilderTrades）
+type BuilderTrade struct {
+	ID              string          `json:"id"`
+	TradeType       TraderSide      `json:"tradeType"`
+	TakerOrderHash  string          `json:"takerOrderHash"`
+	Builder         string          `json:"builder"`
+	Market          string          `json:"market"`
+	AssetID         string          `json:"assetId"`
+	Side            string          `json:"side"`
+	Size            decimal.Decimal `json:"size"`
+	SizeUsdc        decimal.Decimal `json:"sizeUsdc"`
+	Price           decimal.Decimal `json:"price"`
+	Status          TradeStatus     `json:"status"`
+	Outcome         string          `json:"outcome"`
+	OutcomeIndex    int             `json:"outcomeIndex"`
+	Owner           string          `json:"owner"`
+	Maker           string          `json:"maker"`
+	TransactionHash string          `json:"transactionHash"`
+	MatchTime       Timestamp       `json:"matchTime"`
+	BucketIndex     int             `json:"bucketIndex"`
+	Fee             decimal.Decimal `json:"fee"`
+	FeeUsdc         decimal.Decimal `json:"feeUsdc"`
+	ErrMsg          string          `json:"err_msg"`
+	CreatedAt       Timestamp       `json:"createdAt"`
+	UpdatedAt       Timestamp       `json:"updatedAt"`
+}
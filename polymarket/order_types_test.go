@@ -0,0 +1,55 @@
+package polymarket
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestampUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+		raw  string
+	}{
+		{`1700000000`, time.Unix(1700000000, 0).UTC(), "1700000000"},
+		{`"1700000000"`, time.Unix(1700000000, 0).UTC(), "1700000000"},
+		{`"1700000000123"`, time.UnixMilli(1700000000123).UTC(), "1700000000123"},
+		{`"2024-05-01T12:00:00Z"`, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), "2024-05-01T12:00:00Z"},
+		{`"2024-05-01"`, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), "2024-05-01"},
+		{`""`, time.Time{}, ""},
+		{`null`, time.Time{}, "null"},
+		{`0`, time.Time{}, "0"},
+		{`"next tuesday"`, time.Time{}, "next tuesday"},
+		{`"1.5e9"`, time.Time{}, "1.5e9"},
+	}
+	for _, tt := range tests {
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(tt.in), &ts); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.in, err)
+		}
+		if !ts.Time.Equal(tt.want) {
+			t.Errorf("%s: time = %v, want %v", tt.in, ts.Time, tt.want)
+		}
+		if ts.Raw() != tt.raw {
+			t.Errorf("%s: raw = %q, want %q", tt.in, ts.Raw(), tt.raw)
+		}
+	}
+}
+
+func TestTimestampDoesNotFailEnclosingDecode(t *testing.T) {
+	data := `[{"id":"1","match_time":"not-a-time","price":"0.5"},{"id":"2","match_time":"1700000000","price":"0.6"}]`
+	var trades []Trade
+	if err := json.Unmarshal([]byte(data), &trades); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(trades) != 2 {
+		t.Fatalf("len = %d, want 2", len(trades))
+	}
+	if !trades[0].MatchTime.IsZero() || trades[0].MatchTime.Raw() != "not-a-time" {
+		t.Errorf("trades[0].MatchTime = %v (%q), want zero with raw value", trades[0].MatchTime.Time, trades[0].MatchTime.Raw())
+	}
+	if trades[1].MatchTime.Unix() != 1700000000 {
+		t.Errorf("trades[1].MatchTime = %v", trades[1].MatchTime.Time)
+	}
+}
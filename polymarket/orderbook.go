@@ -0,0 +1,446 @@
+package polymarket
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrOrderBookDrift 本地订单簿与服务器状态不一致（且未能自动重新同步）
+var ErrOrderBookDrift = errors.New("order book drift detected")
+
+// PriceLevel 订单簿价位
+type PriceLevel struct {
+	Price decimal.Decimal
+	Size  decimal.Decimal
+}
+
+// bookLevel 本地价位，保留服务器下发的原始字符串（如 "0.40"、"10.00"）用于 hash 校验
+type bookLevel struct {
+	PriceLevel
+	raw OrderSummary
+}
+
+// OrderBookConfig 本地订单簿配置
+type OrderBookConfig struct {
+	Client            *ClobClient   // 用于通过 GetOrderBook 重新同步，nil 时检测到偏差只返回 ErrOrderBookDrift
+	VerifyHash        bool          // 使用服务器下发的 hash 校验快照和增量更新后的本地状态
+	MinResyncInterval time.Duration // 两次自动同步的最小间隔，默认1秒
+}
+
+// OrderBook 本地维护的订单簿
+// 通过快照（BookEvent、GetOrderBook）和增量（PriceChangeEvent）更新；
+// 检测到偏差（hash 不一致、买卖盘交叉、与服务器推送的最优价不符）时自动从 REST 重新同步
+type OrderBook struct {
+	cfg     OrderBookConfig
+	assetID string
+
+	mu           sync.RWMutex
+	market       string
+	bids         map[string]bookLevel // 以规范化的价格字符串为键
+	asks         map[string]bookLevel
+	tickSize     TickSize
+	minOrderSize string
+	negRisk      bool
+	timestamp    string
+	hash         string
+	synced       bool
+	lastResync   time.Time
+	resyncs      int
+}
+
+// NewOrderBook 创建 assetID（token ID）的本地订单簿
+func NewOrderBook(assetID string, cfg OrderBookConfig) *OrderBook {
+	if cfg.MinResyncInterval <= 0 {
+		cfg.MinResyncInterval = time.Second
+	}
+	return &OrderBook{
+		cfg:     cfg,
+		assetID: assetID,
+		bids:    make(map[string]bookLevel),
+		asks:    make(map[string]bookLevel),
+	}
+}
+
+// AssetID 返回订单簿的 token ID
+func (b *OrderBook) AssetID() string {
+	return b.assetID
+}
+
+// Synced 是否已经应用过快照
+func (b *OrderBook) Synced() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.synced
+}
+
+// Resyncs 自动重新同步的次数
+func (b *OrderBook) Resyncs() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.resyncs
+}
+
+// ApplySnapshot 用快照替换整个订单簿
+func (b *OrderBook) ApplySnapshot(s *OrderBookSummary) error {
+	bids, err := parseLevels(s.Bids)
+	if err != nil {
+		return fmt.Errorf("invalid bids: %w", err)
+	}
+	asks, err := parseLevels(s.Asks)
+	if err != nil {
+		return fmt.Errorf("invalid asks: %w", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bids = bids
+	b.asks = asks
+	b.market = s.Market
+	b.timestamp = s.Timestamp
+	b.hash = s.Hash
+	// WebSocket 快照不带以下字段，保留 REST 快照中的值
+	if s.TickSize != "" {
+		b.tickSize = TickSize(s.TickSize)
+	}
+	if s.MinOrderSize != "" {
+		b.minOrderSize = s.MinOrderSize
+		b.negRisk = s.NegRisk
+	}
+	b.synced = true
+	return nil
+}
+
+// ApplyPriceChange 应用单个价位变化，Size 为0时移除该价位
+func (b *OrderBook) ApplyPriceChange(change PriceChange) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.applyChangeLocked(change)
+}
+
+func (b *OrderBook) applyChangeLocked(change PriceChange) {
+	levels := b.bids
+	if strings.EqualFold(change.Side, SELL) {
+		levels = b.asks
+	}
+	key := change.Price.String()
+	if change.Size.Sign() <= 0 {
+		delete(levels, key)
+		return
+	}
+	raw := OrderSummary{Price: change.rawPrice, Size: change.rawSize}
+	if raw.Price == "" {
+		raw.Price = change.Price.String()
+	}
+	if raw.Size == "" {
+		raw.Size = change.Size.String()
+	}
+	levels[key] = bookLevel{PriceLevel: PriceLevel{Price: change.Price, Size: change.Size}, raw: raw}
+}
+
+// Apply 应用市场频道事件，忽略其他 token 的事件
+// 检测到偏差时按配置自动重新同步；无法同步时返回 ErrOrderBookDrift
+func (b *OrderBook) Apply(ctx context.Context, ev MarketEvent) error {
+	switch e := ev.(type) {
+	case *BookEvent:
+		if e.AssetID != b.assetID {
+			return nil
+		}
+		// 按消息原样校验 hash（规范化和合并 REST 字段之前）
+		summary := e.Summary()
+		mismatch := b.cfg.VerifyHash && e.Hash != "" && wsBookHash(summary) != e.Hash
+		if err := b.ApplySnapshot(summary); err != nil {
+			return err
+		}
+		if mismatch {
+			return b.drift(ctx, fmt.Errorf("hash mismatch on snapshot"))
+		}
+
+	case *PriceChangeEvent:
+		var last *PriceChange
+		b.mu.Lock()
+		if !b.synced {
+			b.mu.Unlock()
+			return b.drift(ctx, fmt.Errorf("price change before snapshot"))
+		}
+		for i := range e.PriceChanges {
+			change := &e.PriceChanges[i]
+			if change.AssetID != b.assetID {
+				continue
+			}
+			b.applyChangeLocked(*change)
+			last = change
+		}
+		if last != nil {
+			if raw := e.Timestamp.Raw(); raw != "" {
+				b.timestamp = raw
+			} else if !e.Timestamp.IsZero() {
+				b.timestamp = fmt.Sprintf("%d", e.Timestamp.UnixMilli())
+			}
+		}
+		b.mu.Unlock()
+
+		if last == nil {
+			return nil
+		}
+		if err := b.checkConsistency(last); err != nil {
+			return b.drift(ctx, err)
+		}
+
+	case *TickSizeChangeEvent:
+		if e.AssetID == b.assetID {
+			b.mu.Lock()
+			b.tickSize = e.NewTickSize
+			b.mu.Unlock()
+		}
+
+	case *ReconnectEvent:
+		// 断线期间的增量已丢失，订阅恢复后服务器会推送新快照；没有快照来源时主动同步
+		if b.cfg.Client != nil {
+			return b.Resync(ctx)
+		}
+	}
+	return nil
+}
+
+// checkConsistency 增量更新后的一致性检查
+func (b *OrderBook) checkConsistency(last *PriceChange) error {
+	bid, hasBid := b.BestBid()
+	ask, hasAsk := b.BestAsk()
+
+	if hasBid && hasAsk && bid.Price.GreaterThanOrEqual(ask.Price) {
+		return fmt.Errorf("crossed book: bid %s >= ask %s", bid.Price, ask.Price)
+	}
+	// 服务器在 price_change 中附带变化后的最优价（为0表示未提供或该侧为空）
+	if last.BestBid.Sign() > 0 && (!hasBid || !bid.Price.Equal(last.BestBid)) {
+		return fmt.Errorf("best bid mismatch: local %s, server %s", bid.Price, last.BestBid)
+	}
+	if last.BestAsk.Sign() > 0 && (!hasAsk || !ask.Price.Equal(last.BestAsk)) {
+		return fmt.Errorf("best ask mismatch: local %s, server %s", ask.Price, last.BestAsk)
+	}
+	if b.cfg.VerifyHash && last.Hash != "" && !b.VerifyHash(last.Hash) {
+		return fmt.Errorf("hash mismatch after price change")
+	}
+	return nil
+}
+
+// drift 处理偏差：能同步则同步，否则返回 ErrOrderBookDrift
+func (b *OrderBook) drift(ctx context.Context, cause error) error {
+	b.mu.RLock()
+	tooSoon := time.Since(b.lastResync) < b.cfg.MinResyncInterval
+	b.mu.RUnlock()
+
+	if b.cfg.Client == nil || tooSoon {
+		return fmt.Errorf("%w: %s: %v", ErrOrderBookDrift, b.assetID, cause)
+	}
+	if err := b.Resync(ctx); err != nil {
+		return fmt.Errorf("%w: %s: %v (resync failed: %v)", ErrOrderBookDrift, b.assetID, cause, err)
+	}
+	return nil
+}
+
+// Resync 通过 GetOrderBook 重新获取完整快照
+func (b *OrderBook) Resync(ctx context.Context) error {
+	if b.cfg.Client == nil {
+		return fmt.Errorf("order book %s has no client to resync from", b.assetID)
+	}
+
+	b.mu.Lock()
+	b.lastResync = time.Now()
+	b.resyncs++
+	b.mu.Unlock()
+
+	summary, err := b.cfg.Client.GetOrderBook(ctx, b.assetID)
+	if err != nil {
+		return err
+	}
+	return b.ApplySnapshot(summary)
+}
+
+// Bids 买盘，按价格从高到低排序
+func (b *OrderBook) Bids() []PriceLevel {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return priceLevels(sortedLevels(b.bids, true))
+}
+
+// Asks 卖盘，按价格从低到高排序
+func (b *OrderBook) Asks() []PriceLevel {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return priceLevels(sortedLevels(b.asks, false))
+}
+
+// Depth 返回买卖盘最优的 levels 个价位（levels <= 0 返回全部）
+func (b *OrderBook) Depth(levels int) (bids, asks []PriceLevel) {
+	bids, asks = b.Bids(), b.Asks()
+	if levels > 0 {
+		if len(bids) > levels {
+			bids = bids[:levels]
+		}
+		if len(asks) > levels {
+			asks = asks[:levels]
+		}
+	}
+	return bids, asks
+}
+
+// BestBid 最优买价
+func (b *OrderBook) BestBid() (PriceLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return bestLevel(b.bids, true)
+}
+
+// BestAsk 最优卖价
+func (b *OrderBook) BestAsk() (PriceLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return bestLevel(b.asks, false)
+}
+
+// Mid 中点价格，任一侧为空时返回 false
+func (b *OrderBook) Mid() (decimal.Decimal, bool) {
+	bid, okBid := b.BestBid()
+	ask, okAsk := b.BestAsk()
+	if !okBid || !okAsk {
+		return decimal.Decimal{}, false
+	}
+	return bid.Price.Add(ask.Price).Div(decimal.NewFromInt(2)), true
+}
+
+// Spread 价差，任一侧为空时返回 false
+func (b *OrderBook) Spread() (decimal.Decimal, bool) {
+	bid, okBid := b.BestBid()
+	ask, okAsk := b.BestAsk()
+	if !okBid || !okAsk {
+		return decimal.Decimal{}, false
+	}
+	return ask.Price.Sub(bid.Price), true
+}
+
+// TickSize 当前的最小价格变动（来自 REST 快照或 tick_size_change 事件）
+func (b *OrderBook) TickSize() TickSize {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.tickSize
+}
+
+// Hash 最近一次快照中服务器下发的 hash
+func (b *OrderBook) Hash() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.hash
+}
+
+// Summary 导出为 OrderBookSummary，价位顺序与 GetOrderBook 一致（买盘升序、卖盘降序），价格和数量为服务器下发的原始字符串
+func (b *OrderBook) Summary() *OrderBookSummary {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return &OrderBookSummary{
+		Market:       b.market,
+		AssetID:      b.assetID,
+		Timestamp:    b.timestamp,
+		Bids:         toOrderSummaries(sortedLevels(b.bids, false)),
+		Asks:         toOrderSummaries(sortedLevels(b.asks, true)),
+		MinOrderSize: b.minOrderSize,
+		NegRisk:      b.negRisk,
+		TickSize:     string(b.tickSize),
+		Hash:         b.hash,
+	}
+}
+
+// VerifyHash 计算当前状态的 hash 并与 hash 比较
+// 只包含 WebSocket book 消息中的字段（market、asset_id、timestamp、bids、asks），
+// 不包含 REST 快照独有的 min_order_size、neg_risk、tick_size
+func (b *OrderBook) VerifyHash(hash string) bool {
+	return wsBookHash(b.Summary()) == hash
+}
+
+// wsBookHash 按 WebSocket book 消息的字段计算 hash，字段顺序与 GenerateOrderBookSummaryHash 相同
+func wsBookHash(s *OrderBookSummary) string {
+	payload := struct {
+		Market    string         `json:"market"`
+		AssetID   string         `json:"asset_id"`
+		Timestamp string         `json:"timestamp"`
+		Bids      []OrderSummary `json:"bids"`
+		Asks      []OrderSummary `json:"asks"`
+		Hash      string         `json:"hash"`
+	}{s.Market, s.AssetID, s.Timestamp, s.Bids, s.Asks, ""}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha1.Sum(data))
+}
+
+// parseLevels 解析快照中的价位
+func parseLevels(summaries []OrderSummary) (map[string]bookLevel, error) {
+	levels := make(map[string]bookLevel, len(summaries))
+	for _, s := range summaries {
+		price, err := decimal.NewFromString(s.Price)
+		if err != nil {
+			return nil, fmt.Errorf("price %q: %w", s.Price, err)
+		}
+		size, err := decimal.NewFromString(s.Size)
+		if err != nil {
+			return nil, fmt.Errorf("size %q: %w", s.Size, err)
+		}
+		if size.Sign() > 0 {
+			levels[price.String()] = bookLevel{PriceLevel: PriceLevel{Price: price, Size: size}, raw: s}
+		}
+	}
+	return levels, nil
+}
+
+func sortedLevels(levels map[string]bookLevel, descending bool) []bookLevel {
+	result := make([]bookLevel, 0, len(levels))
+	for _, level := range levels {
+		result = append(result, level)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if descending {
+			return result[i].Price.GreaterThan(result[j].Price)
+		}
+		return result[i].Price.LessThan(result[j].Price)
+	})
+	return result
+}
+
+func bestLevel(levels map[string]bookLevel, highest bool) (PriceLevel, bool) {
+	var best PriceLevel
+	found := false
+	for _, level := range levels {
+		if !found || (highest && level.Price.GreaterThan(best.Price)) || (!highest && level.Price.LessThan(best.Price)) {
+			best = level.PriceLevel
+			found = true
+		}
+	}
+	return best, found
+}
+
+func priceLevels(levels []bookLevel) []PriceLevel {
+	result := make([]PriceLevel, len(levels))
+	for i, level := range levels {
+		result[i] = level.PriceLevel
+	}
+	return result
+}
+
+func toOrderSummaries(levels []bookLevel) []OrderSummary {
+	result := make([]OrderSummary, len(levels))
+	for i, level := range levels {
+		result[i] = level.raw
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package polymarket
+
+import (
+	"context"
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// sha1Hex 独立计算期望的 hash：直接对服务器字段的 JSON 文本求 SHA1
+func sha1Hex(s string) string {
+	return fmt.Sprintf("%x", sha1.Sum([]byte(s)))
+}
+
+func mustMarketEvent(t *testing.T, data string) MarketEvent {
+	t.Helper()
+	ev, err := parseMarketEvent([]byte(data))
+	if err != nil {
+		t.Fatalf("parseMarketEvent: %v", err)
+	}
+	return ev
+}
+
+const (
+	testBookBids = `[{"price":"0.30","size":"10.00"},{"price":"0.40","size":"5"}]`
+	testBookAsks = `[{"price":"0.60","size":"7.5"},{"price":"0.50","size":"2.00"}]`
+)
+
+func testBookEvent(t *testing.T) (MarketEvent, string) {
+	hash := sha1Hex(`{"market":"0xm","asset_id":"123","timestamp":"1700000000000","bids":` + testBookBids + `,"asks":` + testBookAsks + `,"hash":""}`)
+	ev := mustMarketEvent(t, `{"event_type":"book","market":"0xm","asset_id":"123","timestamp":"1700000000000","hash":"`+hash+`","bids":`+testBookBids+`,"asks":`+testBookAsks+`}`)
+	return ev, hash
+}
+
+func TestOrderBookSnapshotHashUsesRawStrings(t *testing.T) {
+	ev, hash := testBookEvent(t)
+	book := NewOrderBook("123", OrderBookConfig{VerifyHash: true})
+	if err := book.Apply(context.Background(), ev); err != nil {
+		t.Fatalf("Apply(book) = %v", err)
+	}
+	if !book.VerifyHash(hash) {
+		t.Errorf("VerifyHash after snapshot = false, want true")
+	}
+	if bid, _ := book.BestBid(); bid.Price.String() != "0.4" {
+		t.Errorf("best bid = %s, want 0.4", bid.Price)
+	}
+}
+
+func TestOrderBookWSSnapshotAfterRESTSnapshot(t *testing.T) {
+	book := NewOrderBook("123", OrderBookConfig{VerifyHash: true})
+	rest := &OrderBookSummary{
+		Market:       "0xm",
+		AssetID:      "123",
+		Timestamp:    "1699999999000",
+		Bids:         []OrderSummary{{Price: "0.20", Size: "1"}},
+		Asks:         []OrderSummary{{Price: "0.80", Size: "1"}},
+		MinOrderSize: "5",
+		TickSize:     "0.01",
+		NegRisk:      true,
+	}
+	if err := book.ApplySnapshot(rest); err != nil {
+		t.Fatalf("ApplySnapshot = %v", err)
+	}
+
+	ev, hash := testBookEvent(t)
+	if err := book.Apply(context.Background(), ev); err != nil {
+		t.Fatalf("Apply(book) after REST snapshot = %v", err)
+	}
+	if !book.VerifyHash(hash) {
+		t.Errorf("VerifyHash = false, want true")
+	}
+	if book.TickSize() != "0.01" {
+		t.Errorf("TickSize = %q, want REST value kept", book.TickSize())
+	}
+}
+
+func TestOrderBookSnapshotHashMismatch(t *testing.T) {
+	ev := mustMarketEvent(t, `{"event_type":"book","market":"0xm","asset_id":"123","timestamp":"1700000000000","hash":"deadbeef","bids":`+testBookBids+`,"asks":`+testBookAsks+`}`)
+	book := NewOrderBook("123", OrderBookConfig{VerifyHash: true})
+	if err := book.Apply(context.Background(), ev); !errors.Is(err, ErrOrderBookDrift) {
+		t.Fatalf("Apply = %v, want ErrOrderBookDrift", err)
+	}
+}
+
+func TestOrderBookPriceChangeHashRoundTrip(t *testing.T) {
+	ev, _ := testBookEvent(t)
+	book := NewOrderBook("123", OrderBookConfig{VerifyHash: true})
+	if err := book.Apply(context.Background(), ev); err != nil {
+		t.Fatalf("Apply(book) = %v", err)
+	}
+
+	// 更新 0.40 买盘、新增 0.45 买盘、移除 0.50 卖盘
+	changes := []struct {
+		price, size, side string
+		book              string // 变化后的订单簿 JSON（只含 WebSocket book 字段）
+		bestBid, bestAsk  string
+		timestamp         string
+	}{
+		{"0.40", "8.50", "BUY",
+			`{"market":"0xm","asset_id":"123","timestamp":"1700000000100","bids":[{"price":"0.30","size":"10.00"},{"price":"0.40","size":"8.50"}],"asks":` + testBookAsks + `,"hash":""}`,
+			"0.40", "0.50", "1700000000100"},
+		{"0.45", "1.0", "BUY",
+			`{"market":"0xm","asset_id":"123","timestamp":"1700000000200","bids":[{"price":"0.30","size":"10.00"},{"price":"0.40","size":"8.50"},{"price":"0.45","size":"1.0"}],"asks":` + testBookAsks + `,"hash":""}`,
+			"0.45", "0.50", "1700000000200"},
+		{"0.50", "0", "SELL",
+			`{"market":"0xm","asset_id":"123","timestamp":"1700000000300","bids":[{"price":"0.30","size":"10.00"},{"price":"0.40","size":"8.50"},{"price":"0.45","size":"1.0"}],"asks":[{"price":"0.60","size":"7.5"}],"hash":""}`,
+			"0.45", "0.60", "1700000000300"},
+	}
+	for _, c := range changes {
+		hash := sha1Hex(c.book)
+		msg := fmt.Sprintf(`{"event_type":"price_change","market":"0xm","timestamp":"%s","price_changes":[{"asset_id":"123","price":"%s","size":"%s","side":"%s","hash":"%s","best_bid":"%s","best_ask":"%s"}]}`,
+			c.timestamp, c.price, c.size, c.side, hash, c.bestBid, c.bestAsk)
+		if err := book.Apply(context.Background(), mustMarketEvent(t, msg)); err != nil {
+			t.Fatalf("Apply(price_change %s %s) = %v", c.price, c.size, err)
+		}
+		if !book.VerifyHash(hash) {
+			t.Fatalf("VerifyHash after price change %s %s = false", c.price, c.size)
+		}
+		if got := book.Summary(); sha1Hex(c.book) != wsBookHash(got) {
+			t.Fatalf("Summary = %+v", got)
+		}
+	}
+
+	// 服务器 hash 与本地状态不一致时报告偏差
+	msg := `{"event_type":"price_change","market":"0xm","timestamp":"1700000000400","price_changes":[{"asset_id":"123","price":"0.30","size":"9","side":"BUY","hash":"deadbeef"}]}`
+	if err := book.Apply(context.Background(), mustMarketEvent(t, msg)); !errors.Is(err, ErrOrderBookDrift) {
+		t.Fatalf("Apply(bad hash) = %v, want ErrOrderBookDrift", err)
+	}
+}
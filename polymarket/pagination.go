@@ -0,0 +1,155 @@
+package polymarket
+
+import (
+	"context"
+	"iter"
+)
+
+// 游标分页迭代器
+//
+// IterXxx 逐条返回数据，按需请求下一页，break 即可提前停止；
+// IterXxxPages 逐页返回数据，可以在处理完一页后保存 page.NextCursor，
+// 崩溃重启后将其作为 cursor 参数传入即可从断点继续。
+
+// fetchPage 请求一页数据并解码为 Page[T]
+func fetchPage[T any](ctx context.Context, c *ClobClient, path string, headers map[string]string) (*Page[T], error) {
+	var page Page[T]
+	if err := c.httpClient.GetInto(ctx, path, headers, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// iterPages 从 cursor 开始逐页读取，直到 EndCursor、出错或调用方停止
+func iterPages[T any](ctx context.Context, cursor string, fetch func(ctx context.Context, cursor string) (*Page[T], error)) iter.Seq2[*Page[T], error] {
+	return func(yield func(*Page[T], error) bool) {
+		// 每次遍历使用自己的游标，同一个迭代器可以重复或并发遍历
+		cur := cursor
+		if cur == "" {
+			cur = "MA=="
+		}
+		for cur != EndCursor {
+			page, err := fetch(ctx, cur)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(page, nil) || !page.HasNext() {
+				return
+			}
+			cur = page.NextCursor
+		}
+	}
+}
+
+// pageItems 将分页迭代器展开为逐条迭代器
+func pageItems[T any](pages iter.Seq2[*Page[T], error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for page, err := range pages {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// collectPages 读取全部分页数据
+func collectPages[T any](pages iter.Seq2[*Page[T], error]) ([]T, error) {
+	var results []T
+	for page, err := range pages {
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, page.Data...)
+	}
+	return results, nil
+}
+
+// IterOrderPages 从 cursor 开始逐页读取订单（空字符串表示从头开始）
+// 需要L2认证
+func (c *ClobClient) IterOrderPages(ctx context.Context, params *OpenOrderParams, cursor string) iter.Seq2[*Page[OpenOrder], error] {
+	return iterPages(ctx, cursor, func(ctx context.Context, cursor string) (*Page[OpenOrder], error) {
+		return c.getOrdersPage(ctx, params, cursor)
+	})
+}
+
+// IterOrders 逐条读取订单
+// 需要L2认证
+func (c *ClobClient) IterOrders(ctx context.Context, params *OpenOrderParams) iter.Seq2[OpenOrder, error] {
+	return pageItems(c.IterOrderPages(ctx, params, ""))
+}
+
+// IterTradePages 从 cursor 开始逐页读取成交记录（空字符串表示从头开始）
+// 需要L2认证
+func (c *ClobClient) IterTradePages(ctx context.Context, params *TradeParams, cursor string) iter.Seq2[*Page[Trade], error] {
+	return iterPages(ctx, cursor, func(ctx context.Context, cursor string) (*Page[Trade], error) {
+		return c.getTradesPage(ctx, params, cursor)
+	})
+}
+
+// IterTrades 逐条读取成交记录
+// 需要L2认证
+func (c *ClobClient) IterTrades(ctx context.Context, params *TradeParams) iter.Seq2[Trade, error] {
+	return pageItems(c.IterTradePages(ctx, params, ""))
+}
+
+// IterBuilderTradePages 从 cursor 开始逐页读取Builder交易记录（空字符串表示从头开始）
+// 需要Builder认证
+func (c *ClobClient) IterBuilderTradePages(ctx context.Context, params *TradeParams, cursor string) iter.Seq2[*Page[BuilderTrade], error] {
+	return iterPages(ctx, cursor, func(ctx context.Context, cursor string) (*Page[BuilderTrade], error) {
+		return c.getBuilderTradesPage(ctx, params, cursor)
+	})
+}
+
+// IterBuilderTrades 逐条读取Builder交易记录
+// 需要Builder认证
+func (c *ClobClient) IterBuilderTrades(ctx context.Context, params *TradeParams) iter.Seq2[BuilderTrade, error] {
+	return pageItems(c.IterBuilderTradePages(ctx, params, ""))
+}
+
+// IterMarketPages 从 cursor 开始逐页读取市场（空字符串表示从头开始）
+func (c *ClobClient) IterMarketPages(ctx context.Context, cursor string) iter.Seq2[*Page[Market], error] {
+	return iterPages(ctx, cursor, c.GetMarkets)
+}
+
+// IterMarkets 逐条读取市场
+func (c *ClobClient) IterMarkets(ctx context.Context) iter.Seq2[Market, error] {
+	return pageItems(c.IterMarketPages(ctx, ""))
+}
+
+// IterSimplifiedMarketPages 从 cursor 开始逐页读取简化市场（空字符串表示从头开始）
+func (c *ClobClient) IterSimplifiedMarketPages(ctx context.Context, cursor string) iter.Seq2[*Page[SimplifiedMarket], error] {
+	return iterPages(ctx, cursor, c.GetSimplifiedMarkets)
+}
+
+// IterSimplifiedMarkets 逐条读取简化市场
+func (c *ClobClient) IterSimplifiedMarkets(ctx context.Context) iter.Seq2[SimplifiedMarket, error] {
+	return pageItems(c.IterSimplifiedMarketPages(ctx, ""))
+}
+
+// IterSamplingMarketPages 从 cursor 开始逐页读取采样市场（空字符串表示从头开始）
+func (c *ClobClient) IterSamplingMarketPages(ctx context.Context, cursor string) iter.Seq2[*Page[Market], error] {
+	return iterPages(ctx, cursor, c.GetSamplingMarkets)
+}
+
+// IterSamplingMarkets 逐条读取采样市场
+func (c *ClobClient) IterSamplingMarkets(ctx context.Context) iter.Seq2[Market, error] {
+	return pageItems(c.IterSamplingMarketPages(ctx, ""))
+}
+
+// IterSamplingSimplifiedMarketPages 从 cursor 开始逐页读取采样简化市场（空字符串表示从头开始）
+func (c *ClobClient) IterSamplingSimplifiedMarketPages(ctx context.Context, cursor string) iter.Seq2[*Page[SimplifiedMarket], error] {
+	return iterPages(ctx, cursor, c.GetSamplingSimplifiedMarkets)
+}
+
+// IterSamplingSimplifiedMarkets 逐条读取采样简化市场
+func (c *ClobClient) IterSamplingSimplifiedMarkets(ctx context.Context) iter.Seq2[SimplifiedMarket, error] {
+	return pageItems(c.IterSamplingSimplifiedMarketPages(ctx, ""))
+}
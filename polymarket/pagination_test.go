@@ -0,0 +1,143 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// pageFetcher 按游标返回预设的分页，记录请求过的游标
+type pageFetcher struct {
+	pages map[string]*Page[int]
+	err   map[string]error
+
+	mu      sync.Mutex
+	cursors []string
+}
+
+func (f *pageFetcher) fetch(ctx context.Context, cursor string) (*Page[int], error) {
+	f.mu.Lock()
+	f.cursors = append(f.cursors, cursor)
+	f.mu.Unlock()
+	if err := f.err[cursor]; err != nil {
+		return nil, err
+	}
+	page, ok := f.pages[cursor]
+	if !ok {
+		return nil, errors.New("unexpected cursor " + cursor)
+	}
+	return page, nil
+}
+
+func threePages() *pageFetcher {
+	return &pageFetcher{pages: map[string]*Page[int]{
+		"MA==": {Data: []int{1, 2}, NextCursor: "Mg=="},
+		"Mg==": {Data: []int{3, 4}, NextCursor: "NA=="},
+		"NA==": {Data: []int{5}, NextCursor: EndCursor},
+	}}
+}
+
+func collectItems(t *testing.T, pages iter.Seq2[*Page[int], error]) []int {
+	t.Helper()
+	items, err := collectPages(pages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return items
+}
+
+func TestIterPagesMultiplePages(t *testing.T) {
+	f := threePages()
+	got := collectItems(t, iterPages(context.Background(), "", f.fetch))
+	if want := []int{1, 2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("items = %v, want %v", got, want)
+	}
+	if want := []string{"MA==", "Mg==", "NA=="}; !reflect.DeepEqual(f.cursors, want) {
+		t.Errorf("cursors = %v, want %v", f.cursors, want)
+	}
+}
+
+func TestIterPagesResumeFromCursor(t *testing.T) {
+	f := threePages()
+	got := collectItems(t, iterPages(context.Background(), "Mg==", f.fetch))
+	if want := []int{3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("items = %v, want %v", got, want)
+	}
+}
+
+func TestIterPagesStopEarly(t *testing.T) {
+	f := threePages()
+	var got []int
+	for item, err := range pageItems(iterPages(context.Background(), "", f.fetch)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, item)
+		if item == 3 {
+			break
+		}
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("items = %v, want %v", got, want)
+	}
+	// 停止后不再请求后续分页
+	if want := []string{"MA==", "Mg=="}; !reflect.DeepEqual(f.cursors, want) {
+		t.Errorf("cursors = %v, want %v", f.cursors, want)
+	}
+}
+
+func TestIterPagesRangeTwice(t *testing.T) {
+	f := threePages()
+	pages := iterPages(context.Background(), "", f.fetch)
+	first := collectItems(t, pages)
+	second := collectItems(t, pages)
+	if !reflect.DeepEqual(first, second) || len(second) != 5 {
+		t.Errorf("second range = %v, want %v", second, first)
+	}
+}
+
+func TestIterPagesConcurrentRanges(t *testing.T) {
+	f := threePages()
+	pages := iterPages(context.Background(), "", f.fetch)
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			items, err := collectPages(pages)
+			if err != nil || len(items) != 5 {
+				t.Errorf("items = %v, err = %v, want 5 items", items, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestIterPagesFetchError(t *testing.T) {
+	f := threePages()
+	wantErr := errors.New("boom")
+	f.err = map[string]error{"Mg==": wantErr}
+
+	var got []int
+	var gotErr error
+	for item, err := range pageItems(iterPages(context.Background(), "", f.fetch)) {
+		if err != nil {
+			gotErr = err
+			continue
+		}
+		got = append(got, item)
+	}
+	if !errors.Is(gotErr, wantErr) {
+		t.Errorf("err = %v, want %v", gotErr, wantErr)
+	}
+	if want := []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("items before error = %v, want %v", got, want)
+	}
+
+	if _, err := collectPages(iterPages(context.Background(), "", f.fetch)); !errors.Is(err, wantErr) {
+		t.Errorf("collectPages err = %v, want %v", err, wantErr)
+	}
+}
@@ -0,0 +1,210 @@
+package polymarket
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointGroup 限流分组，对应 Polymarket 服务端的限流桶
+type EndpointGroup string
+
+const (
+	EndpointGroupMarketData     EndpointGroup = "market_data"     // 订单簿、价格、市场信息
+	EndpointGroupOrderPlacement EndpointGroup = "order_placement" // POST /order, /orders
+	EndpointGroupCancellation   EndpointGroup = "cancellation"    // DELETE /order, /orders, /cancel-all, /cancel-market-orders
+	EndpointGroupAuth           EndpointGroup = "auth"            // /auth/*
+	EndpointGroupData           EndpointGroup = "data"            // /data/*（订单和成交查询）
+	EndpointGroupOther          EndpointGroup = "other"           // 其他端点
+)
+
+// ErrRateLimitExceeded 客户端限流（快速失败模式下令牌不足）
+// 同时满足 errors.Is(err, ErrRateLimited)
+var ErrRateLimitExceeded = fmt.Errorf("client-side rate limit exceeded: %w", ErrRateLimited)
+
+// RateLimiter 限流器接口
+type RateLimiter interface {
+	// Wait 为一次请求获取令牌，阻塞直到可用或ctx取消；快速失败模式下立即返回 ErrRateLimitExceeded
+	Wait(ctx context.Context, group EndpointGroup) error
+	// Usage 返回各分组当前的令牌使用情况
+	Usage() map[EndpointGroup]BucketUsage
+}
+
+// BucketConfig 令牌桶配置
+type BucketConfig struct {
+	Rate  float64 // 每秒补充的令牌数
+	Burst int     // 桶容量
+}
+
+// BucketUsage 令牌桶使用情况（用于监控）
+type BucketUsage struct {
+	Rate      float64 // 每秒补充的令牌数
+	Burst     int     // 桶容量
+	Available float64 // 当前可用令牌数（等待中的请求会使其为负）
+	Allowed   uint64  // 已放行的请求数
+	Rejected  uint64  // 快速失败模式下被拒绝的请求数
+}
+
+// DefaultRateLimits 默认限流配置，略低于 Polymarket 公布的服务端限制
+func DefaultRateLimits() map[EndpointGroup]BucketConfig {
+	return map[EndpointGroup]BucketConfig{
+		EndpointGroupMarketData:     {Rate: 50, Burst: 100},
+		EndpointGroupOrderPlacement: {Rate: 60, Burst: 240},
+		EndpointGroupCancellation:   {Rate: 50, Burst: 200},
+		EndpointGroupAuth:           {Rate: 10, Burst: 20},
+		EndpointGroupData:           {Rate: 50, Burst: 50},
+		EndpointGroupOther:          {Rate: 100, Burst: 200},
+	}
+}
+
+// TokenBucketLimiter 按端点分组的令牌桶限流器
+type TokenBucketLimiter struct {
+	buckets  map[EndpointGroup]*tokenBucket
+	failFast bool
+}
+
+// NewTokenBucketLimiter 创建令牌桶限流器
+// limits: 各分组的配置，nil 使用 DefaultRateLimits；未配置的分组不限流
+// failFast: true 时令牌不足立即返回 ErrRateLimitExceeded，false 时阻塞等待
+func NewTokenBucketLimiter(limits map[EndpointGroup]BucketConfig, failFast bool) *TokenBucketLimiter {
+	if limits == nil {
+		limits = DefaultRateLimits()
+	}
+
+	now := time.Now()
+	buckets := make(map[EndpointGroup]*tokenBucket, len(limits))
+	for group, cfg := range limits {
+		buckets[group] = &tokenBucket{
+			rate:   cfg.Rate,
+			burst:  cfg.Burst,
+			tokens: float64(cfg.Burst),
+			last:   now,
+		}
+	}
+
+	return &TokenBucketLimiter{
+		buckets:  buckets,
+		failFast: failFast,
+	}
+}
+
+// Wait 实现 RateLimiter
+func (l *TokenBucketLimiter) Wait(ctx context.Context, group EndpointGroup) error {
+	bucket, ok := l.buckets[group]
+	if !ok {
+		return nil
+	}
+	return bucket.wait(ctx, l.failFast)
+}
+
+// Usage 实现 RateLimiter
+func (l *TokenBucketLimiter) Usage() map[EndpointGroup]BucketUsage {
+	usage := make(map[EndpointGroup]BucketUsage, len(l.buckets))
+	for group, bucket := range l.buckets {
+		usage[group] = bucket.usage()
+	}
+	return usage
+}
+
+// tokenBucket 单个令牌桶
+// 阻塞模式下采用预约方式：令牌可以为负，调用方按欠额等待
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64
+	burst    int
+	tokens   float64
+	last     time.Time
+	allowed  uint64
+	rejected uint64
+}
+
+// refill 根据流逝的时间补充令牌，调用方需持有锁
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	b.last = now
+	b.tokens = math.Min(float64(b.burst), b.tokens+elapsed*b.rate)
+}
+
+func (b *tokenBucket) wait(ctx context.Context, failFast bool) error {
+	b.mu.Lock()
+	b.refill(time.Now())
+
+	if b.tokens >= 1 {
+		b.tokens--
+		b.allowed++
+		b.mu.Unlock()
+		return nil
+	}
+
+	if failFast || b.rate <= 0 {
+		b.rejected++
+		b.mu.Unlock()
+		return ErrRateLimitExceeded
+	}
+
+	// 预约一个令牌，等待欠额补足
+	delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	b.tokens--
+	b.allowed++
+	b.mu.Unlock()
+
+	if err := sleepContext(ctx, delay); err != nil {
+		// 取消预约，归还令牌
+		b.mu.Lock()
+		b.tokens++
+		b.allowed--
+		b.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (b *tokenBucket) usage() BucketUsage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(time.Now())
+	return BucketUsage{
+		Rate:      b.rate,
+		Burst:     b.burst,
+		Available: b.tokens,
+		Allowed:   b.allowed,
+		Rejected:  b.rejected,
+	}
+}
+
+// ClassifyEndpoint 根据请求方法和路径确定限流分组
+func ClassifyEndpoint(method, path string) EndpointGroup {
+	path = stripQuery(path)
+
+	switch {
+	case strings.HasPrefix(path, "/auth/"):
+		return EndpointGroupAuth
+	case strings.HasPrefix(path, "/data/"):
+		return EndpointGroupData
+	}
+
+	switch path {
+	case PostOrder, PostOrders:
+		// /order 和 /orders 同时用于下单（POST）和撤单（DELETE）
+		if method == http.MethodDelete {
+			return EndpointGroupCancellation
+		}
+		return EndpointGroupOrderPlacement
+	case CancelAll, CancelMarketOrders:
+		return EndpointGroupCancellation
+	case GetOrderBook, GetOrderBooks, MidPoint, MidPoints, Price, GetPrices,
+		GetSpread, GetSpreads, GetLastTradePrice, GetLastTradesPrices,
+		GetTickSize, GetNegRisk, GetFeeRate, GetMarkets, GetSimplifiedMarkets,
+		GetSamplingMarkets, GetSamplingSimplifiedMarkets:
+		return EndpointGroupMarketData
+	}
+
+	if strings.HasPrefix(path, GetMarket) || strings.HasPrefix(path, GetMarketTradesEvents) {
+		return EndpointGroupMarketData
+	}
+	return EndpointGroupOther
+}
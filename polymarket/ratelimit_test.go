@@ -0,0 +1,176 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClassifyEndpoint(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   EndpointGroup
+	}{
+		{http.MethodGet, GetOrderBook + "?token_id=1", EndpointGroupMarketData},
+		{http.MethodPost, GetOrderBooks, EndpointGroupMarketData},
+		{http.MethodGet, MidPoint, EndpointGroupMarketData},
+		{http.MethodGet, GetTickSize + "?token_id=1", EndpointGroupMarketData},
+		{http.MethodGet, GetMarkets + "?next_cursor=MA==", EndpointGroupMarketData},
+		{http.MethodGet, GetMarket + "0xabc", EndpointGroupMarketData},
+		{http.MethodGet, GetMarketTradesEvents + "0xabc", EndpointGroupMarketData},
+		{http.MethodPost, PostOrder, EndpointGroupOrderPlacement},
+		{http.MethodPost, PostOrders, EndpointGroupOrderPlacement},
+		{http.MethodDelete, Cancel, EndpointGroupCancellation},
+		{http.MethodDelete, CancelOrders, EndpointGroupCancellation},
+		{http.MethodDelete, CancelAll, EndpointGroupCancellation},
+		{http.MethodDelete, CancelMarketOrders, EndpointGroupCancellation},
+		{http.MethodPost, CreateAPIKey, EndpointGroupAuth},
+		{http.MethodGet, DeriveAPIKey, EndpointGroupAuth},
+		{http.MethodGet, Trades + "?after=1", EndpointGroupData},
+		{http.MethodGet, GetOrder + "0x1", EndpointGroupData},
+		{http.MethodGet, Time, EndpointGroupOther},
+		{http.MethodGet, GetBalanceAllowance, EndpointGroupOther},
+		{http.MethodPost, CreateRFQRequest, EndpointGroupOther},
+	}
+	for _, tt := range tests {
+		if got := ClassifyEndpoint(tt.method, tt.path); got != tt.want {
+			t.Errorf("ClassifyEndpoint(%s, %s) = %s, want %s", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestTokenBucketRefill(t *testing.T) {
+	start := time.Now()
+	b := &tokenBucket{rate: 10, burst: 5, tokens: 0, last: start}
+
+	b.refill(start.Add(200 * time.Millisecond))
+	if b.tokens != 2 {
+		t.Errorf("tokens after 200ms = %v, want 2", b.tokens)
+	}
+	// 不超过桶容量
+	b.refill(start.Add(10 * time.Second))
+	if b.tokens != 5 {
+		t.Errorf("tokens after 10s = %v, want burst 5", b.tokens)
+	}
+	// 预约产生的欠额同样按速率补回
+	b.tokens = -1
+	b.refill(start.Add(10*time.Second + 150*time.Millisecond))
+	if b.tokens < 0.49 || b.tokens > 0.51 {
+		t.Errorf("tokens after 150ms from -1 = %v, want 0.5", b.tokens)
+	}
+}
+
+func TestTokenBucketLimiterFailFast(t *testing.T) {
+	limiter := NewTokenBucketLimiter(map[EndpointGroup]BucketConfig{
+		EndpointGroupOrderPlacement: {Rate: 1, Burst: 2},
+	}, true)
+	ctx := context.Background()
+
+	for i := range 2 {
+		if err := limiter.Wait(ctx, EndpointGroupOrderPlacement); err != nil {
+			t.Fatalf("request %d within burst: %v", i, err)
+		}
+	}
+	err := limiter.Wait(ctx, EndpointGroupOrderPlacement)
+	if !errors.Is(err, ErrRateLimitExceeded) || !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("request over burst = %v, want ErrRateLimitExceeded", err)
+	}
+	// 未配置的分组不限流
+	for range 10 {
+		if err := limiter.Wait(ctx, EndpointGroupMarketData); err != nil {
+			t.Fatalf("unconfigured group: %v", err)
+		}
+	}
+
+	usage := limiter.Usage()[EndpointGroupOrderPlacement]
+	if usage.Allowed != 2 || usage.Rejected != 1 || usage.Burst != 2 || usage.Rate != 1 {
+		t.Errorf("usage = %+v, want 2 allowed, 1 rejected", usage)
+	}
+}
+
+func TestTokenBucketLimiterBlocks(t *testing.T) {
+	limiter := NewTokenBucketLimiter(map[EndpointGroup]BucketConfig{
+		EndpointGroupMarketData: {Rate: 20, Burst: 1},
+	}, false)
+	ctx := context.Background()
+
+	// 第一个请求使用桶内令牌，之后每个请求等待 1/Rate = 50ms
+	start := time.Now()
+	for range 3 {
+		if err := limiter.Wait(ctx, EndpointGroupMarketData); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("3 requests with burst 1 at 20/s took %s, want at least 100ms", elapsed)
+	}
+	if usage := limiter.Usage()[EndpointGroupMarketData]; usage.Allowed != 3 || usage.Rejected != 0 {
+		t.Errorf("usage = %+v, want 3 allowed", usage)
+	}
+}
+
+func TestTokenBucketLimiterCancelReturnsToken(t *testing.T) {
+	limiter := NewTokenBucketLimiter(map[EndpointGroup]BucketConfig{
+		EndpointGroupMarketData: {Rate: 0.1, Burst: 1},
+	}, false)
+	if err := limiter.Wait(context.Background(), EndpointGroupMarketData); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx, EndpointGroupMarketData); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait = %v, want context.DeadlineExceeded", err)
+	}
+	// 取消的预约不占用令牌
+	if usage := limiter.Usage()[EndpointGroupMarketData]; usage.Allowed != 1 || usage.Available < -0.01 {
+		t.Errorf("usage after cancel = %+v, want 1 allowed and no debt", usage)
+	}
+}
+
+// recordingLimiter 记录每次请求的限流分组
+type recordingLimiter struct {
+	mu     sync.Mutex
+	groups []EndpointGroup
+}
+
+func (l *recordingLimiter) Wait(ctx context.Context, group EndpointGroup) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.groups = append(l.groups, group)
+	return nil
+}
+
+func (l *recordingLimiter) Usage() map[EndpointGroup]BucketUsage {
+	return nil
+}
+
+func TestHTTPClientRateLimiterOptIn(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(server.URL)
+	if client.RateLimiter() != nil {
+		t.Fatalf("default limiter = %T, want nil (opt-in)", client.RateLimiter())
+	}
+
+	limiter := &recordingLimiter{}
+	client.SetRateLimiter(limiter)
+	ctx := context.Background()
+	if _, err := client.Get(ctx, GetOrderBook+"?token_id=1", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Delete(ctx, CancelAll, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(limiter.groups) != 2 || limiter.groups[0] != EndpointGroupMarketData || limiter.groups[1] != EndpointGroupCancellation {
+		t.Errorf("limited groups = %v, want [market_data cancellation]", limiter.groups)
+	}
+}
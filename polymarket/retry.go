@@ -0,0 +1,166 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// DefaultMaxRetryAfter MaxBackoff 未设置时允许等待的最长 Retry-After
+const DefaultMaxRetryAfter = 30 * time.Second
+
+// RetryPolicy HTTP重试策略
+// 默认只重试幂等的读请求；下单等非幂等请求只有在 RetryNonIdempotent 为 true 时才会重试。
+// 服务器返回的 Retry-After 优先于退避时间，但超过 MaxBackoff（未设置时为 DefaultMaxRetryAfter）时不再重试，
+// 直接返回错误，调用方可以通过 APIError.RetryAfter 自行安排重试
+type RetryPolicy struct {
+	MaxAttempts        int           // 最大尝试次数（包含首次请求），<=1 表示不重试
+	InitialBackoff     time.Duration // 首次重试前的等待时间
+	MaxBackoff         time.Duration // 单次等待时间上限，同时限制可接受的 Retry-After
+	Multiplier         float64       // 指数退避倍数
+	Jitter             float64       // 抖动比例（0-1），实际等待时间在 [d*(1-Jitter), d] 之间
+	RetryNonIdempotent bool          // 是否重试非幂等请求（可能导致重复下单/撤单）
+}
+
+// DefaultRetryPolicy 默认重试策略：最多3次，100ms起步指数退避
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.5,
+	}
+}
+
+// NoRetryPolicy 不重试
+func NoRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 1}
+}
+
+// readOnlyPostPaths 使用POST但只读的市场数据端点，可以安全重试
+var readOnlyPostPaths = map[string]bool{
+	GetOrderBooks:       true,
+	MidPoints:           true,
+	GetPrices:           true,
+	GetSpreads:          true,
+	GetLastTradesPrices: true,
+}
+
+// canRetry 请求是否允许重试
+func (p *RetryPolicy) canRetry(method, path string) bool {
+	if p == nil || p.MaxAttempts <= 1 {
+		return false
+	}
+	if p.RetryNonIdempotent {
+		return true
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		return readOnlyPostPaths[stripQuery(path)]
+	}
+	return false
+}
+
+// backoff 计算第 attempt 次重试（从1开始）前的等待时间
+// Retry-After 超过上限时返回 false，表示不应重试
+func (p *RetryPolicy) backoff(attempt int, retryAfter time.Duration) (time.Duration, bool) {
+	d := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		d -= d * p.Jitter * rand.Float64()
+	}
+	delay := time.Duration(d)
+	// 服务器给出的 Retry-After 优先，但不能无限等待
+	if retryAfter > delay {
+		limit := p.MaxBackoff
+		if limit <= 0 {
+			limit = DefaultMaxRetryAfter
+		}
+		if retryAfter > limit {
+			return 0, false
+		}
+		delay = retryAfter
+	}
+	return delay, true
+}
+
+// isRetryableError 判断错误是否为临时错误（429/5xx、连接重置、超时）
+func isRetryableError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return false
+}
+
+// retryAfterOf 获取错误中携带的 Retry-After
+func retryAfterOf(err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.RetryAfter
+	}
+	return 0
+}
+
+// parseRetryAfter 解析 Retry-After 头（秒数或HTTP日期）
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// sleepContext 等待指定时间，ctx取消时提前返回
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// stripQuery 移除路径中的查询参数
+func stripQuery(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		return path[:i]
+	}
+	return path
+}
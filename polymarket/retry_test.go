@@ -0,0 +1,185 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 5, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+	tests := []struct {
+		attempt    int
+		retryAfter time.Duration
+		want       time.Duration
+		wantOK     bool
+	}{
+		{1, 0, 100 * time.Millisecond, true},
+		{2, 0, 200 * time.Millisecond, true},
+		{3, 0, 400 * time.Millisecond, true},
+		{5, 0, time.Second, true}, // 1.6s 被 MaxBackoff 截断
+		{1, 700 * time.Millisecond, 700 * time.Millisecond, true},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond, true}, // Retry-After 短于退避时间
+		{1, time.Second, time.Second, true},
+		{1, time.Hour, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := p.backoff(tt.attempt, tt.retryAfter)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("backoff(%d, %s) = %s, %v, want %s, %v", tt.attempt, tt.retryAfter, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	// MaxBackoff 未设置时 Retry-After 受 DefaultMaxRetryAfter 限制
+	unbounded := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2}
+	if got, ok := unbounded.backoff(1, DefaultMaxRetryAfter); !ok || got != DefaultMaxRetryAfter {
+		t.Errorf("backoff(1, DefaultMaxRetryAfter) = %s, %v", got, ok)
+	}
+	if _, ok := unbounded.backoff(1, DefaultMaxRetryAfter+time.Second); ok {
+		t.Error("Retry-After above DefaultMaxRetryAfter: want no retry")
+	}
+}
+
+func TestRetryPolicyBackoffJitter(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 3, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2, Jitter: 0.5}
+	for range 100 {
+		got, ok := p.backoff(2, 0)
+		if !ok || got < 100*time.Millisecond || got > 200*time.Millisecond {
+			t.Fatalf("backoff with jitter = %s, want within [100ms, 200ms]", got)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{" 3 ", 3 * time.Second},
+		{"0", 0},
+		{"-5", 0},
+		{"soon", 0},
+		{time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+
+	// HTTP 日期精确到秒
+	date := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(date); got < 8*time.Second || got > 10*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %s, want about 10s", date, got)
+	}
+}
+
+func TestRetryPolicyCanRetry(t *testing.T) {
+	p := DefaultRetryPolicy()
+	tests := []struct {
+		policy *RetryPolicy
+		method string
+		path   string
+		want   bool
+	}{
+		{p, http.MethodGet, "/book?token_id=1", true},
+		{p, http.MethodPost, GetOrderBooks, true},
+		{p, http.MethodPost, MidPoints + "?x=1", true},
+		{p, http.MethodPost, PostOrder, false},
+		{p, http.MethodDelete, Cancel, false},
+		{&RetryPolicy{MaxAttempts: 3, RetryNonIdempotent: true}, http.MethodPost, PostOrder, true},
+		{NoRetryPolicy(), http.MethodGet, "/book", false},
+		{nil, http.MethodGet, "/book", false},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.canRetry(tt.method, tt.path); got != tt.want {
+			t.Errorf("canRetry(%s %s) = %v, want %v", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+// flakyServer 前 failures 次请求返回 503（可带 Retry-After），之后返回 200
+func flakyServer(t *testing.T, failures int32, retryAfter string) (*HTTPClient, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":"unavailable"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+	client := NewHTTPClient(server.URL)
+	client.SetRetryPolicy(&RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Second, Multiplier: 2})
+	return client, &calls
+}
+
+func TestHTTPClientRetriesServerError(t *testing.T) {
+	client, calls := flakyServer(t, 1, "")
+	var out struct{ OK bool }
+	if err := client.GetInto(context.Background(), "/ok", nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.OK || calls.Load() != 2 {
+		t.Errorf("ok = %v after %d calls, want success after 2", out.OK, calls.Load())
+	}
+}
+
+func TestHTTPClientHonoursRetryAfter(t *testing.T) {
+	client, calls := flakyServer(t, 1, "1")
+	start := time.Now()
+	if _, err := client.Get(context.Background(), "/ok", nil); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want at least Retry-After 1s", elapsed)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("calls = %d, want 2", calls.Load())
+	}
+}
+
+func TestHTTPClientRetryAfterTooLong(t *testing.T) {
+	client, calls := flakyServer(t, 1, "3600")
+	start := time.Now()
+	_, err := client.Get(context.Background(), "/ok", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != time.Hour {
+		t.Fatalf("Get = %v, want APIError with RetryAfter 1h", err)
+	}
+	if calls.Load() != 1 || time.Since(start) > time.Second {
+		t.Errorf("calls = %d after %s, want 1 call without waiting", calls.Load(), time.Since(start))
+	}
+}
+
+func TestHTTPClientNoRetryForOrders(t *testing.T) {
+	client, calls := flakyServer(t, 1, "")
+	if _, err := client.Post(context.Background(), PostOrder, nil, map[string]string{"a": "b"}); !errors.Is(err, ErrServerError) {
+		t.Fatalf("Post = %v, want ErrServerError", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1 (orders are not retried)", calls.Load())
+	}
+}
+
+func TestHTTPClientRetryGivesUp(t *testing.T) {
+	client, calls := flakyServer(t, 10, "")
+	_, err := client.Get(context.Background(), "/ok", nil)
+	if !errors.Is(err, ErrServerError) {
+		t.Fatalf("Get = %v, want ErrServerError", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("calls = %d, want MaxAttempts 3", got)
+	}
+}
@@ -1,14 +1,15 @@
 package rfq
 
 import (
+	"context"
 	"fmt"
 )
 
 // HTTPClientInterface HTTP客户端接口
 type HTTPClientInterface interface {
-	Get(path string, headers map[string]string) (interface{}, error)
-	Post(path string, headers map[string]string, body interface{}) (interface{}, error)
-	Delete(path string, headers map[string]string, body interface{}) (interface{}, error)
+	Get(ctx context.Context, path string, headers map[string]string) (interface{}, error)
+	Post(ctx context.Context, path string, headers map[string]string, body interface{}) (interface{}, error)
+	Delete(ctx context.Context, path string, headers map[string]string, body interface{}) (interface{}, error)
 }
 
 // SignedOrderData 签名订单数据（用于避免循环导入）
@@ -44,7 +45,7 @@ type ClobClientInterface interface {
 	GetHost() string
 	CreateLevel2HeadersInternal(method, path string, body interface{}) (map[string]string, error)
 	GetAPICreds() (apiKey string)
-	CreateOrderForRFQ(args *OrderCreationArgs) (*SignedOrderData, error)
+	CreateOrderForRFQ(ctx context.Context, args *OrderCreationArgs) (*SignedOrderData, error)
 }
 
 // RfqClient RFQ客户端
@@ -70,7 +71,7 @@ func (r *RfqClient) getL2Headers(method, endpoint string, body interface{}) (map
 }
 
 // CreateRfqRequest 创建RFQ请求
-func (r *RfqClient) CreateRfqRequest(request *RfqUserRequest) (interface{}, error) {
+func (r *RfqClient) CreateRfqRequest(ctx context.Context, request *RfqUserRequest) (interface{}, error) {
 	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
@@ -81,11 +82,11 @@ func (r *RfqClient) CreateRfqRequest(request *RfqUserRequest) (interface{}, erro
 	}
 
 	httpClient := r.parent.GetHTTPClient()
-	return httpClient.Post("/rfq/request", headers, request)
+	return httpClient.Post(ctx, "/rfq/request", headers, request)
 }
 
 // CancelRfqRequest 取消RFQ请求
-func (r *RfqClient) CancelRfqRequest(params *CancelRfqRequestParams) (interface{}, error) {
+func (r *RfqClient) CancelRfqRequest(ctx context.Context, params *CancelRfqRequestParams) (interface{}, error) {
 	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
@@ -95,11 +96,11 @@ func (r *RfqClient) CancelRfqRequest(params *CancelRfqRequestParams) (interface{
 		return nil, err
 	}
 
-	return r.parent.GetHTTPClient().Delete("/rfq/request", headers, params)
+	return r.parent.GetHTTPClient().Delete(ctx, "/rfq/request", headers, params)
 }
 
 // GetRfqRequests 获取RFQ请求列表
-func (r *RfqClient) GetRfqRequests(params *GetRfqRequestsParams) (interface{}, error) {
+func (r *RfqClient) GetRfqRequests(ctx context.Context, params *GetRfqRequestsParams) (interface{}, error) {
 	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
@@ -129,11 +130,11 @@ func (r *RfqClient) GetRfqRequests(params *GetRfqRequestsParams) (interface{}, e
 	}
 
 	httpClient := r.parent.GetHTTPClient()
-	return httpClient.Get(path, headers)
+	return httpClient.Get(ctx, path, headers)
 }
 
 // CreateRfqQuote 创建RFQ报价
-func (r *RfqClient) CreateRfqQuote(quote *RfqUserQuote) (interface{}, error) {
+func (r *RfqClient) CreateRfqQuote(ctx context.Context, quote *RfqUserQuote) (interface{}, error) {
 	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
@@ -143,11 +144,11 @@ func (r *RfqClient) CreateRfqQuote(quote *RfqUserQuote) (interface{}, error) {
 		return nil, err
 	}
 
-	return r.parent.GetHTTPClient().Post("/rfq/quote", headers, quote)
+	return r.parent.GetHTTPClient().Post(ctx, "/rfq/quote", headers, quote)
 }
 
 // CancelRfqQuote 取消RFQ报价
-func (r *RfqClient) CancelRfqQuote(params *CancelRfqQuoteParams) (interface{}, error) {
+func (r *RfqClient) CancelRfqQuote(ctx context.Context, params *CancelRfqQuoteParams) (interface{}, error) {
 	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
@@ -157,11 +158,11 @@ func (r *RfqClient) CancelRfqQuote(params *CancelRfqQuoteParams) (interface{}, e
 		return nil, err
 	}
 
-	return r.parent.GetHTTPClient().Delete("/rfq/quote", headers, params)
+	return r.parent.GetHTTPClient().Delete(ctx, "/rfq/quote", headers, params)
 }
 
 // GetRfqQuotes 获取RFQ报价列表
-func (r *RfqClient) GetRfqQuotes(params *GetRfqQuotesParams) (interface{}, error) {
+func (r *RfqClient) GetRfqQuotes(ctx context.Context, params *GetRfqQuotesParams) (interface{}, error) {
 	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
@@ -194,11 +195,11 @@ func (r *RfqClient) GetRfqQuotes(params *GetRfqQuotesParams) (interface{}, error
 	}
 
 	httpClient := r.parent.GetHTTPClient()
-	return httpClient.Get(path, headers)
+	return httpClient.Get(ctx, path, headers)
 }
 
 // GetRfqBestQuote 获取最佳RFQ报价
-func (r *RfqClient) GetRfqBestQuote(params *GetRfqBestQuoteParams) (interface{}, error) {
+func (r *RfqClient) GetRfqBestQuote(ctx context.Context, params *GetRfqBestQuoteParams) (interface{}, error) {
 	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
@@ -212,18 +213,18 @@ func (r *RfqClient) GetRfqBestQuote(params *GetRfqBestQuoteParams) (interface{},
 	}
 
 	httpClient := r.parent.GetHTTPClient()
-	return httpClient.Get(path, headers)
+	return httpClient.Get(ctx, path, headers)
 }
 
 // AcceptQuote 接受报价（请求方）
 // 此方法会获取报价详情，创建签名订单，然后提交接受请求
-func (r *RfqClient) AcceptQuote(params *AcceptQuoteParams) (interface{}, error) {
+func (r *RfqClient) AcceptQuote(ctx context.Context, params *AcceptQuoteParams) (interface{}, error) {
 	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
 
 	// 步骤1: 获取报价详情
-	quotesResp, err := r.GetRfqQuotes(&GetRfqQuotesParams{
+	quotesResp, err := r.GetRfqQuotes(ctx, &GetRfqQuotesParams{
 		RequestID: params.RequestID,
 	})
 	if err != nil {
@@ -276,7 +277,7 @@ func (r *RfqClient) AcceptQuote(params *AcceptQuoteParams) (interface{}, error)
 	}
 
 	// 步骤3: 创建签名订单
-	order, err := r.parent.CreateOrderForRFQ(orderArgs)
+	order, err := r.parent.CreateOrderForRFQ(ctx, orderArgs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
@@ -306,18 +307,18 @@ func (r *RfqClient) AcceptQuote(params *AcceptQuoteParams) (interface{}, error)
 		return nil, err
 	}
 
-	return r.parent.GetHTTPClient().Post("/rfq/request/accept", headers, acceptPayload)
+	return r.parent.GetHTTPClient().Post(ctx, "/rfq/request/accept", headers, acceptPayload)
 }
 
 // ApproveOrder 批准订单（报价方）
 // 此方法会获取报价详情，创建签名订单，然后提交批准请求
-func (r *RfqClient) ApproveOrder(params *ApproveOrderParams) (interface{}, error) {
+func (r *RfqClient) ApproveOrder(ctx context.Context, params *ApproveOrderParams) (interface{}, error) {
 	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
 
 	// 步骤1: 获取报价详情
-	quotesResp, err := r.GetRfqQuotes(&GetRfqQuotesParams{
+	quotesResp, err := r.GetRfqQuotes(ctx, &GetRfqQuotesParams{
 		RequestID: params.RequestID,
 	})
 	if err != nil {
@@ -382,7 +383,7 @@ func (r *RfqClient) ApproveOrder(params *ApproveOrderParams) (interface{}, error
 	}
 
 	// 步骤3: 创建签名订单
-	order, err := r.parent.CreateOrderForRFQ(orderArgs)
+	order, err := r.parent.CreateOrderForRFQ(ctx, orderArgs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
@@ -412,11 +413,11 @@ func (r *RfqClient) ApproveOrder(params *ApproveOrderParams) (interface{}, error
 		return nil, err
 	}
 
-	return r.parent.GetHTTPClient().Post("/rfq/quote/approve", headers, approvePayload)
+	return r.parent.GetHTTPClient().Post(ctx, "/rfq/quote/approve", headers, approvePayload)
 }
 
 // GetRfqConfig 获取RFQ配置
-func (r *RfqClient) GetRfqConfig() (interface{}, error) {
+func (r *RfqClient) GetRfqConfig(ctx context.Context) (interface{}, error) {
 	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
@@ -426,7 +427,7 @@ func (r *RfqClient) GetRfqConfig() (interface{}, error) {
 		return nil, err
 	}
 
-	return r.parent.GetHTTPClient().Get("/rfq/config", headers)
+	return r.parent.GetHTTPClient().Get(ctx, "/rfq/config", headers)
 }
 
 // OrderCreationResult 订单创建结果
@@ -1,56 +1,58 @@
 package polymarket
 
 import (
+	"context"
+
 	"github.com/0xNetuser/Polymarket-golang/polymarket/rfq"
 )
 
 // CreateRfqRequest 创建RFQ请求（便捷方法）
-func (c *ClobClient) CreateRfqRequest(request *rfq.RfqUserRequest) (interface{}, error) {
-	return c.rfq.CreateRfqRequest(request)
+func (c *ClobClient) CreateRfqRequest(ctx context.Context, request *rfq.RfqUserRequest) (interface{}, error) {
+	return c.rfq.CreateRfqRequest(ctx, request)
 }
 
 // CancelRfqRequest 取消RFQ请求（便捷方法）
-func (c *ClobClient) CancelRfqRequest(params *rfq.CancelRfqRequestParams) (interface{}, error) {
-	return c.rfq.CancelRfqRequest(params)
+func (c *ClobClient) CancelRfqRequest(ctx context.Context, params *rfq.CancelRfqRequestParams) (interface{}, error) {
+	return c.rfq.CancelRfqRequest(ctx, params)
 }
 
 // GetRfqRequests 获取RFQ请求列表（便捷方法）
-func (c *ClobClient) GetRfqRequests(params *rfq.GetRfqRequestsParams) (interface{}, error) {
-	return c.rfq.GetRfqRequests(params)
+func (c *ClobClient) GetRfqRequests(ctx context.Context, params *rfq.GetRfqRequestsParams) (interface{}, error) {
+	return c.rfq.GetRfqRequests(ctx, params)
 }
 
 // CreateRfqQuote 创建RFQ报价（便捷方法）
-func (c *ClobClient) CreateRfqQuote(quote *rfq.RfqUserQuote) (interface{}, error) {
-	return c.rfq.CreateRfqQuote(quote)
+func (c *ClobClient) CreateRfqQuote(ctx context.Context, quote *rfq.RfqUserQuote) (interface{}, error) {
+	return c.rfq.CreateRfqQuote(ctx, quote)
 }
 
 // CancelRfqQuote 取消RFQ报价（便捷方法）
-func (c *ClobClient) CancelRfqQuote(params *rfq.CancelRfqQuoteParams) (interface{}, error) {
-	return c.rfq.CancelRfqQuote(params)
+func (c *ClobClient) CancelRfqQuote(ctx context.Context, params *rfq.CancelRfqQuoteParams) (interface{}, error) {
+	return c.rfq.CancelRfqQuote(ctx, params)
 }
 
 // GetRfqQuotes 获取RFQ报价列表（便捷方法）
-func (c *ClobClient) GetRfqQuotes(params *rfq.GetRfqQuotesParams) (interface{}, error) {
-	return c.rfq.GetRfqQuotes(params)
+func (c *ClobClient) GetRfqQuotes(ctx context.Context, params *rfq.GetRfqQuotesParams) (interface{}, error) {
+	return c.rfq.GetRfqQuotes(ctx, params)
 }
 
 // GetRfqBestQuote 获取最佳RFQ报价（便捷方法）
-func (c *ClobClient) GetRfqBestQuote(params *rfq.GetRfqBestQuoteParams) (interface{}, error) {
-	return c.rfq.GetRfqBestQuote(params)
+func (c *ClobClient) GetRfqBestQuote(ctx context.Context, params *rfq.GetRfqBestQuoteParams) (interface{}, error) {
+	return c.rfq.GetRfqBestQuote(ctx, params)
 }
 
 // AcceptRfqQuote 接受RFQ报价（便捷方法）
-func (c *ClobClient) AcceptRfqQuote(params *rfq.AcceptQuoteParams) (interface{}, error) {
-	return c.rfq.AcceptQuote(params)
+func (c *ClobClient) AcceptRfqQuote(ctx context.Context, params *rfq.AcceptQuoteParams) (interface{}, error) {
+	return c.rfq.AcceptQuote(ctx, params)
 }
 
 // ApproveRfqOrder 批准RFQ订单（便捷方法）
-func (c *ClobClient) ApproveRfqOrder(params *rfq.ApproveOrderParams) (interface{}, error) {
-	return c.rfq.ApproveOrder(params)
+func (c *ClobClient) ApproveRfqOrder(ctx context.Context, params *rfq.ApproveOrderParams) (interface{}, error) {
+	return c.rfq.ApproveOrder(ctx, params)
 }
 
 // GetRfqConfig 获取RFQ配置（便捷方法）
-func (c *ClobClient) GetRfqConfig() (interface{}, error) {
-	return c.rfq.GetRfqConfig()
+func (c *ClobClient) GetRfqConfig(ctx context.Context) (interface{}, error) {
+	return c.rfq.GetRfqConfig(ctx)
 }
 
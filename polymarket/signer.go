@@ -1,48 +1,106 @@
 package polymarket
 
 import (
+	"bytes"
+	"context"
 	"crypto/ecdsa"
 	"fmt"
+	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// SignerBackend 签名后端
+// 私钥只存在于实现内部（本地私钥、远程签名服务、HSM 等），SDK 只通过该接口请求签名。
+// 用于 CLOB L1 认证、订单签名以及 web3/gasless 客户端的交易和中继签名
+type SignerBackend interface {
+	// Address 签名账户地址
+	Address() common.Address
+	// SignHash 对32字节哈希直接签名（不添加任何前缀），返回65字节的 [R || S || V]，V 为 0/1 或 27/28
+	SignHash(ctx context.Context, hash []byte) ([]byte, error)
+	// SignTx 签名交易
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// PrivateKeySigner 使用内存中私钥的签名后端
+type PrivateKeySigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewPrivateKeySigner 从十六进制私钥创建签名后端（可带0x前缀）
+func NewPrivateKeySigner(privateKeyHex string) (*PrivateKeySigner, error) {
+	if len(privateKeyHex) > 2 && privateKeyHex[:2] == "0x" {
+		privateKeyHex = privateKeyHex[2:]
+	}
+	key, err := crypto.HexToECDSA(privateKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return NewPrivateKeySignerFromECDSA(key), nil
+}
+
+// NewPrivateKeySignerFromECDSA 从 ecdsa 私钥创建签名后端
+func NewPrivateKeySignerFromECDSA(key *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{
+		key:     key,
+		address: crypto.PubkeyToAddress(key.PublicKey),
+	}
+}
+
+// Address 签名账户地址
+func (s *PrivateKeySigner) Address() common.Address {
+	return s.address
+}
+
+// SignHash 对哈希签名，V 为 0/1
+func (s *PrivateKeySigner) SignHash(_ context.Context, hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, s.key)
+}
+
+// SignTx 签名交易
+func (s *PrivateKeySigner) SignTx(_ context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+}
+
+// PrivateKey 返回私钥
+func (s *PrivateKeySigner) PrivateKey() *ecdsa.PrivateKey {
+	return s.key
+}
+
 // Signer 签名器
 type Signer struct {
-	privateKey *ecdsa.PrivateKey
-	chainID    int
-	address    string
+	backend SignerBackend
+	chainID int
+	address string
 }
 
-// NewSigner 创建新的签名器
+// NewSigner 使用私钥创建签名器
 func NewSigner(privateKeyHex string, chainID int) (*Signer, error) {
 	if privateKeyHex == "" || chainID == 0 {
 		return nil, fmt.Errorf("private key and chain ID are required")
 	}
 
-	// 移除0x前缀
-	if len(privateKeyHex) > 2 && privateKeyHex[:2] == "0x" {
-		privateKeyHex = privateKeyHex[2:]
-	}
-
-	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+	backend, err := NewPrivateKeySigner(privateKeyHex)
 	if err != nil {
-		return nil, fmt.Errorf("invalid private key: %w", err)
+		return nil, err
 	}
+	return NewSignerWithBackend(backend, chainID)
+}
 
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return nil, fmt.Errorf("failed to get public key")
+// NewSignerWithBackend 使用自定义签名后端（如 RemoteSigner）创建签名器
+func NewSignerWithBackend(backend SignerBackend, chainID int) (*Signer, error) {
+	if backend == nil || chainID == 0 {
+		return nil, fmt.Errorf("signer backend and chain ID are required")
 	}
 
-	address := crypto.PubkeyToAddress(*publicKeyECDSA).Hex()
-
 	return &Signer{
-		privateKey: privateKey,
-		chainID:    chainID,
-		address:    address,
+		backend: backend,
+		chainID: chainID,
+		address: backend.Address().Hex(),
 	}, nil
 }
 
@@ -56,30 +114,59 @@ func (s *Signer) GetChainID() int {
 	return s.chainID
 }
 
+// Backend 返回签名后端
+func (s *Signer) Backend() SignerBackend {
+	return s.backend
+}
+
 // Sign 签名消息哈希
 // 对于EIP-712，messageHash已经是最终的哈希值，不需要TextHash
 // 对于普通消息签名，应该先使用TextHash
+// 远程签名后端无法取消或超时，需要时使用 SignContext
 func (s *Signer) Sign(messageHash []byte) (string, error) {
-	// 直接签名哈希值（EIP-712已经处理了前缀）
-	signature, err := crypto.Sign(messageHash, s.privateKey)
+	return s.SignContext(context.Background(), messageHash)
+}
+
+// SignContext 签名消息哈希，返回0x前缀的十六进制签名；ctx 传递给签名后端
+func (s *Signer) SignContext(ctx context.Context, messageHash []byte) (string, error) {
+	signature, err := s.SignHash(ctx, messageHash)
 	if err != nil {
-		return "", fmt.Errorf("signing failed: %w", err)
+		return "", err
 	}
+	return hexutil.Encode(signature), nil
+}
 
-	// 添加恢复ID（v = 27 或 28）
-	signature[64] += 27
+// SignHash 通过签名后端签名哈希，返回 V 为 27/28 的65字节签名
+// 会校验签名恢复出的地址，防止远程签名服务使用了其他账户
+func (s *Signer) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	signature, err := s.backend.SignHash(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("signing failed: %w", err)
+	}
+	recovered, err := recoverSigner(common.BytesToHash(hash), signature)
+	if err != nil {
+		return nil, fmt.Errorf("signing failed: %w", err)
+	}
+	if recovered.Hex() != s.address {
+		return nil, fmt.Errorf("signing failed: signature recovers to %s, expected %s", recovered.Hex(), s.address)
+	}
 
-	return hexutil.Encode(signature), nil
+	// 统一恢复ID为 v = 27 或 28
+	signature = bytes.Clone(signature)
+	if signature[64] < 27 {
+		signature[64] += 27
+	}
+	return signature, nil
 }
 
-// GetPrivateKey 返回私钥（用于订单构建器）
+// GetPrivateKey 返回私钥（十六进制，无0x前缀）
+// 签名后端不是 PrivateKeySigner 时返回空字符串
+//
+// Deprecated: 通过 SignHash 或 Backend 签名，不要直接读取私钥
 func (s *Signer) GetPrivateKey() string {
-	keyBytes := crypto.FromECDSA(s.privateKey)
-	// 移除0x前缀（如果有）
-	keyHex := hexutil.Encode(keyBytes)
-	if len(keyHex) > 2 && keyHex[:2] == "0x" {
-		return keyHex[2:]
+	local, ok := s.backend.(*PrivateKeySigner)
+	if !ok {
+		return ""
 	}
-	return keyHex
+	return common.Bytes2Hex(crypto.FromECDSA(local.key))
 }
-
@@ -0,0 +1,129 @@
+package polymarket
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// DefaultDerivationPath 以太坊默认的 BIP-44 派生路径（MetaMask 等钱包的第一个账户）
+const DefaultDerivationPath = "m/44'/60'/0'/0/0"
+
+// NewPrivateKeySignerFromKeystore 从以太坊 V3 keystore 文件和密码创建签名后端
+func NewPrivateKeySignerFromKeystore(path, passphrase string) (*PrivateKeySigner, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+	return NewPrivateKeySignerFromKeystoreJSON(keyJSON, passphrase)
+}
+
+// NewPrivateKeySignerFromKeystoreJSON 从 V3 keystore JSON 内容和密码创建签名后端
+func NewPrivateKeySignerFromKeystoreJSON(keyJSON []byte, passphrase string) (*PrivateKeySigner, error) {
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore: %w", err)
+	}
+	return NewPrivateKeySignerFromECDSA(key.PrivateKey), nil
+}
+
+// NewPrivateKeySignerFromMnemonic 从 BIP-39 助记词创建签名后端
+// password 为 BIP-39 扩展密码（没有则为空）；path 为 BIP-44 派生路径，为空时使用 DefaultDerivationPath
+func NewPrivateKeySignerFromMnemonic(mnemonic, password, path string) (*PrivateKeySigner, error) {
+	if path == "" {
+		path = DefaultDerivationPath
+	}
+	derivationPath, err := accounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid derivation path %q: %w", path, err)
+	}
+
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, password)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+
+	key, err := deriveKey(seed, derivationPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key at %s: %w", path, err)
+	}
+	return NewPrivateKeySignerFromECDSA(key), nil
+}
+
+// NewSignerFromKeystore 从 V3 keystore 文件和密码创建签名器
+func NewSignerFromKeystore(path, passphrase string, chainID int) (*Signer, error) {
+	backend, err := NewPrivateKeySignerFromKeystore(path, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return NewSignerWithBackend(backend, chainID)
+}
+
+// NewSignerFromMnemonic 从 BIP-39 助记词和 BIP-44 派生路径创建签名器
+func NewSignerFromMnemonic(mnemonic, password, path string, chainID int) (*Signer, error) {
+	backend, err := NewPrivateKeySignerFromMnemonic(mnemonic, password, path)
+	if err != nil {
+		return nil, err
+	}
+	return NewSignerWithBackend(backend, chainID)
+}
+
+// deriveKey 按 BIP-32 从种子派生 secp256k1 私钥
+func deriveKey(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	curveOrder := crypto.S256().Params().N
+
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := new(big.Int).SetBytes(sum[:32]), sum[32:]
+	if key.Sign() == 0 || key.Cmp(curveOrder) >= 0 {
+		return nil, fmt.Errorf("invalid master key")
+	}
+
+	for _, index := range path {
+		priv, err := crypto.ToECDSA(math32Bytes(key))
+		if err != nil {
+			return nil, err
+		}
+
+		var data []byte
+		if index >= 0x80000000 {
+			// 强化派生：0x00 || ser256(k) || ser32(i)
+			data = append([]byte{0}, math32Bytes(key)...)
+		} else {
+			// 普通派生：serP(K) || ser32(i)
+			data = crypto.CompressPubkey(&priv.PublicKey)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+
+		tweak := new(big.Int).SetBytes(sum[:32])
+		if tweak.Cmp(curveOrder) >= 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+		key = tweak.Add(tweak, key).Mod(tweak, curveOrder)
+		if key.Sign() == 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+		chainCode = sum[32:]
+	}
+
+	return crypto.ToECDSA(math32Bytes(key))
+}
+
+// math32Bytes 将整数编码为32字节大端序
+func math32Bytes(n *big.Int) []byte {
+	return n.FillBytes(make([]byte, 32))
+}
@@ -0,0 +1,121 @@
+package polymarket
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testMnemonic Hardhat / Anvil 默认助记词
+const testMnemonic = "test test test test test test test test test test test junk"
+
+// testKeystoreJSON Hardhat 账户1的 V3 keystore（密码 testpassword，轻量 scrypt 参数）
+const testKeystoreJSON = `{"address":"70997970c51812dc3a010c7d01b50e0d17dc79c8","crypto":{"cipher":"aes-128-ctr","ciphertext":"cee8d6c73d17eaabde4321c0e0dcbf99aba0dc266d77bd19f40a6e66a774e40d","cipherparams":{"iv":"a247b2baedb128f833930d52863a6c31"},"kdf":"scrypt","kdfparams":{"dklen":32,"n":4096,"p":6,"r":8,"salt":"85e140e057faef35b54d1ecb61715017aa3c089bb13cd1b59b17787e144dab33"},"mac":"f831d9ed18000692d4ee8f0a705da81fce127bf56a87998bd6f82d59a7088037"},"id":"d2b8b0e7-f0c4-4cb7-a965-81581a943723","version":3}`
+
+func TestNewPrivateKeySignerFromMnemonic(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"", "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"},
+		{DefaultDerivationPath, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"},
+		{"m/44'/60'/0'/0/1", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"},
+		{"m/44'/60'/0'/0/2", "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"},
+	}
+	for _, tt := range tests {
+		signer, err := NewPrivateKeySignerFromMnemonic(testMnemonic, "", tt.path)
+		if err != nil {
+			t.Fatalf("path %q: %v", tt.path, err)
+		}
+		if got := signer.Address().Hex(); got != tt.want {
+			t.Errorf("path %q: address = %s, want %s", tt.path, got, tt.want)
+		}
+	}
+
+	signer, err := NewSignerFromMnemonic(testMnemonic, "", "", 137)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := signer.Address(); got != "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266" {
+		t.Errorf("NewSignerFromMnemonic address = %s", got)
+	}
+}
+
+func TestNewPrivateKeySignerFromMnemonicErrors(t *testing.T) {
+	if _, err := NewPrivateKeySignerFromMnemonic("test test test test test test test test test test test test", "", ""); err == nil {
+		t.Error("mnemonic with bad checksum: want error")
+	}
+	if _, err := NewPrivateKeySignerFromMnemonic(testMnemonic, "", "m/44'/60'/x"); err == nil {
+		t.Error("invalid derivation path: want error")
+	}
+	// BIP-39 扩展密码派生出不同的账户
+	signer, err := NewPrivateKeySignerFromMnemonic(testMnemonic, "extra", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer.Address().Hex() == "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266" {
+		t.Error("BIP-39 password ignored")
+	}
+}
+
+func TestDeriveKeyBIP32Vector(t *testing.T) {
+	// BIP-32 测试向量1，覆盖强化和普通派生交替的路径
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"m/0'", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0'/1/2'/2/1000000000", "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	}
+	for _, tt := range tests {
+		path, err := accounts.ParseDerivationPath(tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := deriveKey(seed, path)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+		if got := hex.EncodeToString(crypto.FromECDSA(key)); got != tt.want {
+			t.Errorf("%s: key = %s, want %s", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestNewPrivateKeySignerFromKeystore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keystore.json")
+	if err := os.WriteFile(path, []byte(testKeystoreJSON), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := NewPrivateKeySignerFromKeystore(path, "testpassword")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := signer.Address().Hex(); got != "0x70997970C51812dc3A010C7d01b50e0d17dc79C8" {
+		t.Errorf("address = %s, want 0x70997970C51812dc3A010C7d01b50e0d17dc79C8", got)
+	}
+
+	if _, err := NewPrivateKeySignerFromKeystore(path, "wrongpassword"); err == nil {
+		t.Error("wrong password: want error")
+	}
+	if _, err := NewSignerFromKeystore(path, "wrongpassword", 137); err == nil {
+		t.Error("NewSignerFromKeystore with wrong password: want error")
+	}
+	if _, err := NewPrivateKeySignerFromKeystore(filepath.Join(t.TempDir(), "missing.json"), "testpassword"); err == nil {
+		t.Error("missing keystore file: want error")
+	}
+
+	s, err := NewSignerFromKeystore(path, "testpassword", 137)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Address() != signer.Address().Hex() {
+		t.Errorf("NewSignerFromKeystore address = %s, want %s", s.Address(), signer.Address().Hex())
+	}
+}
@@ -0,0 +1,184 @@
+package polymarket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// 远程签名服务默认使用的 JSON-RPC 方法
+const (
+	DefaultRemoteSignHashMethod = "polymarket_signHash" // params: [address, hash] -> 0x 开头的65字节签名
+	DefaultRemoteSignTxMethod   = "eth_signTransaction" // params: [txArgs] -> 0x 开头的 RLP 或 {"raw": ...}
+)
+
+// RemoteSignerConfig 远程签名服务配置
+type RemoteSignerConfig struct {
+	URL            string       // JSON-RPC 端点（http/https/ws）
+	Address        string       // 签名账户，为空时使用 eth_accounts 返回的第一个账户
+	HTTPClient     *http.Client // 自定义 HTTP 客户端（mTLS 等），可选
+	Headers        http.Header  // 额外的请求头（如认证令牌），可选
+	SignHashMethod string       // 哈希签名方法，默认 DefaultRemoteSignHashMethod
+	SignTxMethod   string       // 交易签名方法，默认 DefaultRemoteSignTxMethod
+}
+
+// RemoteSigner 通过 JSON-RPC 调用外部签名服务的签名后端，私钥不进入本进程
+// 返回的签名会被校验：哈希签名必须能恢复出签名账户，交易签名必须与请求的交易一致
+type RemoteSigner struct {
+	client  *rpc.Client
+	address common.Address
+	cfg     RemoteSignerConfig
+}
+
+// NewRemoteSigner 连接远程签名服务
+func NewRemoteSigner(ctx context.Context, cfg RemoteSignerConfig) (*RemoteSigner, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("remote signer URL is required")
+	}
+	if cfg.SignHashMethod == "" {
+		cfg.SignHashMethod = DefaultRemoteSignHashMethod
+	}
+	if cfg.SignTxMethod == "" {
+		cfg.SignTxMethod = DefaultRemoteSignTxMethod
+	}
+
+	var opts []rpc.ClientOption
+	if cfg.HTTPClient != nil {
+		opts = append(opts, rpc.WithHTTPClient(cfg.HTTPClient))
+	}
+	if cfg.Headers != nil {
+		opts = append(opts, rpc.WithHeaders(cfg.Headers))
+	}
+	client, err := rpc.DialOptions(ctx, cfg.URL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to remote signer: %w", err)
+	}
+
+	s := &RemoteSigner{client: client, cfg: cfg}
+	if cfg.Address != "" {
+		if !common.IsHexAddress(cfg.Address) {
+			client.Close()
+			return nil, fmt.Errorf("invalid signer address: %s", cfg.Address)
+		}
+		s.address = common.HexToAddress(cfg.Address)
+		return s, nil
+	}
+
+	var accounts []common.Address
+	if err := client.CallContext(ctx, &accounts, "eth_accounts"); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to list remote signer accounts: %w", err)
+	}
+	if len(accounts) == 0 {
+		client.Close()
+		return nil, fmt.Errorf("remote signer has no accounts")
+	}
+	s.address = accounts[0]
+	return s, nil
+}
+
+// Address 签名账户地址
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignHash 请求远程服务对哈希签名
+func (s *RemoteSigner) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	var signature hexutil.Bytes
+	if err := s.client.CallContext(ctx, &signature, s.cfg.SignHashMethod, s.address, hexutil.Bytes(hash)); err != nil {
+		return nil, fmt.Errorf("remote sign hash: %w", err)
+	}
+	recovered, err := recoverSigner(common.BytesToHash(hash), signature)
+	if err != nil {
+		return nil, fmt.Errorf("remote sign hash: %w", err)
+	}
+	if recovered != s.address {
+		return nil, fmt.Errorf("remote sign hash: signed by %s, expected %s", recovered.Hex(), s.address.Hex())
+	}
+	return signature, nil
+}
+
+// remoteTxArgs eth_signTransaction 的参数（与 geth/Clef 的 TransactionArgs 兼容）
+type remoteTxArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to,omitempty"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	Input                hexutil.Bytes   `json:"input"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+}
+
+// SignTx 请求远程服务签名交易（支持 legacy 和 EIP-1559 交易）
+func (s *RemoteSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	args := remoteTxArgs{
+		From:    s.address,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		Input:   tx.Data(),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.DynamicFeeTxType:
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	default:
+		return nil, fmt.Errorf("remote signer does not support transaction type %d", tx.Type())
+	}
+
+	var result json.RawMessage
+	if err := s.client.CallContext(ctx, &result, s.cfg.SignTxMethod, args); err != nil {
+		return nil, fmt.Errorf("remote sign transaction: %w", err)
+	}
+
+	// geth/Clef 返回 {"raw": "0x...", "tx": {...}}，部分实现直接返回 RLP
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err != nil {
+		var wrapped struct {
+			Raw hexutil.Bytes `json:"raw"`
+		}
+		if err := json.Unmarshal(result, &wrapped); err != nil || len(wrapped.Raw) == 0 {
+			return nil, fmt.Errorf("remote sign transaction: unexpected result %s", string(result))
+		}
+		raw = wrapped.Raw
+	}
+
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("remote sign transaction: invalid raw transaction: %w", err)
+	}
+
+	signer := types.LatestSignerForChainID(chainID)
+	if signer.Hash(signed) != signer.Hash(tx) {
+		return nil, fmt.Errorf("remote sign transaction: signed transaction differs from the request")
+	}
+	sender, err := types.Sender(signer, signed)
+	if err != nil {
+		return nil, fmt.Errorf("remote sign transaction: %w", err)
+	}
+	if sender != s.address {
+		return nil, fmt.Errorf("remote sign transaction: signed by %s, expected %s", sender.Hex(), s.address.Hex())
+	}
+	return signed, nil
+}
+
+// Close 关闭与远程签名服务的连接
+func (s *RemoteSigner) Close() {
+	s.client.Close()
+}
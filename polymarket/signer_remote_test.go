@@ -0,0 +1,274 @@
+package polymarket
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// remoteSignerStub 模拟远程签名服务的 JSON-RPC 端点
+type remoteSignerStub struct {
+	key   *ecdsa.PrivateKey // 服务器使用的签名私钥
+	token string            // 要求的 Authorization 头，为空时不检查
+
+	mu   sync.Mutex
+	mode string // "", "v27", "wrongkey", "tampered"
+}
+
+func (s *remoteSignerStub) setMode(mode string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mode = mode
+}
+
+func (s *remoteSignerStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	reply := func(result interface{}, rpcErr string) {
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if rpcErr != "" {
+			resp["error"] = map[string]interface{}{"code": -32000, "message": rpcErr}
+		} else {
+			resp["result"] = result
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}
+	if s.token != "" && r.Header.Get("Authorization") != s.token {
+		reply(nil, "unauthorized")
+		return
+	}
+
+	s.mu.Lock()
+	mode := s.mode
+	s.mu.Unlock()
+	key := s.key
+	if mode == "wrongkey" {
+		key, _ = crypto.GenerateKey()
+	}
+
+	switch req.Method {
+	case "eth_accounts":
+		reply([]common.Address{crypto.PubkeyToAddress(s.key.PublicKey)}, "")
+
+	case DefaultRemoteSignHashMethod:
+		var hash hexutil.Bytes
+		if err := json.Unmarshal(req.Params[1], &hash); err != nil {
+			reply(nil, err.Error())
+			return
+		}
+		sig, err := crypto.Sign(hash, key)
+		if err != nil {
+			reply(nil, err.Error())
+			return
+		}
+		switch mode {
+		case "v27":
+			sig[64] += 27
+		case "tampered":
+			sig[10] ^= 0xff
+		}
+		reply(hexutil.Bytes(sig), "")
+
+	case DefaultRemoteSignTxMethod:
+		var args remoteTxArgs
+		if err := json.Unmarshal(req.Params[0], &args); err != nil {
+			reply(nil, err.Error())
+			return
+		}
+		nonce := uint64(args.Nonce)
+		if mode == "tampered" {
+			nonce++
+		}
+		var tx *types.Transaction
+		if args.MaxFeePerGas != nil {
+			tx = types.NewTx(&types.DynamicFeeTx{
+				ChainID:   args.ChainID.ToInt(),
+				Nonce:     nonce,
+				GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+				GasFeeCap: args.MaxFeePerGas.ToInt(),
+				Gas:       uint64(args.Gas),
+				To:        args.To,
+				Value:     args.Value.ToInt(),
+				Data:      args.Data,
+			})
+		} else {
+			tx = types.NewTx(&types.LegacyTx{
+				Nonce:    nonce,
+				GasPrice: args.GasPrice.ToInt(),
+				Gas:      uint64(args.Gas),
+				To:       args.To,
+				Value:    args.Value.ToInt(),
+				Data:     args.Data,
+			})
+		}
+		signed, err := types.SignTx(tx, types.LatestSignerForChainID(args.ChainID.ToInt()), key)
+		if err != nil {
+			reply(nil, err.Error())
+			return
+		}
+		raw, _ := signed.MarshalBinary()
+		reply(map[string]interface{}{"raw": hexutil.Bytes(raw), "tx": signed}, "")
+
+	default:
+		reply(nil, "method not found: "+req.Method)
+	}
+}
+
+func newRemoteSignerStub(t *testing.T) (*remoteSignerStub, *httptest.Server) {
+	t.Helper()
+	key, err := crypto.HexToECDSA(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stub := &remoteSignerStub{key: key}
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+	return stub, server
+}
+
+func TestRemoteSignerAddressFromAccounts(t *testing.T) {
+	stub, server := newRemoteSignerStub(t)
+	signer, err := NewRemoteSigner(context.Background(), RemoteSignerConfig{URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer signer.Close()
+	if want := crypto.PubkeyToAddress(stub.key.PublicKey); signer.Address() != want {
+		t.Errorf("Address = %s, want %s", signer.Address().Hex(), want.Hex())
+	}
+}
+
+func TestRemoteSignerHeaders(t *testing.T) {
+	stub, server := newRemoteSignerStub(t)
+	stub.token = "Bearer secret"
+	if _, err := NewRemoteSigner(context.Background(), RemoteSignerConfig{URL: server.URL}); err == nil {
+		t.Fatal("NewRemoteSigner without token: want error")
+	}
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer secret")
+	signer, err := NewRemoteSigner(context.Background(), RemoteSignerConfig{URL: server.URL, Headers: headers})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer.Close()
+}
+
+func TestRemoteSignerSignHash(t *testing.T) {
+	stub, server := newRemoteSignerStub(t)
+	remote, err := NewRemoteSigner(context.Background(), RemoteSignerConfig{URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer remote.Close()
+	signer, err := NewSignerWithBackend(remote, 137)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := crypto.Keccak256([]byte("order"))
+
+	for _, mode := range []string{"", "v27"} {
+		stub.setMode(mode)
+		raw, err := remote.SignHash(context.Background(), hash)
+		if err != nil {
+			t.Fatalf("mode %q: RemoteSigner.SignHash: %v", mode, err)
+		}
+		if mode == "v27" && raw[64] < 27 || mode == "" && raw[64] > 1 {
+			t.Errorf("mode %q: raw V = %d, want server value unchanged", mode, raw[64])
+		}
+
+		sig, err := signer.SignHash(context.Background(), hash)
+		if err != nil {
+			t.Fatalf("mode %q: Signer.SignHash: %v", mode, err)
+		}
+		if sig[64] != 27 && sig[64] != 28 {
+			t.Errorf("mode %q: normalized V = %d, want 27 or 28", mode, sig[64])
+		}
+		recovered, err := recoverSigner(common.BytesToHash(hash), sig)
+		if err != nil || recovered != remote.Address() {
+			t.Errorf("mode %q: recovered %s (%v), want %s", mode, recovered.Hex(), err, remote.Address().Hex())
+		}
+	}
+
+	for _, mode := range []string{"wrongkey", "tampered"} {
+		stub.setMode(mode)
+		if _, err := remote.SignHash(context.Background(), hash); err == nil {
+			t.Errorf("mode %q: RemoteSigner.SignHash: want error", mode)
+		}
+		if _, err := signer.SignHash(context.Background(), hash); err == nil {
+			t.Errorf("mode %q: Signer.SignHash: want error", mode)
+		}
+	}
+}
+
+func TestRemoteSignerAddressMismatch(t *testing.T) {
+	_, server := newRemoteSignerStub(t)
+	other, _ := crypto.GenerateKey()
+	remote, err := NewRemoteSigner(context.Background(), RemoteSignerConfig{
+		URL:     server.URL,
+		Address: crypto.PubkeyToAddress(other.PublicKey).Hex(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer remote.Close()
+	_, err = remote.SignHash(context.Background(), crypto.Keccak256([]byte("order")))
+	if err == nil || !strings.Contains(err.Error(), "expected") {
+		t.Fatalf("SignHash with mismatched address = %v, want address error", err)
+	}
+}
+
+func TestRemoteSignerSignTx(t *testing.T) {
+	stub, server := newRemoteSignerStub(t)
+	remote, err := NewRemoteSigner(context.Background(), RemoteSignerConfig{URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer remote.Close()
+
+	chainID := big.NewInt(137)
+	to := common.HexToAddress("0x4D97DCd97eC945f40cF65F87097ACe5EA0476045")
+	txs := map[string]*types.Transaction{
+		"legacy": types.NewTx(&types.LegacyTx{Nonce: 7, GasPrice: big.NewInt(30e9), Gas: 100000, To: &to, Value: big.NewInt(0), Data: []byte{1, 2, 3}}),
+		"1559":   types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 8, GasTipCap: big.NewInt(30e9), GasFeeCap: big.NewInt(100e9), Gas: 100000, To: &to, Value: big.NewInt(1), Data: []byte{4, 5}}),
+	}
+	for name, tx := range txs {
+		stub.setMode("")
+		signed, err := remote.SignTx(context.Background(), tx, chainID)
+		if err != nil {
+			t.Fatalf("%s: SignTx: %v", name, err)
+		}
+		sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+		if err != nil || sender != remote.Address() {
+			t.Errorf("%s: sender = %s (%v), want %s", name, sender.Hex(), err, remote.Address().Hex())
+		}
+		if signed.Nonce() != tx.Nonce() || signed.Gas() != tx.Gas() {
+			t.Errorf("%s: signed tx differs from request", name)
+		}
+
+		for _, mode := range []string{"wrongkey", "tampered"} {
+			stub.setMode(mode)
+			if _, err := remote.SignTx(context.Background(), tx, chainID); err == nil {
+				t.Errorf("%s mode %q: SignTx: want error", name, mode)
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package polymarket
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
@@ -19,6 +20,11 @@ import (
 // SignClobAuthMessage 签名CLOB认证消息（L1认证）
 // 使用EIP-712标准签名
 func SignClobAuthMessage(signer *Signer, timestamp int, nonce int) (string, error) {
+	return SignClobAuthMessageContext(context.Background(), signer, timestamp, nonce)
+}
+
+// SignClobAuthMessageContext 签名CLOB认证消息（L1认证），ctx 传递给签名后端
+func SignClobAuthMessageContext(ctx context.Context, signer *Signer, timestamp int, nonce int) (string, error) {
 	// 构建EIP-712域
 	// 根据EIP-712标准，域分隔符的构建方式：
 	// keccak256(0x1901 || keccak256("EIP712Domain(string name,string version,uint256 chainId)") || nameHash || versionHash || chainId)
@@ -90,7 +96,7 @@ func SignClobAuthMessage(signer *Signer, timestamp int, nonce int) (string, erro
 	// Python代码：signer.sign(auth_struct_hash)
 	// Account._sign_hash接收hex字符串，内部会解码为字节并签名
 	// 我们直接对哈希值进行签名（等价于解码hex字符串后签名）
-	signature, err := signer.Sign(authStructHash.Bytes())
+	signature, err := signer.SignContext(ctx, authStructHash.Bytes())
 	if err != nil {
 		return "", err
 	}
@@ -148,6 +154,11 @@ func BuildHMACSignature(secret string, timestamp int, method string, requestPath
 
 // CreateLevel1Headers 创建L1认证头
 func CreateLevel1Headers(signer *Signer, nonce *int) (map[string]string, error) {
+	return CreateLevel1HeadersContext(context.Background(), signer, nonce)
+}
+
+// CreateLevel1HeadersContext 创建L1认证头，ctx 传递给签名后端（如 RemoteSigner）
+func CreateLevel1HeadersContext(ctx context.Context, signer *Signer, nonce *int) (map[string]string, error) {
 	timestamp := int(time.Now().Unix())
 
 	n := 0
@@ -155,7 +166,7 @@ func CreateLevel1Headers(signer *Signer, nonce *int) (map[string]string, error)
 		n = *nonce
 	}
 
-	signature, err := SignClobAuthMessage(signer, timestamp, n)
+	signature, err := SignClobAuthMessageContext(ctx, signer, timestamp, n)
 	if err != nil {
 		return nil, err
 	}
@@ -40,6 +40,10 @@ type OrderArgs struct {
 	Nonce       int     `json:"nonce"`        // 用于链上取消的nonce
 	Expiration  int     `json:"expiration"`    // 订单过期时间戳
 	Taker       string  `json:"taker"`         // 订单接受者地址，零地址表示公开订单
+
+	// 精确的十进制字符串（如 "0.56"），设置后优先于 Price/Size，避免 float64 的精度问题
+	PriceStr string `json:"-"`
+	SizeStr  string `json:"-"`
 }
 
 // MarketOrderArgs 市价订单参数
@@ -52,6 +56,10 @@ type MarketOrderArgs struct {
 	Nonce       int       `json:"nonce"`        // 用于链上取消的nonce
 	Taker       string    `json:"taker"`         // 订单接受者地址
 	OrderType   OrderType `json:"order_type"`   // 订单类型
+
+	// 精确的十进制字符串，设置后优先于 Amount/Price
+	AmountStr string `json:"-"`
+	PriceStr  string `json:"-"`
 }
 
 // TradeParams 交易查询参数
@@ -138,6 +146,9 @@ type PartialCreateOrderOptions struct {
 	NegRisk   *bool      `json:"neg_risk,omitempty"`   // neg risk（RawOrder 模式下必须提供）
 	RawOrder  bool       `json:"raw_order,omitempty"`  // 跳过从服务器获取 tick_size/neg_risk/fee_rate，必须提供 TickSize 和 NegRisk
 	OrderType *OrderType `json:"order_type,omitempty"` // 订单类型：GTC, FOK, GTD, FAK（默认 GTC）
+
+	SkipValidation bool `json:"-"` // CreateAndPostOrder 跳过提交前校验
+	CheckBalance   bool `json:"-"` // 提交前校验时检查余额（需要L2认证）
 }
 
 // RoundConfig 舍入配置
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
 )
 
 // ParseRawOrderBookSummary 解析原始订单簿摘要
@@ -163,3 +164,24 @@ func getBool(m map[string]interface{}, key string) bool {
 	return false
 }
 
+
+// priceInRange 检查价格是否在 [tickSize, 1-tickSize] 范围内（精确比较）
+func priceInRange(price decimal.Decimal, tickSize TickSize) bool {
+	tick, err := decimal.NewFromString(string(tickSize))
+	if err != nil {
+		return false
+	}
+	return price.GreaterThanOrEqual(tick) && price.LessThanOrEqual(decimal.NewFromInt(1).Sub(tick))
+}
+
+// exactDecimal 解析精确的十进制字符串，未设置时使用 float64 的最短表示
+func exactDecimal(name, str string, value float64) (decimal.Decimal, error) {
+	if str == "" {
+		return decimal.NewFromFloat(value), nil
+	}
+	d, err := decimal.NewFromString(str)
+	if err != nil {
+		return decimal.Decimal{}, fmt.Errorf("invalid %s %q: %w", name, str, err)
+	}
+	return d, nil
+}
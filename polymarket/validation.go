@@ -0,0 +1,297 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	obuilder "github.com/0xNetuser/Polymarket-golang/polymarket/order_builder"
+	"github.com/shopspring/decimal"
+)
+
+// ErrInvalidOrder 订单未通过提交前校验，所有 ValidationError 都匹配该哨兵
+var ErrInvalidOrder = errors.New("invalid order")
+
+// MinGTDExpirationLead GTD 订单过期时间至少要比当前时间晚的时长（服务器的安全阈值为1分钟）
+const MinGTDExpirationLead = time.Minute
+
+// ValidationCode 校验错误类型
+type ValidationCode string
+
+const (
+	ValidationInvalidSide         ValidationCode = "INVALID_SIDE"         // side 不是 BUY/SELL
+	ValidationInvalidTickSize     ValidationCode = "INVALID_TICK_SIZE"    // tick size 小于市场的最小值
+	ValidationPriceOutOfRange     ValidationCode = "PRICE_OUT_OF_RANGE"   // 价格不在 [tick, 1-tick] 范围内
+	ValidationPriceNotOnTick      ValidationCode = "PRICE_NOT_ON_TICK"    // 价格不是 tick size 的整数倍
+	ValidationInvalidSize         ValidationCode = "INVALID_SIZE"         // 数量不是正数
+	ValidationZeroAmount          ValidationCode = "ZERO_AMOUNT"          // 舍入后数量或金额为0
+	ValidationBelowMinSize        ValidationCode = "BELOW_MIN_SIZE"       // 小于市场最小下单量
+	ValidationInvalidExpiration   ValidationCode = "INVALID_EXPIRATION"   // GTD 过期时间无效，或非 GTD 订单设置了过期时间
+	ValidationInsufficientBalance ValidationCode = "INSUFFICIENT_BALANCE" // 余额不足
+)
+
+// ValidationError 单个校验错误
+type ValidationError struct {
+	Code    ValidationCode
+	Field   string // 出错的 OrderArgs 字段
+	Message string
+}
+
+// Error 实现 error 接口
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// Is 匹配 ErrInvalidOrder；余额不足同时匹配 ErrInsufficientBalance
+func (e *ValidationError) Is(target error) bool {
+	switch target {
+	case ErrInvalidOrder:
+		return true
+	case ErrInsufficientBalance:
+		return e.Code == ValidationInsufficientBalance
+	}
+	return false
+}
+
+// ValidationErrors 订单的全部校验错误
+type ValidationErrors []*ValidationError
+
+// Error 实现 error 接口
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidOrder, strings.Join(msgs, "; "))
+}
+
+// Unwrap 支持 errors.Is / errors.As 匹配其中的单个错误
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Has 是否包含指定类型的错误
+func (e ValidationErrors) Has(code ValidationCode) bool {
+	for _, err := range e {
+		if err.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// add 追加一个错误
+func (e *ValidationErrors) add(code ValidationCode, field, format string, args ...interface{}) {
+	*e = append(*e, &ValidationError{Code: code, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// OrderConstraints 校验限价订单使用的市场约束
+type OrderConstraints struct {
+	TickSize     TickSize            // 订单使用的 tick size（必填）
+	MinTickSize  TickSize            // 市场的最小 tick size，为空时不检查
+	MinOrderSize decimal.Decimal     // 市场最小下单量（份额），为0时不检查
+	OrderType    OrderType           // 订单类型，默认 GTC
+	Balance      decimal.NullDecimal // 可用余额（BUY 为 USDC，SELL 为份额），无效时不检查
+	Now          time.Time           // 用于检查 GTD 过期时间，默认当前时间
+}
+
+// ValidateOrderArgs 按市场约束校验限价订单参数，返回全部校验错误（没有错误时返回 nil）
+// 不发起任何网络请求；需要自动获取约束时使用 ClobClient.ValidateOrder
+func ValidateOrderArgs(orderArgs *OrderArgs, cons OrderConstraints) ValidationErrors {
+	var errs ValidationErrors
+
+	isBuy := orderArgs.Side == BUY
+	if !isBuy && orderArgs.Side != SELL {
+		errs.add(ValidationInvalidSide, "Side", "must be %s or %s, got %q", BUY, SELL, orderArgs.Side)
+	}
+
+	if cons.MinTickSize != "" && IsTickSizeSmaller(cons.TickSize, cons.MinTickSize) {
+		errs.add(ValidationInvalidTickSize, "TickSize", "tick size %s is smaller than the market minimum %s", cons.TickSize, cons.MinTickSize)
+	}
+	roundConfig, knownTick := obuilder.RoundingConfig[string(cons.TickSize)]
+	if !knownTick {
+		errs.add(ValidationInvalidTickSize, "TickSize", "unsupported tick size %q", cons.TickSize)
+	}
+
+	price, err := exactDecimal("price", orderArgs.PriceStr, orderArgs.Price)
+	if err != nil {
+		errs.add(ValidationPriceOutOfRange, "Price", "%v", err)
+	} else if knownTick {
+		tick := decimal.RequireFromString(string(cons.TickSize))
+		if !priceInRange(price, cons.TickSize) {
+			errs.add(ValidationPriceOutOfRange, "Price", "price %s must be between %s and %s", price, tick, decimal.NewFromInt(1).Sub(tick))
+		} else if !price.Mod(tick).IsZero() {
+			errs.add(ValidationPriceNotOnTick, "Price", "price %s is not a multiple of tick size %s", price, tick)
+		}
+	}
+
+	size, err := exactDecimal("size", orderArgs.SizeStr, orderArgs.Size)
+	if err != nil {
+		errs.add(ValidationInvalidSize, "Size", "%v", err)
+	} else if size.Sign() <= 0 {
+		errs.add(ValidationInvalidSize, "Size", "size must be positive, got %s", size)
+	} else {
+		if cons.MinOrderSize.Sign() > 0 && size.LessThan(cons.MinOrderSize) {
+			errs.add(ValidationBelowMinSize, "Size", "size %s is below the minimum order size %s", size, cons.MinOrderSize)
+		}
+		if knownTick && price.Sign() > 0 {
+			// 与 GetOrderAmountsDecimal 相同的舍入：份额向下取整到 Size 位，金额至少要有 Amount 位精度
+			shares := obuilder.RoundDownDecimal(size, roundConfig.Size)
+			amount := obuilder.RoundDownDecimal(shares.Mul(price), roundConfig.Amount)
+			if shares.IsZero() || amount.IsZero() {
+				errs.add(ValidationZeroAmount, "Size", "size %s at price %s rounds to a zero amount", size, price)
+			}
+		}
+	}
+
+	orderType := cons.OrderType
+	if orderType == "" {
+		orderType = OrderTypeGTC
+	}
+	now := cons.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if orderType == OrderTypeGTD {
+		earliest := now.Add(MinGTDExpirationLead)
+		if orderArgs.Expiration <= 0 {
+			errs.add(ValidationInvalidExpiration, "Expiration", "GTD order requires an expiration timestamp")
+		} else if expiration := time.Unix(int64(orderArgs.Expiration), 0); !expiration.After(earliest) {
+			errs.add(ValidationInvalidExpiration, "Expiration", "expiration %s must be later than %s (now + %s)",
+				expiration.UTC().Format(time.RFC3339), earliest.UTC().Format(time.RFC3339), MinGTDExpirationLead)
+		}
+	} else if orderArgs.Expiration != 0 {
+		errs.add(ValidationInvalidExpiration, "Expiration", "expiration is only allowed for GTD orders, got %s order", orderType)
+	}
+
+	if cons.Balance.Valid && size.Sign() > 0 {
+		required, unit := size, "shares"
+		if isBuy {
+			required, unit = size.Mul(price), "USDC"
+		}
+		if cons.Balance.Decimal.LessThan(required) {
+			errs.add(ValidationInsufficientBalance, "Size", "order needs %s %s but only %s is available", required, unit, cons.Balance.Decimal)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// ValidateOrder 提交前校验限价订单
+// 从订单簿获取 tick size 和最小下单量；options.CheckBalance 为 true 时通过 GetBalanceAllowance 检查余额（需要L2认证）。
+// options.RawOrder 为 true 时不获取订单簿，只使用 options.TickSize。
+// 校验失败返回 ValidationErrors（可用 errors.Is(err, ErrInvalidOrder) 判断），请求失败返回对应的错误
+func (c *ClobClient) ValidateOrder(ctx context.Context, orderArgs *OrderArgs, options *PartialCreateOrderOptions) error {
+	cons := OrderConstraints{}
+	if options != nil && options.OrderType != nil {
+		cons.OrderType = *options.OrderType
+	}
+
+	if options != nil && options.RawOrder {
+		if options.TickSize == nil {
+			return fmt.Errorf("RawOrder mode requires TickSize to be provided in options")
+		}
+		cons.TickSize = *options.TickSize
+	} else {
+		book, err := c.GetOrderBook(ctx, orderArgs.TokenID)
+		if err != nil {
+			return fmt.Errorf("no orderbook: %w", err)
+		}
+		cons.MinTickSize = TickSize(book.TickSize)
+		cons.TickSize = cons.MinTickSize
+		if options != nil && options.TickSize != nil {
+			cons.TickSize = *options.TickSize
+		}
+		if book.MinOrderSize != "" {
+			minSize, err := decimal.NewFromString(book.MinOrderSize)
+			if err != nil {
+				return fmt.Errorf("invalid min_order_size %q: %w", book.MinOrderSize, err)
+			}
+			cons.MinOrderSize = minSize
+		}
+	}
+
+	if options != nil && options.CheckBalance && (orderArgs.Side == BUY || orderArgs.Side == SELL) {
+		var negRisk bool
+		if options.NegRisk != nil {
+			negRisk = *options.NegRisk
+		} else {
+			var err error
+			if negRisk, err = c.GetNegRisk(ctx, orderArgs.TokenID); err != nil {
+				return fmt.Errorf("get neg risk: %w", err)
+			}
+		}
+		balance, err := c.availableBalance(ctx, orderArgs.Side, orderArgs.TokenID, negRisk)
+		if err != nil {
+			return err
+		}
+		cons.Balance = decimal.NewNullDecimal(balance)
+	}
+
+	if errs := ValidateOrderArgs(orderArgs, cons); errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// availableBalance 查询下单可用的余额（BUY 为 USDC，SELL 为 token 份额），取余额与授权额度中较小者
+// 授权额度取订单所用交易所合约（negRisk 决定 CTF Exchange 或 Neg Risk CTF Exchange）的额度
+func (c *ClobClient) availableBalance(ctx context.Context, side, tokenID string, negRisk bool) (decimal.Decimal, error) {
+	params := &BalanceAllowanceParams{AssetType: AssetTypeCollateral}
+	if side == SELL {
+		params = &BalanceAllowanceParams{AssetType: AssetTypeConditional, TokenID: tokenID}
+	}
+	resp, err := c.GetBalanceAllowance(ctx, params)
+	if err != nil {
+		return decimal.Decimal{}, fmt.Errorf("get balance: %w", err)
+	}
+
+	balance, err := decimal.NewFromString(fmt.Sprintf("%v", resp["balance"]))
+	if err != nil {
+		return decimal.Decimal{}, fmt.Errorf("invalid balance %v: %w", resp["balance"], err)
+	}
+
+	// 授权额度：旧版本返回 allowance，新版本按交易所合约地址返回 allowances
+	var allowance decimal.NullDecimal
+	if raw, ok := resp["allowance"]; ok {
+		if a, err := decimal.NewFromString(fmt.Sprintf("%v", raw)); err == nil {
+			allowance = decimal.NewNullDecimal(a)
+		}
+	}
+	if raw, ok := resp["allowances"].(map[string]interface{}); ok {
+		allowance, err = exchangeAllowance(raw, getContractConfig(c.chainID, negRisk).Exchange)
+		if err != nil {
+			return decimal.Decimal{}, err
+		}
+	}
+	if allowance.Valid && allowance.Decimal.LessThan(balance) {
+		balance = allowance.Decimal
+	}
+
+	// 余额以6位小数的基本单位返回
+	return balance.Shift(-6), nil
+}
+
+// exchangeAllowance 从按合约地址返回的 allowances 中取出 exchange 的额度，没有该地址时为0
+func exchangeAllowance(allowances map[string]interface{}, exchange string) (decimal.NullDecimal, error) {
+	for addr, v := range allowances {
+		if !strings.EqualFold(addr, exchange) {
+			continue
+		}
+		a, err := decimal.NewFromString(fmt.Sprintf("%v", v))
+		if err != nil {
+			return decimal.NullDecimal{}, fmt.Errorf("invalid allowance %v for %s: %w", v, addr, err)
+		}
+		return decimal.NewNullDecimal(a), nil
+	}
+	return decimal.NewNullDecimal(decimal.Zero), nil
+}
@@ -0,0 +1,68 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestValidateOrderUsesExchangeAllowance(t *testing.T) {
+	// 余额 100 USDC，只授权给了 Neg Risk CTF Exchange
+	body := `{"balance":"100000000","allowances":{
+		"0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E":"0",
+		"0xc5d563a36ae78145c45a50134d48a1215220f80a":"50000000",
+		"0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296":"1000000000"}}`
+
+	tests := []struct {
+		name    string
+		negRisk bool
+		size    float64
+		wantErr bool
+	}{
+		{"ctf exchange not approved", false, 10, true},
+		{"neg risk within allowance", true, 10, false},
+		{"neg risk above allowance", true, 120, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			creds := testOldCreds
+			client := newStaticClient(t, body)
+			client.SetAPICreds(&creds)
+			tickSize, negRisk := TickSize("0.01"), tt.negRisk
+			err := client.ValidateOrder(context.Background(),
+				&OrderArgs{TokenID: "123", Side: BUY, Price: 0.5, Size: tt.size},
+				&PartialCreateOrderOptions{TickSize: &tickSize, NegRisk: &negRisk, RawOrder: true, CheckBalance: true})
+			if got := errors.Is(err, ErrInsufficientBalance); got != tt.wantErr {
+				t.Errorf("ValidateOrder = %v, want insufficient balance %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExchangeAllowance(t *testing.T) {
+	allowances := map[string]interface{}{
+		"0xC5d563A36AE78145C45a50134d48A1215220f80a": "25",
+		"0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E": float64(7),
+	}
+	tests := []struct {
+		exchange string
+		want     string
+	}{
+		{"0xc5d563a36ae78145c45a50134d48a1215220f80a", "25"},
+		{"0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E", "7"},
+		{"0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296", "0"},
+	}
+	for _, tt := range tests {
+		got, err := exchangeAllowance(allowances, tt.exchange)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Valid || got.Decimal.String() != tt.want {
+			t.Errorf("exchangeAllowance(%s) = %v, want %s", tt.exchange, got, tt.want)
+		}
+	}
+
+	if _, err := exchangeAllowance(map[string]interface{}{"0xC5d563A36AE78145C45a50134d48A1215220f80a": "lots"}, "0xC5d563A36AE78145C45a50134d48A1215220f80a"); err == nil {
+		t.Error("exchangeAllowance with invalid value: want error")
+	}
+}
@@ -21,6 +21,7 @@ var (
 	ProxyFactoryABI      abi.ABI
 	SafeProxyFactoryABI  abi.ABI
 	SafeABI              abi.ABI
+	MultiSendABI         abi.ABI
 )
 
 func init() {
@@ -65,6 +66,11 @@ func init() {
 	if err != nil {
 		panic("failed to load Safe ABI: " + err.Error())
 	}
+
+	MultiSendABI, err = loadABI("MultiSend")
+	if err != nil {
+		panic("failed to load MultiSend ABI: " + err.Error())
+	}
 }
 
 func loadABI(contractName string) (abi.ABI, error) {
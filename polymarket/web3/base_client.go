@@ -12,6 +12,8 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/0xNetuser/Polymarket-golang/polymarket"
 )
 
 // 常量地址
@@ -23,6 +25,7 @@ var (
 	NegRiskAdapterAddress   = common.HexToAddress("0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296")
 	ProxyFactoryAddress     = common.HexToAddress("0xaB45c5A4B0c941a2F231C04C3f49182e1A254052")
 	SafeProxyFactoryAddress = common.HexToAddress("0xaacFeEa03eb1561C4e67d661e40682Bd20E3541b")
+	MultiSendAddress        = common.HexToAddress("0xA238CBeb142c10Ef7Ad8442C6D1f9E89e07e7761") // Safe MultiSend（通过 delegatecall 批量执行）
 
 	// 默认 RPC 端点
 	DefaultPolygonRPC = "https://polygon-rpc.com"
@@ -58,12 +61,13 @@ var chainConfigs = map[int64]*ChainConfig{
 // BaseWeb3Client Web3 基础客户端
 type BaseWeb3Client struct {
 	client        *ethclient.Client
-	privateKey    *ecdsa.PrivateKey
+	signer        polymarket.SignerBackend
 	account       common.Address
 	signatureType SignatureType
 	chainID       int64
 	config        *ChainConfig
 	negRiskConfig *ChainConfig
+	receiptConfig ReceiptWaitConfig
 
 	// 地址（根据签名类型不同）
 	Address common.Address
@@ -76,6 +80,7 @@ type BaseWeb3Client struct {
 	NegRiskAdapterAddress    common.Address
 	ProxyFactoryAddress      common.Address
 	SafeProxyFactoryAddress  common.Address
+	MultiSendAddress         common.Address
 }
 
 // NewBaseWeb3Client 创建基础 Web3 客户端
@@ -85,29 +90,38 @@ func NewBaseWeb3Client(
 	chainID int64,
 	rpcURL string,
 ) (*BaseWeb3Client, error) {
+	// 解析私钥
+	signer, err := polymarket.NewPrivateKeySigner(stripHexPrefix(privateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	return NewBaseWeb3ClientWithSigner(context.Background(), signer, signatureType, chainID, rpcURL)
+}
+
+// NewBaseWeb3ClientWithSigner 使用签名后端（如 polymarket.RemoteSigner）创建基础 Web3 客户端
+// ctx 用于连接节点和查询代理钱包地址，取消或超时时返回错误
+func NewBaseWeb3ClientWithSigner(
+	ctx context.Context,
+	signer polymarket.SignerBackend,
+	signatureType SignatureType,
+	chainID int64,
+	rpcURL string,
+) (*BaseWeb3Client, error) {
+	if signer == nil {
+		return nil, fmt.Errorf("signer is required")
+	}
 	if rpcURL == "" {
 		rpcURL = DefaultPolygonRPC
 	}
 
 	// 连接到以太坊客户端
-	client, err := ethclient.Dial(rpcURL)
+	client, err := ethclient.DialContext(ctx, rpcURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to Ethereum client: %w", err)
 	}
 
-	// 解析私钥
-	privKey, err := crypto.HexToECDSA(stripHexPrefix(privateKey))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse private key: %w", err)
-	}
-
 	// 获取账户地址
-	publicKey := privKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return nil, fmt.Errorf("failed to get public key")
-	}
-	account := crypto.PubkeyToAddress(*publicKeyECDSA)
+	account := signer.Address()
 
 	// 获取链配置
 	config, ok := chainConfigs[chainID]
@@ -126,12 +140,13 @@ func NewBaseWeb3Client(
 
 	c := &BaseWeb3Client{
 		client:        client,
-		privateKey:    privKey,
+		signer:        signer,
 		account:       account,
 		signatureType: signatureType,
 		chainID:       chainID,
 		config:        config,
 		negRiskConfig: negRiskConfig,
+		receiptConfig: DefaultReceiptWaitConfig,
 
 		USDCAddress:              config.Collateral,
 		ConditionalTokensAddress: config.ConditionalTokens,
@@ -140,10 +155,12 @@ func NewBaseWeb3Client(
 		NegRiskAdapterAddress:    NegRiskAdapterAddress,
 		ProxyFactoryAddress:      ProxyFactoryAddress,
 		SafeProxyFactoryAddress:  SafeProxyFactoryAddress,
+		MultiSendAddress:         MultiSendAddress,
 	}
 
 	// 设置地址（根据签名类型）
-	if err := c.setupAddress(); err != nil {
+	if err := c.setupAddress(ctx); err != nil {
+		client.Close()
 		return nil, err
 	}
 
@@ -151,18 +168,18 @@ func NewBaseWeb3Client(
 }
 
 // setupAddress 根据签名类型设置地址
-func (c *BaseWeb3Client) setupAddress() error {
+func (c *BaseWeb3Client) setupAddress(ctx context.Context) error {
 	switch c.signatureType {
 	case SignatureTypeEOA:
 		c.Address = c.account
 	case SignatureTypePolyProxy:
-		addr, err := c.GetPolyProxyAddress(c.account)
+		addr, err := c.GetPolyProxyAddress(ctx, c.account)
 		if err != nil {
 			return err
 		}
 		c.Address = addr
 	case SignatureTypeSafe:
-		addr, err := c.GetSafeProxyAddress(c.account)
+		addr, err := c.GetSafeProxyAddress(ctx, c.account)
 		if err != nil {
 			return err
 		}
@@ -179,7 +196,7 @@ func (c *BaseWeb3Client) GetBaseAddress() common.Address {
 }
 
 // GetPolyProxyAddress 获取 Polymarket 代理地址
-func (c *BaseWeb3Client) GetPolyProxyAddress(address common.Address) (common.Address, error) {
+func (c *BaseWeb3Client) GetPolyProxyAddress(ctx context.Context, address common.Address) (common.Address, error) {
 	// 调用 CTFExchange 合约的 getPolyProxyWalletAddress 方法
 	data, err := CTFExchangeABI.Pack("getPolyProxyWalletAddress", address)
 	if err != nil {
@@ -191,7 +208,7 @@ func (c *BaseWeb3Client) GetPolyProxyAddress(address common.Address) (common.Add
 		Data: data,
 	}
 
-	result, err := c.client.CallContract(context.Background(), msg, nil)
+	result, err := c.client.CallContract(ctx, msg, nil)
 	if err != nil {
 		return common.Address{}, fmt.Errorf("failed to call contract: %w", err)
 	}
@@ -206,7 +223,7 @@ func (c *BaseWeb3Client) GetPolyProxyAddress(address common.Address) (common.Add
 }
 
 // GetSafeProxyAddress 获取 Safe 代理地址
-func (c *BaseWeb3Client) GetSafeProxyAddress(address common.Address) (common.Address, error) {
+func (c *BaseWeb3Client) GetSafeProxyAddress(ctx context.Context, address common.Address) (common.Address, error) {
 	// 调用 SafeProxyFactory 合约的 computeProxyAddress 方法
 	data, err := SafeProxyFactoryABI.Pack("computeProxyAddress", address)
 	if err != nil {
@@ -218,7 +235,7 @@ func (c *BaseWeb3Client) GetSafeProxyAddress(address common.Address) (common.Add
 		Data: data,
 	}
 
-	result, err := c.client.CallContract(context.Background(), msg, nil)
+	result, err := c.client.CallContract(ctx, msg, nil)
 	if err != nil {
 		return common.Address{}, fmt.Errorf("failed to call contract: %w", err)
 	}
@@ -233,8 +250,8 @@ func (c *BaseWeb3Client) GetSafeProxyAddress(address common.Address) (common.Add
 }
 
 // GetPOLBalance 获取 POL 余额
-func (c *BaseWeb3Client) GetPOLBalance() (*big.Float, error) {
-	balance, err := c.client.BalanceAt(context.Background(), c.account, nil)
+func (c *BaseWeb3Client) GetPOLBalance(ctx context.Context) (*big.Float, error) {
+	balance, err := c.client.BalanceAt(ctx, c.account, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -248,7 +265,7 @@ func (c *BaseWeb3Client) GetPOLBalance() (*big.Float, error) {
 }
 
 // GetUSDCBalance 获取 USDC 余额
-func (c *BaseWeb3Client) GetUSDCBalance(address common.Address) (*big.Float, error) {
+func (c *BaseWeb3Client) GetUSDCBalance(ctx context.Context, address common.Address) (*big.Float, error) {
 	if address == (common.Address{}) {
 		address = c.Address
 	}
@@ -264,7 +281,7 @@ func (c *BaseWeb3Client) GetUSDCBalance(address common.Address) (*big.Float, err
 		Data: data,
 	}
 
-	result, err := c.client.CallContract(context.Background(), msg, nil)
+	result, err := c.client.CallContract(ctx, msg, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call contract: %w", err)
 	}
@@ -284,7 +301,7 @@ func (c *BaseWeb3Client) GetUSDCBalance(address common.Address) (*big.Float, err
 }
 
 // GetTokenBalance 获取条件代币余额
-func (c *BaseWeb3Client) GetTokenBalance(tokenID string, address common.Address) (*big.Float, error) {
+func (c *BaseWeb3Client) GetTokenBalance(ctx context.Context, tokenID string, address common.Address) (*big.Float, error) {
 	if address == (common.Address{}) {
 		address = c.Address
 	}
@@ -303,7 +320,7 @@ func (c *BaseWeb3Client) GetTokenBalance(tokenID string, address common.Address)
 		Data: data,
 	}
 
-	result, err := c.client.CallContract(context.Background(), msg, nil)
+	result, err := c.client.CallContract(ctx, msg, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call contract: %w", err)
 	}
@@ -323,7 +340,7 @@ func (c *BaseWeb3Client) GetTokenBalance(tokenID string, address common.Address)
 }
 
 // GetTokenComplement 获取互补代币 ID
-func (c *BaseWeb3Client) GetTokenComplement(tokenID string) (string, error) {
+func (c *BaseWeb3Client) GetTokenComplement(ctx context.Context, tokenID string) (string, error) {
 	tokenIDBig := new(big.Int)
 	tokenIDBig.SetString(tokenID, 10)
 
@@ -338,7 +355,7 @@ func (c *BaseWeb3Client) GetTokenComplement(tokenID string) (string, error) {
 		Data: data,
 	}
 
-	result, err := c.client.CallContract(context.Background(), msg, nil)
+	result, err := c.client.CallContract(ctx, msg, nil)
 	if err == nil && len(result) > 0 {
 		var complement *big.Int
 		err = NegRiskExchangeABI.UnpackIntoInterface(&complement, "getComplement", result)
@@ -358,7 +375,7 @@ func (c *BaseWeb3Client) GetTokenComplement(tokenID string) (string, error) {
 		Data: data,
 	}
 
-	result, err = c.client.CallContract(context.Background(), msg, nil)
+	result, err = c.client.CallContract(ctx, msg, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to call contract: %w", err)
 	}
@@ -373,7 +390,7 @@ func (c *BaseWeb3Client) GetTokenComplement(tokenID string) (string, error) {
 }
 
 // GetConditionIDNegRisk 获取 neg risk 市场的 condition ID
-func (c *BaseWeb3Client) GetConditionIDNegRisk(questionID common.Hash) (common.Hash, error) {
+func (c *BaseWeb3Client) GetConditionIDNegRisk(ctx context.Context, questionID common.Hash) (common.Hash, error) {
 	// 调用 NegRiskAdapter 合约的 getConditionId 方法
 	data, err := NegRiskAdapterABI.Pack("getConditionId", questionID)
 	if err != nil {
@@ -385,7 +402,7 @@ func (c *BaseWeb3Client) GetConditionIDNegRisk(questionID common.Hash) (common.H
 		Data: data,
 	}
 
-	result, err := c.client.CallContract(context.Background(), msg, nil)
+	result, err := c.client.CallContract(ctx, msg, nil)
 	if err != nil {
 		return common.Hash{}, fmt.Errorf("failed to call contract: %w", err)
 	}
@@ -452,13 +469,13 @@ func (c *BaseWeb3Client) encodeProxy(proxyTxn interface{}) ([]byte, error) {
 }
 
 // GetTransactionOpts 获取交易选项
-func (c *BaseWeb3Client) GetTransactionOpts() (*bind.TransactOpts, error) {
-	nonce, err := c.client.PendingNonceAt(context.Background(), c.account)
+func (c *BaseWeb3Client) GetTransactionOpts(ctx context.Context) (*bind.TransactOpts, error) {
+	nonce, err := c.client.PendingNonceAt(ctx, c.account)
 	if err != nil {
 		return nil, err
 	}
 
-	gasPrice, err := c.client.SuggestGasPrice(context.Background())
+	gasPrice, err := c.client.SuggestGasPrice(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -467,9 +484,15 @@ func (c *BaseWeb3Client) GetTransactionOpts() (*bind.TransactOpts, error) {
 	gasPrice = new(big.Int).Mul(gasPrice, big.NewInt(105))
 	gasPrice = new(big.Int).Div(gasPrice, big.NewInt(100))
 
-	auth, err := bind.NewKeyedTransactorWithChainID(c.privateKey, big.NewInt(c.chainID))
-	if err != nil {
-		return nil, err
+	auth := &bind.TransactOpts{
+		From: c.account,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != c.account {
+				return nil, bind.ErrNotAuthorized
+			}
+			return c.signTx(ctx, tx)
+		},
+		Context: ctx,
 	}
 
 	auth.Nonce = big.NewInt(int64(nonce))
@@ -481,8 +504,14 @@ func (c *BaseWeb3Client) GetTransactionOpts() (*bind.TransactOpts, error) {
 }
 
 // WaitForReceipt 等待交易收据
-func (c *BaseWeb3Client) WaitForReceipt(txHash common.Hash) (*types.Receipt, error) {
-	return bind.WaitMined(context.Background(), c.client, &types.Transaction{})
+// 按 SetReceiptWaitConfig 设置的间隔轮询，超时或交易被丢弃时返回 *ReceiptWaitError
+func (c *BaseWeb3Client) WaitForReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return NewReceiptWaiter(c.client, c.receiptConfig).Wait(ctx, txHash)
+}
+
+// SetReceiptWaitConfig 设置等待交易回执的轮询间隔、超时、确认数等（应在发送交易前设置）
+func (c *BaseWeb3Client) SetReceiptWaitConfig(cfg ReceiptWaitConfig) {
+	c.receiptConfig = cfg
 }
 
 // Client 返回底层的 ethclient
@@ -490,9 +519,30 @@ func (c *BaseWeb3Client) Client() *ethclient.Client {
 	return c.client
 }
 
-// PrivateKey 返回私钥
+// PrivateKey 返回私钥，签名后端不是 polymarket.PrivateKeySigner 时返回 nil
+//
+// Deprecated: 通过 Signer 签名，不要直接读取私钥
 func (c *BaseWeb3Client) PrivateKey() *ecdsa.PrivateKey {
-	return c.privateKey
+	if local, ok := c.signer.(*polymarket.PrivateKeySigner); ok {
+		return local.PrivateKey()
+	}
+	return nil
+}
+
+// Signer 返回签名后端
+func (c *BaseWeb3Client) Signer() polymarket.SignerBackend {
+	return c.signer
+}
+
+// signTx 使用签名后端签名交易
+func (c *BaseWeb3Client) signTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	return c.signer.SignTx(ctx, tx, big.NewInt(c.chainID))
+}
+
+// signMessageHash 按 eth_sign 方式（添加 "\x19Ethereum Signed Message:\n32" 前缀）签名32字节哈希，返回的 v 为 0/1 或 27/28
+func (c *BaseWeb3Client) signMessageHash(ctx context.Context, hash []byte) ([]byte, error) {
+	prefixedHash := crypto.Keccak256(append([]byte("\x19Ethereum Signed Message:\n32"), hash...))
+	return c.signer.SignHash(ctx, prefixedHash)
 }
 
 // ChainID 返回链 ID
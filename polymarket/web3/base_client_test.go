@@ -0,0 +1,46 @@
+package web3
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/0xNetuser/Polymarket-golang/polymarket"
+)
+
+func TestNewBaseWeb3ClientWithSignerHonoursContext(t *testing.T) {
+	// 节点不响应时，查询代理钱包地址应随 ctx 超时返回
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	signer, err := polymarket.NewPrivateKeySigner("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := NewBaseWeb3ClientWithSigner(ctx, signer, SignatureTypeSafe, 137, server.URL)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("NewBaseWeb3ClientWithSigner against unresponsive node: want error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("NewBaseWeb3ClientWithSigner did not return after ctx timeout")
+	}
+}
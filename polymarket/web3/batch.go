@@ -0,0 +1,109 @@
+package web3
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Call 批量交易中的单个调用
+type Call struct {
+	To    common.Address
+	Value *big.Int // 可选，默认0
+	Data  []byte
+}
+
+// Safe execTransaction 的 operation 参数
+const (
+	SafeOperationCall         uint8 = 0
+	SafeOperationDelegateCall uint8 = 1
+)
+
+// proxyCallTypeCall ProxyWalletFactory 调用类型：CALL
+const proxyCallTypeCall uint8 = 1
+
+// safeCall Safe execTransaction 执行的调用
+type safeCall struct {
+	To        common.Address
+	Value     *big.Int
+	Data      []byte
+	Operation uint8
+}
+
+// EncodeMultiSend 将多个调用编码为 MultiSend.multiSend(bytes) 的调用数据
+// 每个调用按 operation(1) | to(20) | value(32) | dataLength(32) | data 紧密打包，operation 均为 CALL
+func EncodeMultiSend(calls []Call) ([]byte, error) {
+	if len(calls) == 0 {
+		return nil, fmt.Errorf("no calls to encode")
+	}
+
+	var packed []byte
+	for _, call := range calls {
+		packed = append(packed, SafeOperationCall)
+		packed = append(packed, call.To.Bytes()...)
+		packed = append(packed, common.LeftPadBytes(callValue(call).Bytes(), 32)...)
+		var length [32]byte
+		binary.BigEndian.PutUint64(length[24:], uint64(len(call.Data)))
+		packed = append(packed, length[:]...)
+		packed = append(packed, call.Data...)
+	}
+
+	data, err := MultiSendABI.Pack("multiSend", packed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode multiSend: %w", err)
+	}
+	return data, nil
+}
+
+// proxyCalls 将调用转换为 ProxyWalletFactory.proxy 的参数
+func proxyCalls(calls []Call) []ProxyCall {
+	result := make([]ProxyCall, len(calls))
+	for i, call := range calls {
+		result[i] = ProxyCall{
+			TypeCode: proxyCallTypeCall,
+			To:       call.To,
+			Value:    callValue(call),
+			Data:     call.Data,
+		}
+	}
+	return result
+}
+
+// safeCallFor 单个调用直接执行；多个调用通过 delegatecall MultiSend 在同一笔交易中执行
+func (c *BaseWeb3Client) safeCallFor(calls []Call) (safeCall, error) {
+	if len(calls) == 1 {
+		return safeCall{To: calls[0].To, Value: callValue(calls[0]), Data: calls[0].Data, Operation: SafeOperationCall}, nil
+	}
+	data, err := EncodeMultiSend(calls)
+	if err != nil {
+		return safeCall{}, err
+	}
+	return safeCall{To: c.MultiSendAddress, Value: big.NewInt(0), Data: data, Operation: SafeOperationDelegateCall}, nil
+}
+
+// estimateBatchGas 估算代理/Safe交易的gas
+// 单个调用按钱包直接调用目标合约估算；多个调用的后续调用可能依赖前面的调用（如先 approve），
+// 因此按签名账户发送的外层交易（to, data）整体估算
+func (c *BaseWeb3Client) estimateBatchGas(ctx context.Context, calls []Call, to common.Address, data []byte) uint64 {
+	msg := ethereum.CallMsg{From: c.account, To: &to, Data: data}
+	if len(calls) == 1 {
+		msg = ethereum.CallMsg{From: c.Address, To: &calls[0].To, Data: calls[0].Data}
+	}
+	gas, err := c.client.EstimateGas(ctx, msg)
+	if err != nil {
+		gas = 500000 * uint64(len(calls))
+	}
+	return uint64(float64(gas)*1.05) + 100000
+}
+
+// callValue 调用的 value，未设置时为0
+func callValue(call Call) *big.Int {
+	if call.Value == nil {
+		return big.NewInt(0)
+	}
+	return call.Value
+}
@@ -0,0 +1,135 @@
+package web3
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	testUSDC = common.HexToAddress("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174")
+	testCTF  = common.HexToAddress("0x4D97DCd97eC945f40cF65F87097ACe5EA0476045")
+)
+
+// mustHex 拼接十六进制片段并解码，片段中的空格忽略
+func mustHex(t *testing.T, parts ...string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(strings.ReplaceAll(strings.Join(parts, ""), " ", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestEncodeMultiSendGolden(t *testing.T) {
+	calls := []Call{
+		{To: testUSDC, Data: []byte{0xde, 0xad, 0xbe, 0xef}},
+		{To: testCTF, Value: big.NewInt(1)},
+	}
+	got, err := EncodeMultiSend(calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Safe MultiSend 打包格式：operation(1) | to(20) | value(32) | dataLength(32) | data
+	want := mustHex(t,
+		"8d80ff0a", // multiSend(bytes)
+		"0000000000000000000000000000000000000000000000000000000000000020", // bytes 偏移
+		"00000000000000000000000000000000000000000000000000000000000000ae", // bytes 长度 174 = 89 + 85
+		// 调用1
+		"00",
+		"2791bca1f2de4661ed88a30c99a7a9449aa84174",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000004",
+		"deadbeef",
+		// 调用2
+		"00",
+		"4d97dcd97ec945f40cf65f87097ace5ea0476045",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		// 补齐到32字节
+		"000000000000000000000000000000000000",
+	)
+	if !bytes.Equal(got, want) {
+		t.Errorf("EncodeMultiSend =\n%x\nwant\n%x", got, want)
+	}
+	if sel := crypto.Keccak256([]byte("multiSend(bytes)"))[:4]; !bytes.Equal(got[:4], sel) {
+		t.Errorf("selector = %x, want %x", got[:4], sel)
+	}
+}
+
+func TestEncodeMultiSendEmpty(t *testing.T) {
+	if _, err := EncodeMultiSend(nil); err == nil {
+		t.Error("EncodeMultiSend(nil): want error")
+	}
+}
+
+func TestProxyCalls(t *testing.T) {
+	calls := []Call{
+		{To: testUSDC, Data: []byte{0xde, 0xad, 0xbe, 0xef}},
+		{To: testCTF, Value: big.NewInt(5), Data: []byte{1}},
+	}
+	got := proxyCalls(calls)
+	if len(got) != 2 {
+		t.Fatalf("len = %d, want 2", len(got))
+	}
+	for i, pc := range got {
+		if pc.TypeCode != proxyCallTypeCall || pc.To != calls[i].To || !bytes.Equal(pc.Data, calls[i].Data) {
+			t.Errorf("proxyCalls[%d] = %+v", i, pc)
+		}
+	}
+	if got[0].Value == nil || got[0].Value.Sign() != 0 {
+		t.Errorf("proxyCalls[0].Value = %v, want 0", got[0].Value)
+	}
+	if got[1].Value.Int64() != 5 {
+		t.Errorf("proxyCalls[1].Value = %v, want 5", got[1].Value)
+	}
+}
+
+func TestProxyCallsPackGolden(t *testing.T) {
+	got, err := ProxyFactoryABI.Pack("proxy", proxyCalls([]Call{{To: testUSDC, Data: []byte{0xde, 0xad, 0xbe, 0xef}}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := append(crypto.Keccak256([]byte("proxy((uint8,address,uint256,bytes)[])"))[:4], mustHex(t,
+		"0000000000000000000000000000000000000000000000000000000000000020", // 数组偏移
+		"0000000000000000000000000000000000000000000000000000000000000001", // 数组长度
+		"0000000000000000000000000000000000000000000000000000000000000020", // 元素0偏移
+		"0000000000000000000000000000000000000000000000000000000000000001", // typeCode = CALL
+		"0000000000000000000000002791bca1f2de4661ed88a30c99a7a9449aa84174", // to
+		"0000000000000000000000000000000000000000000000000000000000000000", // value
+		"0000000000000000000000000000000000000000000000000000000000000080", // data 偏移
+		"0000000000000000000000000000000000000000000000000000000000000004", // data 长度
+		"deadbeef00000000000000000000000000000000000000000000000000000000",
+	)...)
+	if !bytes.Equal(got, want) {
+		t.Errorf("proxy calldata =\n%x\nwant\n%x", got, want)
+	}
+}
+
+func TestSafeCallFor(t *testing.T) {
+	c := &BaseWeb3Client{MultiSendAddress: MultiSendAddress}
+
+	single, err := c.safeCallFor([]Call{{To: testCTF, Data: []byte{1}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if single.To != testCTF || single.Operation != SafeOperationCall || single.Value.Sign() != 0 || !bytes.Equal(single.Data, []byte{1}) {
+		t.Errorf("single call = %+v", single)
+	}
+
+	calls := []Call{{To: testUSDC, Data: []byte{1}}, {To: testCTF, Data: []byte{2}}}
+	batch, err := c.safeCallFor(calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := EncodeMultiSend(calls)
+	if batch.To != MultiSendAddress || batch.Operation != SafeOperationDelegateCall || !bytes.Equal(batch.Data, want) {
+		t.Errorf("batch call = %+v", batch)
+	}
+}
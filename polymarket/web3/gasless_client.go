@@ -12,7 +12,6 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/0xNetuser/Polymarket-golang/polymarket"
 )
@@ -36,17 +35,34 @@ func NewPolymarketGaslessWeb3Client(
 	builderCreds *polymarket.ApiCreds,
 	chainID int64,
 	rpcURL string,
+) (*PolymarketGaslessWeb3Client, error) {
+	signer, err := polymarket.NewPrivateKeySigner(stripHexPrefix(privateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	return NewPolymarketGaslessWeb3ClientWithSigner(context.Background(), signer, signatureType, builderCreds, chainID, rpcURL)
+}
+
+// NewPolymarketGaslessWeb3ClientWithSigner 使用签名后端（如 polymarket.RemoteSigner）创建PolymarketGaslessWeb3Client
+// ctx 用于连接节点和查询代理钱包地址
+func NewPolymarketGaslessWeb3ClientWithSigner(
+	ctx context.Context,
+	backend polymarket.SignerBackend,
+	signatureType SignatureType,
+	builderCreds *polymarket.ApiCreds,
+	chainID int64,
+	rpcURL string,
 ) (*PolymarketGaslessWeb3Client, error) {
 	if signatureType != SignatureTypePolyProxy && signatureType != SignatureTypeSafe {
 		return nil, fmt.Errorf("PolymarketGaslessWeb3Client only supports signature_type=1 (Poly proxy wallets) and signature_type=2 (Safe wallets)")
 	}
 
-	base, err := NewBaseWeb3Client(privateKey, signatureType, chainID, rpcURL)
+	base, err := NewBaseWeb3ClientWithSigner(ctx, backend, signatureType, chainID, rpcURL)
 	if err != nil {
 		return nil, err
 	}
 
-	signer, err := polymarket.NewSigner(privateKey, int(chainID))
+	signer, err := polymarket.NewSignerWithBackend(backend, int(chainID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create signer: %w", err)
 	}
@@ -60,16 +76,48 @@ func NewPolymarketGaslessWeb3Client(
 	}, nil
 }
 
-// Execute 通过无gas中继执行交易
-func (c *PolymarketGaslessWeb3Client) Execute(to common.Address, data []byte, operationName string, metadata string) (*TransactionReceipt, error) {
+// Execute 通过无gas中继执行交易，并等待交易上链
+func (c *PolymarketGaslessWeb3Client) Execute(ctx context.Context, to common.Address, data []byte, operationName string, metadata string) (*TransactionReceipt, error) {
+	return c.ExecuteBatch(ctx, []Call{{To: to, Data: data}}, operationName, metadata)
+}
+
+// ExecuteBatch 通过无gas中继在一笔交易中原子地执行多个调用，并等待交易上链
+// 交易是否执行成功由返回收据的 Status 表示；需要交易哈希等中间状态时使用 SubmitBatch
+// Poly 代理钱包通过 ProxyWalletFactory.proxy 执行多个调用；Safe 钱包通过 delegatecall MultiSend 执行
+func (c *PolymarketGaslessWeb3Client) ExecuteBatch(ctx context.Context, calls []Call, operationName string, metadata string) (*TransactionReceipt, error) {
+	handle, err := c.SubmitBatch(ctx, calls, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	// 等待确认
+	receipt, err := handle.Wait(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operationName, err)
+	}
+	return receipt, nil
+}
+
+// Submit 构建、签名并提交中继交易，不等待结果
+// 返回的 RelayerTxHandle 可以稍后查询状态（Status）或等待完成（Wait）
+func (c *PolymarketGaslessWeb3Client) Submit(ctx context.Context, to common.Address, data []byte, metadata string) (*RelayerTxHandle, error) {
+	return c.SubmitBatch(ctx, []Call{{To: to, Data: data}}, metadata)
+}
+
+// SubmitBatch 将多个调用作为一笔中继交易提交，不等待结果
+func (c *PolymarketGaslessWeb3Client) SubmitBatch(ctx context.Context, calls []Call, metadata string) (*RelayerTxHandle, error) {
+	if len(calls) == 0 {
+		return nil, fmt.Errorf("no calls to submit")
+	}
+
 	var body *RelaySubmitRequest
 	var err error
 
 	switch c.signatureType {
 	case SignatureTypePolyProxy:
-		body, err = c.buildProxyRelayTransaction(to, data, metadata)
+		body, err = c.buildProxyRelayTransaction(ctx, calls, metadata)
 	case SignatureTypeSafe:
-		body, err = c.buildSafeRelayTransaction(to, data, metadata)
+		body, err = c.buildSafeRelayTransaction(ctx, calls, metadata)
 	default:
 		return nil, fmt.Errorf("invalid signature_type: %d", c.signatureType)
 	}
@@ -78,45 +126,48 @@ func (c *PolymarketGaslessWeb3Client) Execute(to common.Address, data []byte, op
 	}
 
 	// 获取headers
-	headers, err := c.getRelayHeaders(body)
+	headers, err := c.getRelayHeaders(ctx, body)
 	if err != nil {
 		return nil, err
 	}
 
 	// 提交到中继
-	resp, err := c.submitToRelay(body, headers)
+	resp, err := c.submitToRelay(ctx, body, headers)
 	if err != nil {
 		return nil, err
 	}
+	if resp.TransactionID == "" && resp.TransactionHash == "" {
+		return nil, fmt.Errorf("no transaction ID or hash in relay response: %+v", resp)
+	}
 
-	fmt.Printf("Gasless txn submitted: %s\n", resp.TransactionHash)
-	fmt.Printf("Transaction ID: %s\n", resp.TransactionID)
-	fmt.Printf("State: %s\n", resp.State)
-
-	// 等待确认
-	if resp.TransactionHash != "" {
-		receipt, err := c.waitForReceipt(common.HexToHash(resp.TransactionHash))
-		if err != nil {
-			return nil, err
-		}
-
-		if receipt.Status == 1 {
-			fmt.Printf("%s succeeded\n", operationName)
-		} else {
-			fmt.Printf("%s failed\n", operationName)
-		}
+	return &RelayerTxHandle{
+		client:          c,
+		TransactionID:   resp.TransactionID,
+		TransactionHash: resp.TransactionHash,
+		State:           RelayerState(resp.State),
+	}, nil
+}
 
-		return receipt, nil
-	}
+// SetRelayConfig 设置中继器配置（如使用自建或本地的中继器）
+func (c *PolymarketGaslessWeb3Client) SetRelayConfig(cfg RelayConfig) {
+	c.relayConfig = cfg
+}
 
-	return nil, fmt.Errorf("no transaction hash in response: %+v", resp)
+// SetHTTPClient 设置访问中继器和签名服务使用的 HTTP 客户端
+func (c *PolymarketGaslessWeb3Client) SetHTTPClient(client *http.Client) {
+	c.httpClient = client
 }
 
 // getRelayNonce 获取中继nonce
-func (c *PolymarketGaslessWeb3Client) getRelayNonce(walletType string) (int, error) {
+func (c *PolymarketGaslessWeb3Client) getRelayNonce(ctx context.Context, walletType string) (int, error) {
 	url := fmt.Sprintf("%s/nonce?address=%s&type=%s", c.relayConfig.RelayURL, c.GetBaseAddress().Hex(), walletType)
 
-	resp, err := c.httpClient.Get(url)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get nonce: %w", err)
 	}
@@ -124,7 +175,7 @@ func (c *PolymarketGaslessWeb3Client) getRelayNonce(walletType string) (int, err
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return 0, fmt.Errorf("failed to get nonce: %s", string(body))
+		return 0, polymarket.NewAPIError("GET", "/nonce", resp.StatusCode, body)
 	}
 
 	var result struct {
@@ -143,8 +194,8 @@ func (c *PolymarketGaslessWeb3Client) getRelayNonce(walletType string) (int, err
 }
 
 // buildProxyRelayTransaction 构建Proxy中继交易
-func (c *PolymarketGaslessWeb3Client) buildProxyRelayTransaction(to common.Address, data []byte, metadata string) (*RelaySubmitRequest, error) {
-	proxyNonce, err := c.getRelayNonce("PROXY")
+func (c *PolymarketGaslessWeb3Client) buildProxyRelayTransaction(ctx context.Context, calls []Call, metadata string) (*RelaySubmitRequest, error) {
+	proxyNonce, err := c.getRelayNonce(ctx, "PROXY")
 	if err != nil {
 		return nil, err
 	}
@@ -153,15 +204,7 @@ func (c *PolymarketGaslessWeb3Client) buildProxyRelayTransaction(to common.Addre
 	relayerFee := "0"
 
 	// 编码代理交易 - 使用正确的切片类型
-	calls := []ProxyCall{
-		{
-			TypeCode: 1,
-			To:       to,
-			Value:    big.NewInt(0),
-			Data:     data,
-		},
-	}
-	proxyData, err := ProxyFactoryABI.Pack("proxy", calls)
+	proxyData, err := ProxyFactoryABI.Pack("proxy", proxyCalls(calls))
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode proxy transaction: %w", err)
 	}
@@ -169,7 +212,7 @@ func (c *PolymarketGaslessWeb3Client) buildProxyRelayTransaction(to common.Addre
 
 	// 估算gas
 	var gasLimit string
-	gas, err := c.client.EstimateGas(context.Background(), ethereum.CallMsg{
+	gas, err := c.client.EstimateGas(ctx, ethereum.CallMsg{
 		From: c.GetBaseAddress(),
 		To:   &c.ProxyFactoryAddress,
 		Data: proxyData,
@@ -196,8 +239,7 @@ func (c *PolymarketGaslessWeb3Client) buildProxyRelayTransaction(to common.Addre
 	structHash := Keccak256Hash(structBytes)
 
 	// 签名（eth_sign风格）
-	prefixedHash := crypto.Keccak256(append([]byte("\x19Ethereum Signed Message:\n32"), common.FromHex(structHash)...))
-	sig, err := crypto.Sign(prefixedHash, c.privateKey)
+	sig, err := c.signMessageHash(ctx, common.FromHex(structHash))
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign: %w", err)
 	}
@@ -207,7 +249,7 @@ func (c *PolymarketGaslessWeb3Client) buildProxyRelayTransaction(to common.Addre
 	}
 	signature := "0x" + common.Bytes2Hex(sig)
 
-	proxyWalletAddr, err := c.GetPolyProxyAddress(c.account)
+	proxyWalletAddr, err := c.GetPolyProxyAddress(ctx, c.account)
 	if err != nil {
 		return nil, err
 	}
@@ -232,21 +274,28 @@ func (c *PolymarketGaslessWeb3Client) buildProxyRelayTransaction(to common.Addre
 }
 
 // buildSafeRelayTransaction 构建Safe中继交易
-func (c *PolymarketGaslessWeb3Client) buildSafeRelayTransaction(to common.Address, data []byte, metadata string) (*RelaySubmitRequest, error) {
-	safeNonce, err := c.getRelayNonce("SAFE")
+func (c *PolymarketGaslessWeb3Client) buildSafeRelayTransaction(ctx context.Context, calls []Call, metadata string) (*RelaySubmitRequest, error) {
+	call, err := c.safeCallFor(calls)
+	if err != nil {
+		return nil, err
+	}
+	if call.Value.Sign() != 0 {
+		return nil, fmt.Errorf("relayer does not support sending value from a Safe, use a batch or PolymarketWeb3Client")
+	}
+
+	safeNonce, err := c.getRelayNonce(ctx, "SAFE")
 	if err != nil {
 		return nil, err
 	}
 
 	// 获取Safe交易哈希
-	txHash, err := c.getSafeTransactionHash(to, data, big.NewInt(int64(safeNonce)))
+	txHash, err := c.getSafeTransactionHash(ctx, call, big.NewInt(int64(safeNonce)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get safe transaction hash: %w", err)
 	}
 
 	// 签名
-	prefixedHash := crypto.Keccak256(append([]byte("\x19Ethereum Signed Message:\n32"), txHash...))
-	sig, err := crypto.Sign(prefixedHash, c.privateKey)
+	sig, err := c.signMessageHash(ctx, txHash)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign: %w", err)
 	}
@@ -260,13 +309,13 @@ func (c *PolymarketGaslessWeb3Client) buildSafeRelayTransaction(to common.Addres
 	}
 	signature := "0x" + common.Bytes2Hex(sig)
 
-	safeProxyAddr, err := c.GetSafeProxyAddress(c.account)
+	safeProxyAddr, err := c.GetSafeProxyAddress(ctx, c.account)
 	if err != nil {
 		return nil, err
 	}
 
 	return &RelaySubmitRequest{
-		Data:        "0x" + common.Bytes2Hex(data),
+		Data:        "0x" + common.Bytes2Hex(call.Data),
 		From:        c.GetBaseAddress().Hex(),
 		Metadata:    metadata,
 		Nonce:       strconv.Itoa(safeNonce),
@@ -276,22 +325,22 @@ func (c *PolymarketGaslessWeb3Client) buildSafeRelayTransaction(to common.Addres
 			"baseGas":        "0",
 			"gasPrice":       "0",
 			"gasToken":       AddressZero.Hex(),
-			"operation":      "0",
+			"operation":      strconv.Itoa(int(call.Operation)),
 			"refundReceiver": AddressZero.Hex(),
 			"safeTxnGas":     "0",
 		},
-		To:   to.Hex(),
+		To:   call.To.Hex(),
 		Type: "SAFE",
 	}, nil
 }
 
 // getSafeTransactionHash 获取Safe交易哈希
-func (c *PolymarketGaslessWeb3Client) getSafeTransactionHash(to common.Address, data []byte, nonce *big.Int) ([]byte, error) {
+func (c *PolymarketGaslessWeb3Client) getSafeTransactionHash(ctx context.Context, call safeCall, nonce *big.Int) ([]byte, error) {
 	txHashData, err := SafeABI.Pack("getTransactionHash",
-		to,
-		big.NewInt(0),
-		data,
-		uint8(0),
+		call.To,
+		call.Value,
+		call.Data,
+		call.Operation,
 		big.NewInt(0),
 		big.NewInt(0),
 		big.NewInt(0),
@@ -303,7 +352,7 @@ func (c *PolymarketGaslessWeb3Client) getSafeTransactionHash(to common.Address,
 		return nil, err
 	}
 
-	result, err := c.client.CallContract(context.Background(), ethereum.CallMsg{
+	result, err := c.client.CallContract(ctx, ethereum.CallMsg{
 		To:   &c.Address,
 		Data: txHashData,
 	}, nil)
@@ -320,7 +369,7 @@ func (c *PolymarketGaslessWeb3Client) getSafeTransactionHash(to common.Address,
 }
 
 // getRelayHeaders 获取中继请求headers
-func (c *PolymarketGaslessWeb3Client) getRelayHeaders(body *RelaySubmitRequest) (map[string]string, error) {
+func (c *PolymarketGaslessWeb3Client) getRelayHeaders(ctx context.Context, body *RelaySubmitRequest) (map[string]string, error) {
 	bodyJSON, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
@@ -339,7 +388,13 @@ func (c *PolymarketGaslessWeb3Client) getRelayHeaders(body *RelaySubmitRequest)
 			return nil, err
 		}
 
-		resp, err := c.httpClient.Post(c.relayConfig.SignURL, "application/json", bytes.NewReader(payloadJSON))
+		req, err := http.NewRequestWithContext(ctx, "POST", c.relayConfig.SignURL, bytes.NewReader(payloadJSON))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.httpClient.Do(req)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get headers from sign server: %w", err)
 		}
@@ -347,7 +402,7 @@ func (c *PolymarketGaslessWeb3Client) getRelayHeaders(body *RelaySubmitRequest)
 
 		if resp.StatusCode != http.StatusOK {
 			respBody, _ := io.ReadAll(resp.Body)
-			return nil, fmt.Errorf("sign server error: %s", string(respBody))
+			return nil, polymarket.NewAPIError("POST", c.relayConfig.SignURL, resp.StatusCode, respBody)
 		}
 
 		var headers map[string]string
@@ -374,14 +429,14 @@ func (c *PolymarketGaslessWeb3Client) getRelayHeaders(body *RelaySubmitRequest)
 }
 
 // submitToRelay 提交到中继
-func (c *PolymarketGaslessWeb3Client) submitToRelay(body *RelaySubmitRequest, headers map[string]string) (*RelayResponse, error) {
+func (c *PolymarketGaslessWeb3Client) submitToRelay(ctx context.Context, body *RelaySubmitRequest, headers map[string]string) (*RelayResponse, error) {
 	bodyJSON, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 
 	url := fmt.Sprintf("%s/submit", c.relayConfig.RelayURL)
-	req, err := http.NewRequest("POST", url, bytes.NewReader(bodyJSON))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(bodyJSON))
 	if err != nil {
 		return nil, err
 	}
@@ -399,7 +454,7 @@ func (c *PolymarketGaslessWeb3Client) submitToRelay(body *RelaySubmitRequest, he
 
 	if resp.StatusCode != http.StatusOK {
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("relay error: %s", string(respBody))
+		return nil, polymarket.NewAPIError("POST", "/submit", resp.StatusCode, respBody)
 	}
 
 	var result RelayResponse
@@ -411,18 +466,16 @@ func (c *PolymarketGaslessWeb3Client) submitToRelay(body *RelaySubmitRequest, he
 }
 
 // waitForReceipt 等待交易回执
-func (c *PolymarketGaslessWeb3Client) waitForReceipt(txHash common.Hash) (*TransactionReceipt, error) {
-	for {
-		receipt, err := c.client.TransactionReceipt(context.Background(), txHash)
-		if err == nil {
-			return FromEthReceipt(receipt, c.account), nil
-		}
-		// 继续等待...
+func (c *PolymarketGaslessWeb3Client) waitForReceipt(ctx context.Context, txHash common.Hash) (*TransactionReceipt, error) {
+	receipt, err := c.WaitForReceipt(ctx, txHash)
+	if err != nil {
+		return nil, err
 	}
+	return FromEthReceipt(receipt, c.account), nil
 }
 
 // SplitPosition 分割USDC为两个互补头寸
-func (c *PolymarketGaslessWeb3Client) SplitPosition(conditionID common.Hash, amount float64, negRisk bool) (*TransactionReceipt, error) {
+func (c *PolymarketGaslessWeb3Client) SplitPosition(ctx context.Context, conditionID common.Hash, amount float64, negRisk bool) (*TransactionReceipt, error) {
 	amountInt := ToWei(amount, 6)
 
 	var to common.Address
@@ -440,11 +493,11 @@ func (c *PolymarketGaslessWeb3Client) SplitPosition(conditionID common.Hash, amo
 		return nil, err
 	}
 
-	return c.Execute(to, data, "Split Position", "split")
+	return c.Execute(ctx, to, data, "Split Position", "split")
 }
 
 // MergePosition 合并两个互补头寸为USDC
-func (c *PolymarketGaslessWeb3Client) MergePosition(conditionID common.Hash, amount float64, negRisk bool) (*TransactionReceipt, error) {
+func (c *PolymarketGaslessWeb3Client) MergePosition(ctx context.Context, conditionID common.Hash, amount float64, negRisk bool) (*TransactionReceipt, error) {
 	amountInt := ToWei(amount, 6)
 
 	var to common.Address
@@ -462,11 +515,11 @@ func (c *PolymarketGaslessWeb3Client) MergePosition(conditionID common.Hash, amo
 		return nil, err
 	}
 
-	return c.Execute(to, data, "Merge Position", "merge")
+	return c.Execute(ctx, to, data, "Merge Position", "merge")
 }
 
 // RedeemPosition 赎回头寸为USDC
-func (c *PolymarketGaslessWeb3Client) RedeemPosition(conditionID common.Hash, amounts []float64, negRisk bool) (*TransactionReceipt, error) {
+func (c *PolymarketGaslessWeb3Client) RedeemPosition(ctx context.Context, conditionID common.Hash, amounts []float64, negRisk bool) (*TransactionReceipt, error) {
 	var to common.Address
 	var data []byte
 	var err error
@@ -486,11 +539,23 @@ func (c *PolymarketGaslessWeb3Client) RedeemPosition(conditionID common.Hash, am
 		return nil, err
 	}
 
-	return c.Execute(to, data, "Redeem Position", "redeem")
+	return c.Execute(ctx, to, data, "Redeem Position", "redeem")
+}
+
+// RedeemAll 发现钱包的全部头寸，通过中继器批量赎回已结算的市场，返回每个市场赎回的USDC
+// neg risk 市场通过 NegRiskAdapter 赎回，钱包需要已授权 NegRiskAdapter 转移条件代币
+func (c *PolymarketGaslessWeb3Client) RedeemAll(ctx context.Context, opts *RedeemAllOptions) (*RedeemReport, error) {
+	batchSize := 0
+	if opts != nil {
+		batchSize = opts.BatchSize
+	}
+	return c.redeemAll(ctx, opts, batchSize, func(ctx context.Context, calls []Call) (*TransactionReceipt, error) {
+		return c.ExecuteBatch(ctx, calls, "Redeem All", "redeem")
+	})
 }
 
 // ConvertPositions 转换NegRisk No头寸为Yes头寸和USDC
-func (c *PolymarketGaslessWeb3Client) ConvertPositions(questionIDs []string, amount float64) (*TransactionReceipt, error) {
+func (c *PolymarketGaslessWeb3Client) ConvertPositions(ctx context.Context, questionIDs []string, amount float64) (*TransactionReceipt, error) {
 	amountInt := ToWei(amount, 6)
 	negRiskMarketID := common.HexToHash(questionIDs[0][:len(questionIDs[0])-2] + "00")
 	indexSet := big.NewInt(int64(GetIndexSet(questionIDs)))
@@ -501,6 +566,6 @@ func (c *PolymarketGaslessWeb3Client) ConvertPositions(questionIDs []string, amo
 		return nil, err
 	}
 
-	return c.Execute(to, data, "Convert Positions", "convert")
+	return c.Execute(ctx, to, data, "Convert Positions", "convert")
 }
 
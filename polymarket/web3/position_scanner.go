@@ -0,0 +1,487 @@
+package web3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DefaultPositionScanChunkSize 每次 FilterLogs 查询的默认区块数
+const DefaultPositionScanChunkSize uint64 = 5000
+
+// DefaultPositionScanConfirmations 默认停在最新区块之前的区块数
+// 检查点不会再回头扫描，只扫描已确认的区块可以避免重组后检查点中留下被撤销的转账
+const DefaultPositionScanConfirmations = 64
+
+// PositionScanConfig 链上头寸扫描配置
+type PositionScanConfig struct {
+	FromBlock     uint64                                 // 起始区块，建议设置为钱包第一次使用的区块，默认0
+	ToBlock       uint64                                 // 结束区块，默认每次扫描时的最新区块减去 Confirmations
+	Confirmations int                                    // 未设置 ToBlock 时停在最新区块之前的区块数，默认 DefaultPositionScanConfirmations；为负数时扫描到最新区块
+	ChunkSize     uint64                                 // 每次 FilterLogs 查询的区块数，默认 DefaultPositionScanChunkSize；节点拒绝查询时自动减半
+	Checkpoint    *PositionScanCheckpoint                // 从检查点恢复扫描（可选）
+	OnCheckpoint  func(cp *PositionScanCheckpoint) error // 每段区块扫描完成后调用，可用于保存进度；返回错误时停止扫描
+}
+
+// withDefaults 返回填充默认值后的配置
+func (cfg PositionScanConfig) withDefaults() PositionScanConfig {
+	if cfg.ChunkSize == 0 {
+		cfg.ChunkSize = DefaultPositionScanChunkSize
+	}
+	if cfg.Confirmations == 0 {
+		cfg.Confirmations = DefaultPositionScanConfirmations
+	}
+	return cfg
+}
+
+// ScannedToken token ID 对应的条件和结果
+type ScannedToken struct {
+	ConditionID  common.Hash `json:"conditionId"`
+	OutcomeIndex int         `json:"outcomeIndex"`
+	NegRisk      bool        `json:"negRisk"`
+}
+
+// PositionScanCheckpoint 扫描进度，可序列化为 JSON 保存，之后从 NextBlock 继续扫描
+type PositionScanCheckpoint struct {
+	Owner      common.Address          `json:"owner"`
+	NextBlock  uint64                  `json:"nextBlock"`  // 下一个待扫描的区块
+	Balances   map[string]*big.Int     `json:"balances"`   // 按 Transfer 事件累计的持仓，按 token ID 索引
+	Conditions []common.Hash           `json:"conditions"` // 拆分/合并/赎回事件涉及的条件
+	Tokens     map[string]ScannedToken `json:"tokens"`     // 已解析的 token ID
+}
+
+// newPositionScanCheckpoint 创建空的检查点
+func newPositionScanCheckpoint(owner common.Address, fromBlock uint64) *PositionScanCheckpoint {
+	return &PositionScanCheckpoint{
+		Owner:     owner,
+		NextBlock: fromBlock,
+		Balances:  make(map[string]*big.Int),
+		Tokens:    make(map[string]ScannedToken),
+	}
+}
+
+// SavePositionScanCheckpoint 将检查点保存到文件（先写临时文件再重命名）
+func SavePositionScanCheckpoint(path string, cp *PositionScanCheckpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// LoadPositionScanCheckpoint 从文件读取检查点，文件不存在时返回的错误匹配 os.ErrNotExist
+func LoadPositionScanCheckpoint(path string) (*PositionScanCheckpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	var cp PositionScanCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint: %w", err)
+	}
+	if cp.Balances == nil {
+		cp.Balances = make(map[string]*big.Int)
+	}
+	if cp.Tokens == nil {
+		cp.Tokens = make(map[string]ScannedToken)
+	}
+	return &cp, nil
+}
+
+// PositionScanResult 扫描结果
+type PositionScanResult struct {
+	Positions  []Position              // 链上余额大于0且已解析条件的头寸（Balance 为 balanceOf 读取的余额）
+	Unresolved []*big.Int              // 链上余额大于0但无法确定所属条件的 token ID
+	Checkpoint *PositionScanCheckpoint // 扫描结束时的检查点
+}
+
+// PositionScanner 通过 ConditionalTokens 事件发现钱包持有的头寸
+// 扫描 TransferSingle/TransferBatch 累计每个 token ID 的持仓，扫描 PositionSplit、PositionsMerge、
+// PayoutRedemption（ConditionalTokens 和 NegRiskAdapter）记录涉及的条件，最后用 balanceOfBatch 校验余额。
+// 实现 PositionSource，可以作为 RedeemAllOptions.Source 使用
+type PositionScanner struct {
+	client *BaseWeb3Client
+	cfg    PositionScanConfig
+
+	mu         sync.Mutex
+	checkpoint *PositionScanCheckpoint
+	wcol       common.Address
+}
+
+// NewPositionScanner 创建链上头寸扫描器
+func (c *BaseWeb3Client) NewPositionScanner(cfg PositionScanConfig) *PositionScanner {
+	return &PositionScanner{
+		client:     c,
+		cfg:        cfg.withDefaults(),
+		checkpoint: cfg.Checkpoint,
+	}
+}
+
+// Checkpoint 返回当前检查点（未扫描时为 nil）
+func (s *PositionScanner) Checkpoint() *PositionScanCheckpoint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checkpoint
+}
+
+// Positions 实现 PositionSource，返回 owner 当前持有且已解析条件的头寸
+func (s *PositionScanner) Positions(ctx context.Context, owner common.Address) ([]Position, error) {
+	result, err := s.Scan(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	return result.Positions, nil
+}
+
+// Scan 从检查点（或 FromBlock）扫描到 ToBlock，返回 owner 当前的头寸
+// 检查点属于其他地址时重新从 FromBlock 开始扫描
+func (s *PositionScanner) Scan(ctx context.Context, owner common.Address) (*PositionScanResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.checkpoint == nil || s.checkpoint.Owner != owner {
+		s.checkpoint = newPositionScanCheckpoint(owner, s.cfg.FromBlock)
+	}
+	cp := s.checkpoint
+
+	toBlock, confirmed := s.cfg.ToBlock, true
+	if toBlock == 0 {
+		head, err := s.client.client.BlockNumber(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block number: %w", err)
+		}
+		toBlock = head
+		if n := s.cfg.Confirmations; n > 0 {
+			// 链还不够长时没有已确认的区块
+			confirmed = head >= uint64(n)
+			if confirmed {
+				toBlock = head - uint64(n)
+			}
+		}
+	}
+	if confirmed {
+		if err := s.scanLogs(ctx, cp, toBlock); err != nil {
+			return nil, err
+		}
+	}
+
+	// 用链上余额校验按事件累计的持仓（起始区块晚于钱包第一次使用时，事件累计值不完整）
+	tokenIDs := make([]*big.Int, 0, len(cp.Balances))
+	for id := range cp.Balances {
+		tokenID, ok := new(big.Int).SetString(id, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid token ID %q in checkpoint", id)
+		}
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+	sort.Slice(tokenIDs, func(i, j int) bool { return tokenIDs[i].Cmp(tokenIDs[j]) < 0 })
+	balances, err := s.client.balancesOf(ctx, owner, tokenIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var held []*big.Int
+	for _, tokenID := range tokenIDs {
+		if balances[tokenID.String()].Sign() > 0 {
+			held = append(held, tokenID)
+		}
+	}
+	if err := s.resolveTokens(ctx, cp, held); err != nil {
+		return nil, err
+	}
+
+	result := &PositionScanResult{Checkpoint: cp}
+	for _, tokenID := range held {
+		token, ok := cp.Tokens[tokenID.String()]
+		if !ok {
+			result.Unresolved = append(result.Unresolved, tokenID)
+			continue
+		}
+		result.Positions = append(result.Positions, Position{
+			ConditionID:  token.ConditionID,
+			TokenID:      tokenID,
+			OutcomeIndex: token.OutcomeIndex,
+			NegRisk:      token.NegRisk,
+			Balance:      balances[tokenID.String()],
+		})
+	}
+	return result, nil
+}
+
+// scanLogs 按区块段扫描事件直到 toBlock，每段完成后更新检查点
+func (s *PositionScanner) scanLogs(ctx context.Context, cp *PositionScanCheckpoint, toBlock uint64) error {
+	chunk := s.cfg.ChunkSize
+	for cp.NextBlock <= toBlock {
+		from := cp.NextBlock
+		to := min(from+chunk-1, toBlock)
+
+		logs, err := s.filterLogs(ctx, cp.Owner, from, to)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if to > from {
+				// 节点通常按区块范围或结果数量限制 eth_getLogs，缩小区块段重试
+				chunk = max((to-from+1)/2, 1)
+				continue
+			}
+			return fmt.Errorf("failed to filter logs at block %d: %w", from, err)
+		}
+
+		for _, log := range logs {
+			s.applyLog(cp, log)
+		}
+		cp.NextBlock = to + 1
+		if s.cfg.OnCheckpoint != nil {
+			if err := s.cfg.OnCheckpoint(cp); err != nil {
+				return fmt.Errorf("checkpoint: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// filterLogs 查询区块段内与 owner 相关的事件：转入、转出，以及拆分/合并/赎回
+func (s *PositionScanner) filterLogs(ctx context.Context, owner common.Address, from, to uint64) ([]types.Log, error) {
+	c := s.client
+	ownerTopic := common.BytesToHash(owner.Bytes())
+	transfers := []common.Hash{
+		ConditionalTokensABI.Events["TransferSingle"].ID,
+		ConditionalTokensABI.Events["TransferBatch"].ID,
+	}
+	var activity []common.Hash
+	for _, name := range []string{"PositionSplit", "PositionsMerge", "PayoutRedemption"} {
+		activity = append(activity, ConditionalTokensABI.Events[name].ID, NegRiskAdapterABI.Events[name].ID)
+	}
+
+	queries := []ethereum.FilterQuery{
+		{Addresses: []common.Address{c.ConditionalTokensAddress}, Topics: [][]common.Hash{transfers, nil, {ownerTopic}}},
+		{Addresses: []common.Address{c.ConditionalTokensAddress}, Topics: [][]common.Hash{transfers, nil, nil, {ownerTopic}}},
+		{Addresses: []common.Address{c.ConditionalTokensAddress, c.NegRiskAdapterAddress}, Topics: [][]common.Hash{activity, {ownerTopic}}},
+	}
+
+	// 转给自己的 Transfer 会同时出现在前两个查询中，按交易哈希和日志索引去重
+	type logKey struct {
+		tx    common.Hash
+		index uint
+	}
+	seen := make(map[logKey]bool)
+	var logs []types.Log
+	for _, q := range queries {
+		q.FromBlock = new(big.Int).SetUint64(from)
+		q.ToBlock = new(big.Int).SetUint64(to)
+		result, err := c.client.FilterLogs(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		for _, log := range result {
+			key := logKey{log.TxHash, log.Index}
+			if log.Removed || seen[key] {
+				continue
+			}
+			seen[key] = true
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+// applyLog 将事件计入检查点：Transfer 更新持仓，拆分/合并/赎回记录条件
+func (s *PositionScanner) applyLog(cp *PositionScanCheckpoint, log types.Log) {
+	if len(log.Topics) == 0 {
+		return
+	}
+	ownerTopic := common.BytesToHash(cp.Owner.Bytes())
+
+	if log.Address == s.client.ConditionalTokensAddress && len(log.Topics) == 4 {
+		var ids, values []*big.Int
+		switch log.Topics[0] {
+		case ConditionalTokensABI.Events["TransferSingle"].ID:
+			decoded, err := ConditionalTokensABI.Events["TransferSingle"].Inputs.NonIndexed().Unpack(log.Data)
+			if err != nil {
+				return
+			}
+			ids = []*big.Int{decoded[0].(*big.Int)}
+			values = []*big.Int{decoded[1].(*big.Int)}
+		case ConditionalTokensABI.Events["TransferBatch"].ID:
+			decoded, err := ConditionalTokensABI.Events["TransferBatch"].Inputs.NonIndexed().Unpack(log.Data)
+			if err != nil {
+				return
+			}
+			ids = decoded[0].([]*big.Int)
+			values = decoded[1].([]*big.Int)
+		}
+		if ids != nil {
+			for i := range ids {
+				if i >= len(values) {
+					break
+				}
+				key := ids[i].String()
+				balance := cp.Balances[key]
+				if balance == nil {
+					balance = new(big.Int)
+					cp.Balances[key] = balance
+				}
+				if log.Topics[3] == ownerTopic {
+					balance.Add(balance, values[i])
+				}
+				if log.Topics[2] == ownerTopic {
+					balance.Sub(balance, values[i])
+				}
+			}
+			return
+		}
+	}
+
+	for _, contractABI := range []abi.ABI{ConditionalTokensABI, NegRiskAdapterABI} {
+		event, err := contractABI.EventByID(log.Topics[0])
+		if err != nil {
+			continue
+		}
+		if conditionID, ok := eventConditionID(event, log); ok {
+			s.addCondition(cp, conditionID)
+			return
+		}
+	}
+}
+
+// addCondition 记录条件（去重）
+func (s *PositionScanner) addCondition(cp *PositionScanCheckpoint, conditionID common.Hash) {
+	for _, id := range cp.Conditions {
+		if id == conditionID {
+			return
+		}
+	}
+	cp.Conditions = append(cp.Conditions, conditionID)
+}
+
+// eventConditionID 从事件中读取 conditionId 参数（indexed 或非 indexed）
+func eventConditionID(event *abi.Event, log types.Log) (common.Hash, bool) {
+	fields := make(map[string]interface{})
+	if err := event.Inputs.UnpackIntoMap(fields, log.Data); err != nil {
+		return common.Hash{}, false
+	}
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if len(log.Topics) != len(indexed)+1 {
+		return common.Hash{}, false
+	}
+	if err := abi.ParseTopicsIntoMap(fields, indexed, log.Topics[1:]); err != nil {
+		return common.Hash{}, false
+	}
+	conditionID, ok := fields["conditionId"].([32]byte)
+	return common.Hash(conditionID), ok
+}
+
+// resolveTokens 确定持有的 token ID 所属的条件和结果
+// 先根据拆分/合并/赎回涉及的条件计算 position ID；仍未解析的 token 通过交易所的 getConditionId 查询
+func (s *PositionScanner) resolveTokens(ctx context.Context, cp *PositionScanCheckpoint, tokenIDs []*big.Int) error {
+	resolved := make(map[common.Hash]bool)
+	for _, token := range cp.Tokens {
+		resolved[token.ConditionID] = true
+	}
+	for _, conditionID := range cp.Conditions {
+		if resolved[conditionID] {
+			continue
+		}
+		if err := s.resolveCondition(ctx, cp, conditionID); err != nil {
+			return err
+		}
+		resolved[conditionID] = true
+	}
+
+	exchanges := []common.Address{s.client.ExchangeAddress}
+	if s.client.NegRiskExchangeAddress != s.client.ExchangeAddress {
+		exchanges = append(exchanges, s.client.NegRiskExchangeAddress)
+	}
+	for _, tokenID := range tokenIDs {
+		if _, ok := cp.Tokens[tokenID.String()]; ok {
+			continue
+		}
+		for _, exchange := range exchanges {
+			var conditionID [32]byte
+			if err := s.client.callView(ctx, CTFExchangeABI, exchange, &conditionID, "getConditionId", tokenID); err != nil {
+				return err
+			}
+			if conditionID == ([32]byte{}) {
+				continue
+			}
+			if !resolved[conditionID] {
+				if err := s.resolveCondition(ctx, cp, conditionID); err != nil {
+					return err
+				}
+				resolved[conditionID] = true
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// resolveCondition 计算条件每个结果的 position ID（USDC 和 neg risk 的 WrappedCollateral 两种抵押品），记录到检查点
+func (s *PositionScanner) resolveCondition(ctx context.Context, cp *PositionScanCheckpoint, conditionID common.Hash) error {
+	c := s.client
+	var slots *big.Int
+	if err := c.callConditionalTokens(ctx, &slots, "getOutcomeSlotCount", conditionID); err != nil {
+		return err
+	}
+	if slots.Sign() == 0 {
+		return nil
+	}
+
+	if s.wcol == (common.Address{}) {
+		if err := c.callView(ctx, NegRiskAdapterABI, c.NegRiskAdapterAddress, &s.wcol, "wcol"); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < int(slots.Int64()); i++ {
+		var collectionID [32]byte
+		indexSet := new(big.Int).Lsh(big.NewInt(1), uint(i))
+		if err := c.callConditionalTokens(ctx, &collectionID, "getCollectionId", HashZero, conditionID, indexSet); err != nil {
+			return err
+		}
+		for _, collateral := range []common.Address{c.USDCAddress, s.wcol} {
+			var positionID *big.Int
+			if err := c.callConditionalTokens(ctx, &positionID, "getPositionId", collateral, collectionID); err != nil {
+				return err
+			}
+			cp.Tokens[positionID.String()] = ScannedToken{
+				ConditionID:  conditionID,
+				OutcomeIndex: i,
+				NegRisk:      collateral == s.wcol,
+			}
+		}
+	}
+	return nil
+}
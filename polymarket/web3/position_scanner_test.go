@@ -0,0 +1,87 @@
+package web3
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// scanNode 只实现 eth_blockNumber 和 eth_getLogs 的节点，记录查询到的最大区块
+type scanNode struct {
+	head uint64
+
+	mu      sync.Mutex
+	queries int
+	maxTo   uint64
+}
+
+func (n *scanNode) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(n.head)
+}
+
+func (n *scanNode) GetLogs(query map[string]interface{}) ([]types.Log, error) {
+	to, err := hexutil.DecodeUint64(query["toBlock"].(string))
+	if err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.queries++
+	n.maxTo = max(n.maxTo, to)
+	return []types.Log{}, nil
+}
+
+func newScanTestClient(t *testing.T, node *scanNode) *BaseWeb3Client {
+	t.Helper()
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", node); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Stop)
+	eth := ethclient.NewClient(rpc.DialInProc(server))
+	t.Cleanup(eth.Close)
+	return &BaseWeb3Client{client: eth}
+}
+
+func TestPositionScannerConfirmations(t *testing.T) {
+	owner := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	tests := []struct {
+		name          string
+		head          uint64
+		cfg           PositionScanConfig
+		wantNextBlock uint64
+		wantQueries   bool
+	}{
+		{"default confirmations", 1000, PositionScanConfig{FromBlock: 900}, 1000 - DefaultPositionScanConfirmations + 1, true},
+		{"custom confirmations", 1000, PositionScanConfig{FromBlock: 900, Confirmations: 10}, 991, true},
+		{"scan to head", 1000, PositionScanConfig{FromBlock: 900, Confirmations: -1}, 1001, true},
+		{"explicit ToBlock", 1000, PositionScanConfig{FromBlock: 900, ToBlock: 999}, 1000, true},
+		{"chain shorter than confirmations", 5, PositionScanConfig{Confirmations: 10}, 0, false},
+		{"checkpoint ahead of confirmed head", 1000, PositionScanConfig{Checkpoint: &PositionScanCheckpoint{Owner: owner, NextBlock: 980}}, 980, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &scanNode{head: tt.head}
+			scanner := newScanTestClient(t, node).NewPositionScanner(tt.cfg)
+			result, err := scanner.Scan(context.Background(), owner)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Checkpoint.NextBlock != tt.wantNextBlock {
+				t.Errorf("NextBlock = %d, want %d", result.Checkpoint.NextBlock, tt.wantNextBlock)
+			}
+			if (node.queries > 0) != tt.wantQueries {
+				t.Errorf("getLogs queries = %d, want queries %v", node.queries, tt.wantQueries)
+			}
+			if tt.wantQueries && node.maxTo != tt.wantNextBlock-1 {
+				t.Errorf("max toBlock = %d, want %d", node.maxTo, tt.wantNextBlock-1)
+			}
+		})
+	}
+}
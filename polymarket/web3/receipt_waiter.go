@@ -0,0 +1,193 @@
+package web3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// 等待回执的错误分类哨兵，配合 errors.Is 使用
+var (
+	ErrTxDropped      = errors.New("transaction dropped or not found")
+	ErrReceiptTimeout = errors.New("timed out waiting for receipt")
+)
+
+// ReceiptWaitError 等待回执失败
+// Dropped 为 true 时节点已不知道该交易（被交易池丢弃、被替换或从未广播），匹配 ErrTxDropped；
+// 否则交易在超时时仍处于待处理状态，匹配 ErrReceiptTimeout
+type ReceiptWaitError struct {
+	TxHash  common.Hash
+	Dropped bool
+	Waited  time.Duration
+	LastErr error // 最后一次 RPC 错误（如果有）
+}
+
+// Error 实现 error 接口
+func (e *ReceiptWaitError) Error() string {
+	msg := fmt.Sprintf("transaction %s still pending after %s", e.TxHash.Hex(), e.Waited.Round(time.Second))
+	if e.Dropped {
+		msg = fmt.Sprintf("transaction %s not found after %s (dropped?)", e.TxHash.Hex(), e.Waited.Round(time.Second))
+	}
+	if e.LastErr != nil {
+		msg += ": last error: " + e.LastErr.Error()
+	}
+	return msg
+}
+
+// Is 匹配 ErrTxDropped 或 ErrReceiptTimeout
+func (e *ReceiptWaitError) Is(target error) bool {
+	switch target {
+	case ErrTxDropped:
+		return e.Dropped
+	case ErrReceiptTimeout:
+		return !e.Dropped
+	}
+	return false
+}
+
+// ReceiptBackend 等待回执所需的节点接口（*ethclient.Client 满足该接口）
+type ReceiptBackend interface {
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	TransactionByHash(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// ReceiptWaitConfig 等待回执配置，零值字段使用默认值
+type ReceiptWaitConfig struct {
+	PollInterval  time.Duration // 轮询间隔，默认2秒
+	Timeout       time.Duration // 最长等待时间，默认5分钟；为负数时只受 ctx 限制
+	Confirmations uint64        // 确认区块数（包含交易所在区块），默认1
+	// DroppedAfter 节点持续不知道该交易超过该时长时提前返回 ErrTxDropped；
+	// 默认0表示只在超时时判断（中继提交的交易可能不经过当前节点的交易池）
+	DroppedAfter time.Duration
+}
+
+// DefaultReceiptWaitConfig 默认的等待回执配置
+var DefaultReceiptWaitConfig = ReceiptWaitConfig{
+	PollInterval:  2 * time.Second,
+	Timeout:       5 * time.Minute,
+	Confirmations: 1,
+}
+
+// ReceiptWaiter 轮询等待交易回执
+type ReceiptWaiter struct {
+	backend ReceiptBackend
+	cfg     ReceiptWaitConfig
+}
+
+// NewReceiptWaiter 创建回执等待器
+func NewReceiptWaiter(backend ReceiptBackend, cfg ReceiptWaitConfig) *ReceiptWaiter {
+	return &ReceiptWaiter{backend: backend, cfg: cfg.withDefaults()}
+}
+
+// withDefaults 为零值字段填充默认值
+func (cfg ReceiptWaitConfig) withDefaults() ReceiptWaitConfig {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultReceiptWaitConfig.PollInterval
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultReceiptWaitConfig.Timeout
+	}
+	if cfg.Confirmations == 0 {
+		cfg.Confirmations = DefaultReceiptWaitConfig.Confirmations
+	}
+	return cfg
+}
+
+// Wait 等待交易被打包并达到确认数
+// 回执已出现但确认数不足时继续轮询；期间发生重组导致回执消失时重新等待。
+// ctx 取消时返回 ctx.Err()；超时返回 *ReceiptWaitError
+func (w *ReceiptWaiter) Wait(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	start := time.Now()
+	var deadline time.Time
+	if w.cfg.Timeout > 0 {
+		deadline = start.Add(w.cfg.Timeout)
+	}
+
+	var lastErr error
+	var notFoundSince time.Time
+	for {
+		receipt, err := w.backend.TransactionReceipt(ctx, txHash)
+		switch {
+		case err == nil:
+			notFoundSince = time.Time{}
+			confirmed, err := w.confirmed(ctx, receipt)
+			if err != nil {
+				lastErr = err
+			} else if confirmed {
+				return receipt, nil
+			}
+		case ctx.Err() != nil:
+			return nil, ctx.Err()
+		case errors.Is(err, ethereum.NotFound):
+			if w.cfg.DroppedAfter > 0 {
+				dropped, err := w.dropped(ctx, txHash)
+				if err != nil {
+					lastErr = err
+				} else if !dropped {
+					notFoundSince = time.Time{}
+				} else if notFoundSince.IsZero() {
+					notFoundSince = time.Now()
+				} else if time.Since(notFoundSince) >= w.cfg.DroppedAfter {
+					return nil, &ReceiptWaitError{TxHash: txHash, Dropped: true, Waited: time.Since(start), LastErr: lastErr}
+				}
+			}
+		default:
+			// 节点临时错误，继续轮询
+			lastErr = err
+		}
+
+		wait := w.cfg.PollInterval
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return nil, w.timeoutError(ctx, txHash, start, lastErr)
+			}
+			if remaining < wait {
+				wait = remaining
+			}
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// confirmed 回执所在区块是否已达到确认数
+func (w *ReceiptWaiter) confirmed(ctx context.Context, receipt *types.Receipt) (bool, error) {
+	if w.cfg.Confirmations <= 1 || receipt.BlockNumber == nil {
+		return true, nil
+	}
+	head, err := w.backend.BlockNumber(ctx)
+	if err != nil {
+		return false, err
+	}
+	return head+1 >= receipt.BlockNumber.Uint64()+w.cfg.Confirmations, nil
+}
+
+// dropped 节点是否已不知道该交易（既没有回执，也不在交易池中）
+func (w *ReceiptWaiter) dropped(ctx context.Context, txHash common.Hash) (bool, error) {
+	_, _, err := w.backend.TransactionByHash(ctx, txHash)
+	if errors.Is(err, ethereum.NotFound) {
+		return true, nil
+	}
+	return false, err
+}
+
+// timeoutError 超时时区分交易仍在等待还是已被丢弃
+func (w *ReceiptWaiter) timeoutError(ctx context.Context, txHash common.Hash, start time.Time, lastErr error) error {
+	dropped, err := w.dropped(ctx, txHash)
+	if err != nil {
+		lastErr = err
+	}
+	return &ReceiptWaitError{TxHash: txHash, Dropped: dropped, Waited: time.Since(start), LastErr: lastErr}
+}
@@ -0,0 +1,249 @@
+package web3
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// fakeReceiptBackend 可编程的 ReceiptBackend
+// receipt 按调用次数返回结果；txKnown 表示交易是否仍在节点交易池中
+type fakeReceiptBackend struct {
+	mu           sync.Mutex
+	receipt      func(call int) (*types.Receipt, error)
+	txKnown      bool
+	txErr        error
+	head         func(call int) uint64
+	receiptCalls int
+	headCalls    int
+}
+
+func (b *fakeReceiptBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	b.mu.Lock()
+	b.receiptCalls++
+	call := b.receiptCalls
+	b.mu.Unlock()
+	return b.receipt(call)
+}
+
+func (b *fakeReceiptBackend) TransactionByHash(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
+	if b.txErr != nil {
+		return nil, false, b.txErr
+	}
+	if !b.txKnown {
+		return nil, false, ethereum.NotFound
+	}
+	return types.NewTx(&types.LegacyTx{}), true, nil
+}
+
+func (b *fakeReceiptBackend) BlockNumber(ctx context.Context) (uint64, error) {
+	b.mu.Lock()
+	b.headCalls++
+	call := b.headCalls
+	b.mu.Unlock()
+	return b.head(call), nil
+}
+
+func notFound(int) (*types.Receipt, error) { return nil, ethereum.NotFound }
+
+func receiptAt(block int64) *types.Receipt {
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(block)}
+}
+
+var testTxHash = common.HexToHash("0x01")
+
+func TestReceiptWaiterTimeoutPending(t *testing.T) {
+	backend := &fakeReceiptBackend{receipt: notFound, txKnown: true}
+	w := NewReceiptWaiter(backend, ReceiptWaitConfig{PollInterval: time.Millisecond, Timeout: 20 * time.Millisecond})
+
+	_, err := w.Wait(context.Background(), testTxHash)
+	if !errors.Is(err, ErrReceiptTimeout) || errors.Is(err, ErrTxDropped) {
+		t.Fatalf("Wait = %v, want ErrReceiptTimeout only", err)
+	}
+	if backend.receiptCalls < 2 {
+		t.Errorf("receipt polled %d times, want repeated polling", backend.receiptCalls)
+	}
+}
+
+func TestReceiptWaiterTimeoutDropped(t *testing.T) {
+	// DroppedAfter 为0时不提前返回，超时时再判断交易是否已被丢弃
+	backend := &fakeReceiptBackend{receipt: notFound}
+	w := NewReceiptWaiter(backend, ReceiptWaitConfig{PollInterval: time.Millisecond, Timeout: 20 * time.Millisecond})
+
+	start := time.Now()
+	_, err := w.Wait(context.Background(), testTxHash)
+	if !errors.Is(err, ErrTxDropped) || errors.Is(err, ErrReceiptTimeout) {
+		t.Fatalf("Wait = %v, want ErrTxDropped only", err)
+	}
+	if time.Since(start) < 20*time.Millisecond {
+		t.Errorf("Wait returned after %s, before the timeout", time.Since(start))
+	}
+}
+
+func TestReceiptWaiterDroppedAfter(t *testing.T) {
+	backend := &fakeReceiptBackend{receipt: notFound}
+	w := NewReceiptWaiter(backend, ReceiptWaitConfig{
+		PollInterval: time.Millisecond,
+		Timeout:      5 * time.Second,
+		DroppedAfter: 10 * time.Millisecond,
+	})
+
+	start := time.Now()
+	_, err := w.Wait(context.Background(), testTxHash)
+	var waitErr *ReceiptWaitError
+	if !errors.As(err, &waitErr) || !waitErr.Dropped {
+		t.Fatalf("Wait = %v, want dropped ReceiptWaitError", err)
+	}
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("Wait took %s, want early return after DroppedAfter", elapsed)
+	}
+}
+
+func TestReceiptWaiterDroppedAfterPendingTx(t *testing.T) {
+	// 交易仍在交易池中，不判定为丢弃
+	backend := &fakeReceiptBackend{receipt: notFound, txKnown: true}
+	w := NewReceiptWaiter(backend, ReceiptWaitConfig{
+		PollInterval: time.Millisecond,
+		Timeout:      30 * time.Millisecond,
+		DroppedAfter: 5 * time.Millisecond,
+	})
+	if _, err := w.Wait(context.Background(), testTxHash); !errors.Is(err, ErrReceiptTimeout) {
+		t.Fatalf("Wait = %v, want ErrReceiptTimeout", err)
+	}
+}
+
+func TestReceiptWaiterConfirmations(t *testing.T) {
+	// 交易在区块10，每次查询区块高度前进一个区块
+	backend := &fakeReceiptBackend{
+		receipt: func(int) (*types.Receipt, error) { return receiptAt(10), nil },
+		head:    func(call int) uint64 { return uint64(9 + call) },
+	}
+	w := NewReceiptWaiter(backend, ReceiptWaitConfig{PollInterval: time.Millisecond, Timeout: 5 * time.Second, Confirmations: 3})
+
+	receipt, err := w.Wait(context.Background(), testTxHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.BlockNumber.Int64() != 10 {
+		t.Errorf("receipt block = %d, want 10", receipt.BlockNumber.Int64())
+	}
+	// 区块10、11、12 共3个确认
+	if backend.headCalls != 3 {
+		t.Errorf("head queried %d times, want 3", backend.headCalls)
+	}
+}
+
+func TestReceiptWaiterReorg(t *testing.T) {
+	// 回执出现后因重组消失，再次出现在新区块
+	backend := &fakeReceiptBackend{
+		receipt: func(call int) (*types.Receipt, error) {
+			switch call {
+			case 1:
+				return receiptAt(10), nil
+			case 2:
+				return nil, ethereum.NotFound
+			}
+			return receiptAt(11), nil
+		},
+		txKnown: true,
+		head: func(call int) uint64 {
+			if call == 1 {
+				return 10
+			}
+			return 12
+		},
+	}
+	w := NewReceiptWaiter(backend, ReceiptWaitConfig{PollInterval: time.Millisecond, Timeout: 5 * time.Second, Confirmations: 2})
+
+	receipt, err := w.Wait(context.Background(), testTxHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.BlockNumber.Int64() != 11 {
+		t.Errorf("receipt block = %d, want 11 after reorg", receipt.BlockNumber.Int64())
+	}
+}
+
+func TestReceiptWaiterContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	backend := &fakeReceiptBackend{receipt: func(call int) (*types.Receipt, error) {
+		if call == 3 {
+			cancel()
+		}
+		return nil, ethereum.NotFound
+	}, txKnown: true}
+	w := NewReceiptWaiter(backend, ReceiptWaitConfig{PollInterval: time.Millisecond, Timeout: -1})
+
+	if _, err := w.Wait(ctx, testTxHash); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Wait = %v, want context.Canceled", err)
+	}
+	if backend.receiptCalls != 3 {
+		t.Errorf("receipt polled %d times after cancel, want 3", backend.receiptCalls)
+	}
+}
+
+func TestReceiptWaiterContextCancelDuringSleep(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	backend := &fakeReceiptBackend{receipt: notFound, txKnown: true}
+	w := NewReceiptWaiter(backend, ReceiptWaitConfig{PollInterval: time.Hour, Timeout: -1})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := w.Wait(ctx, testTxHash)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Wait = %v, want context.DeadlineExceeded", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Wait did not return after ctx deadline")
+	}
+}
+
+func TestReceiptWaiterRPCErrors(t *testing.T) {
+	boom := errors.New("connection reset")
+
+	t.Run("transient errors keep polling", func(t *testing.T) {
+		backend := &fakeReceiptBackend{receipt: func(call int) (*types.Receipt, error) {
+			if call < 4 {
+				return nil, boom
+			}
+			return receiptAt(10), nil
+		}}
+		w := NewReceiptWaiter(backend, ReceiptWaitConfig{PollInterval: time.Millisecond, Timeout: 5 * time.Second})
+		if _, err := w.Wait(context.Background(), testTxHash); err != nil {
+			t.Fatal(err)
+		}
+		if backend.receiptCalls != 4 {
+			t.Errorf("receipt polled %d times, want 4", backend.receiptCalls)
+		}
+	})
+
+	t.Run("errors are not treated as dropped", func(t *testing.T) {
+		// 只有 NotFound 才会触发丢弃判断，其他错误持续到超时
+		backend := &fakeReceiptBackend{receipt: func(int) (*types.Receipt, error) { return nil, boom }, txErr: boom}
+		w := NewReceiptWaiter(backend, ReceiptWaitConfig{
+			PollInterval: time.Millisecond,
+			Timeout:      20 * time.Millisecond,
+			DroppedAfter: time.Millisecond,
+		})
+		_, err := w.Wait(context.Background(), testTxHash)
+		var waitErr *ReceiptWaitError
+		if !errors.As(err, &waitErr) || waitErr.Dropped {
+			t.Fatalf("Wait = %v, want pending ReceiptWaitError", err)
+		}
+		if !errors.Is(waitErr.LastErr, boom) {
+			t.Errorf("LastErr = %v, want %v", waitErr.LastErr, boom)
+		}
+	})
+}
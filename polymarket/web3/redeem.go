@@ -0,0 +1,447 @@
+package web3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/0xNetuser/Polymarket-golang/polymarket"
+)
+
+// DefaultDataAPIURL Polymarket Data API 地址
+const DefaultDataAPIURL = "https://data-api.polymarket.com"
+
+// DefaultRedeemBatchSize RedeemAll 每笔交易默认包含的赎回调用数
+const DefaultRedeemBatchSize = 10
+
+// Position 钱包持有的条件代币头寸
+type Position struct {
+	ConditionID  common.Hash
+	TokenID      *big.Int // ERC1155 position ID
+	OutcomeIndex int      // 结果索引（二元市场 0=Yes，1=No）
+	NegRisk      bool     // 是否为 neg risk 市场（通过 NegRiskAdapter 赎回）
+	Balance      *big.Int // 持仓（6位小数，可选）
+	Title        string   // 市场标题（可选，用于报告）
+	Outcome      string   // 结果名称（可选）
+}
+
+// PositionSource 头寸来源，用于 RedeemAll 发现钱包持有的头寸
+type PositionSource interface {
+	Positions(ctx context.Context, owner common.Address) ([]Position, error)
+}
+
+// DataAPIPositionSource 通过 Data API 的 /positions 接口发现头寸
+type DataAPIPositionSource struct {
+	BaseURL        string       // 默认 DefaultDataAPIURL
+	HTTPClient     *http.Client // 默认30秒超时
+	RedeemableOnly bool         // 只返回可赎回的头寸（redeemable=true）
+}
+
+// NewDataAPIPositionSource 创建只返回可赎回头寸的 Data API 头寸来源
+func NewDataAPIPositionSource() *DataAPIPositionSource {
+	return &DataAPIPositionSource{
+		BaseURL:        DefaultDataAPIURL,
+		HTTPClient:     &http.Client{Timeout: 30 * time.Second},
+		RedeemableOnly: true,
+	}
+}
+
+// dataAPIPosition Data API 返回的头寸
+type dataAPIPosition struct {
+	Asset        string  `json:"asset"`
+	ConditionID  string  `json:"conditionId"`
+	Size         float64 `json:"size"`
+	OutcomeIndex int     `json:"outcomeIndex"`
+	NegativeRisk bool    `json:"negativeRisk"`
+	Title        string  `json:"title"`
+	Outcome      string  `json:"outcome"`
+}
+
+// Positions 分页获取 owner 的全部头寸
+func (s *DataAPIPositionSource) Positions(ctx context.Context, owner common.Address) ([]Position, error) {
+	baseURL := s.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultDataAPIURL
+	}
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	const limit = 500
+	var positions []Position
+	for offset := 0; ; offset += limit {
+		params := url.Values{}
+		params.Set("user", owner.Hex())
+		params.Set("sizeThreshold", "0")
+		params.Set("limit", strconv.Itoa(limit))
+		params.Set("offset", strconv.Itoa(offset))
+		if s.RedeemableOnly {
+			params.Set("redeemable", "true")
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", baseURL+"/positions?"+params.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get positions: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read positions: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, polymarket.NewAPIError("GET", "/positions", resp.StatusCode, body)
+		}
+
+		var page []dataAPIPosition
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to decode positions: %w", err)
+		}
+		for _, p := range page {
+			tokenID, ok := new(big.Int).SetString(p.Asset, 10)
+			if !ok {
+				return nil, fmt.Errorf("invalid position asset %q", p.Asset)
+			}
+			positions = append(positions, Position{
+				ConditionID:  common.HexToHash(p.ConditionID),
+				TokenID:      tokenID,
+				OutcomeIndex: p.OutcomeIndex,
+				NegRisk:      p.NegativeRisk,
+				Balance:      ToWei(p.Size, 6),
+				Title:        p.Title,
+				Outcome:      p.Outcome,
+			})
+		}
+		if len(page) < limit {
+			return positions, nil
+		}
+	}
+}
+
+// RedeemAllOptions RedeemAll 选项
+type RedeemAllOptions struct {
+	Source    PositionSource // 头寸来源，默认 NewDataAPIPositionSource()
+	BatchSize int            // 每笔交易的赎回调用数，默认 DefaultRedeemBatchSize（EOA 钱包每笔交易一个调用）
+	MinPayout *big.Int       // 预期赔付（USDC 基本单位，6位小数）低于该值的市场不赎回，默认跳过赔付为0的市场
+	DryRun    bool           // 只检查和计算，不发送交易
+}
+
+// RedeemResult 单个市场的赎回结果
+type RedeemResult struct {
+	ConditionID    common.Hash
+	NegRisk        bool
+	Title          string
+	Balances       []*big.Int  // 按结果索引的持仓（6位小数）
+	Payouts        []*big.Int  // 链上 payoutNumerators
+	ExpectedPayout *big.Int    // 按链上赔付比例计算的预期 USDC（6位小数）
+	Recovered      *big.Int    // 从交易日志 PayoutRedemption 读取的实际 USDC，未执行时为 nil
+	TxHash         common.Hash // 赎回交易哈希
+	Skipped        string      // 未赎回的原因（未结算、无赔付等）
+	Err            error       // 赎回交易失败的错误
+
+	call Call
+}
+
+// RecoveredUSDC 实际赎回的 USDC
+func (r *RedeemResult) RecoveredUSDC() float64 {
+	if r.Recovered == nil {
+		return 0
+	}
+	return FromWei(r.Recovered, 6)
+}
+
+// RedeemReport RedeemAll 的结果
+type RedeemReport struct {
+	Results        []*RedeemResult       // 每个市场一条（包括跳过和失败的市场）
+	Receipts       []*TransactionReceipt // 发送的赎回交易
+	TotalExpected  *big.Int              // 预期赎回的 USDC 合计（6位小数）
+	TotalRecovered *big.Int              // 实际赎回的 USDC 合计（6位小数）
+}
+
+// PlanRedeemAll 发现钱包的头寸并检查链上结算状态，返回每个市场的赎回计划（不发送交易）
+// 通过 payoutDenominator / payoutNumerators 判断条件是否已结算，通过 balanceOfBatch 读取精确持仓
+func (c *BaseWeb3Client) PlanRedeemAll(ctx context.Context, opts *RedeemAllOptions) ([]*RedeemResult, error) {
+	if opts == nil {
+		opts = &RedeemAllOptions{}
+	}
+	source := opts.Source
+	if source == nil {
+		source = NewDataAPIPositionSource()
+	}
+
+	positions, err := source.Positions(ctx, c.Address)
+	if err != nil {
+		return nil, fmt.Errorf("discover positions: %w", err)
+	}
+
+	// 按条件分组
+	var order []common.Hash
+	byCondition := make(map[common.Hash][]Position)
+	for _, p := range positions {
+		if _, ok := byCondition[p.ConditionID]; !ok {
+			order = append(order, p.ConditionID)
+		}
+		byCondition[p.ConditionID] = append(byCondition[p.ConditionID], p)
+	}
+
+	tokenIDs := make([]*big.Int, 0, len(positions))
+	for _, p := range positions {
+		tokenIDs = append(tokenIDs, p.TokenID)
+	}
+	balances, err := c.balancesOf(ctx, c.Address, tokenIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*RedeemResult, 0, len(order))
+	for _, conditionID := range order {
+		group := byCondition[conditionID]
+		result := &RedeemResult{ConditionID: conditionID, NegRisk: group[0].NegRisk, Title: group[0].Title}
+		results = append(results, result)
+
+		denominator, numerators, err := c.conditionPayouts(ctx, conditionID)
+		if err != nil {
+			return nil, err
+		}
+		result.Payouts = numerators
+		if denominator.Sign() == 0 {
+			result.Skipped = "condition not resolved"
+			continue
+		}
+
+		result.Balances = make([]*big.Int, len(numerators))
+		for i := range result.Balances {
+			result.Balances[i] = new(big.Int)
+		}
+		for _, p := range group {
+			if p.OutcomeIndex < 0 || p.OutcomeIndex >= len(numerators) {
+				return nil, fmt.Errorf("condition %s: invalid outcome index %d", conditionID.Hex(), p.OutcomeIndex)
+			}
+			result.Balances[p.OutcomeIndex].Add(result.Balances[p.OutcomeIndex], balances[p.TokenID.String()])
+		}
+
+		expected := new(big.Int)
+		for i, balance := range result.Balances {
+			expected.Add(expected, new(big.Int).Mul(balance, numerators[i]))
+		}
+		result.ExpectedPayout = expected.Div(expected, denominator)
+
+		minPayout := opts.MinPayout
+		if minPayout == nil {
+			minPayout = big.NewInt(1)
+		}
+		if result.ExpectedPayout.Cmp(minPayout) < 0 {
+			result.Skipped = "payout below minimum"
+			continue
+		}
+
+		result.call, err = c.redeemCall(conditionID, result.NegRisk, result.Balances)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// redeemAll 按批次执行赎回计划，exec 负责在一笔交易中执行一批调用
+func (c *BaseWeb3Client) redeemAll(ctx context.Context, opts *RedeemAllOptions, batchSize int, exec func(ctx context.Context, calls []Call) (*TransactionReceipt, error)) (*RedeemReport, error) {
+	if opts == nil {
+		opts = &RedeemAllOptions{}
+	}
+	results, err := c.PlanRedeemAll(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RedeemReport{Results: results, TotalExpected: new(big.Int), TotalRecovered: new(big.Int)}
+	var pending []*RedeemResult
+	for _, r := range results {
+		if r.Skipped == "" {
+			pending = append(pending, r)
+			report.TotalExpected.Add(report.TotalExpected, r.ExpectedPayout)
+		}
+	}
+	if opts.DryRun {
+		return report, nil
+	}
+
+	if batchSize <= 0 {
+		batchSize = DefaultRedeemBatchSize
+	}
+	for start := 0; start < len(pending); start += batchSize {
+		batch := pending[start:min(start+batchSize, len(pending))]
+		if err := ctx.Err(); err != nil {
+			for _, r := range pending[start:] {
+				r.Err = err
+			}
+			return report, err
+		}
+
+		calls := make([]Call, len(batch))
+		for i, r := range batch {
+			calls[i] = r.call
+		}
+		receipt, err := exec(ctx, calls)
+		if err == nil && receipt.Status != 1 {
+			err = fmt.Errorf("redeem transaction %s reverted", receipt.TxHash.Hex())
+		}
+		if receipt != nil {
+			report.Receipts = append(report.Receipts, receipt)
+		}
+		for _, r := range batch {
+			if receipt != nil {
+				r.TxHash = receipt.TxHash
+			}
+			if err != nil {
+				r.Err = err
+				continue
+			}
+			r.Recovered = c.redeemedAmount(receipt, r)
+			report.TotalRecovered.Add(report.TotalRecovered, r.Recovered)
+		}
+	}
+	return report, nil
+}
+
+// redeemCall 构建赎回调用：标准市场调用 ConditionalTokens，neg risk 市场通过 NegRiskAdapter 按持仓数量赎回
+func (c *BaseWeb3Client) redeemCall(conditionID common.Hash, negRisk bool, balances []*big.Int) (Call, error) {
+	if negRisk {
+		data, err := c.encodeRedeemNegRisk(conditionID, balances)
+		if err != nil {
+			return Call{}, err
+		}
+		return Call{To: c.NegRiskAdapterAddress, Data: data}, nil
+	}
+
+	indexSets := make([]*big.Int, len(balances))
+	for i := range balances {
+		indexSets[i] = new(big.Int).Lsh(big.NewInt(1), uint(i))
+	}
+	data, err := ConditionalTokensABI.Pack("redeemPositions", c.USDCAddress, HashZero, conditionID, indexSets)
+	if err != nil {
+		return Call{}, err
+	}
+	return Call{To: c.ConditionalTokensAddress, Data: data}, nil
+}
+
+// redeemedAmount 从回执的 PayoutRedemption 日志中读取该市场实际赎回的 USDC
+func (c *BaseWeb3Client) redeemedAmount(receipt *TransactionReceipt, r *RedeemResult) *big.Int {
+	ctEvent := ConditionalTokensABI.Events["PayoutRedemption"]
+	adapterEvent := NegRiskAdapterABI.Events["PayoutRedemption"]
+	redeemer := common.BytesToHash(c.Address.Bytes())
+
+	total := new(big.Int)
+	for _, log := range receipt.Logs {
+		if len(log.Topics) < 2 || log.Topics[1] != redeemer {
+			continue
+		}
+		switch {
+		case !r.NegRisk && log.Address == c.ConditionalTokensAddress && log.Topics[0] == ctEvent.ID:
+			values, err := ctEvent.Inputs.NonIndexed().Unpack(log.Data)
+			if err != nil || len(values) != 3 || common.Hash(values[0].([32]byte)) != r.ConditionID {
+				continue
+			}
+			total.Add(total, values[2].(*big.Int))
+		case r.NegRisk && log.Address == c.NegRiskAdapterAddress && log.Topics[0] == adapterEvent.ID:
+			if len(log.Topics) < 3 || log.Topics[2] != r.ConditionID {
+				continue
+			}
+			values, err := adapterEvent.Inputs.NonIndexed().Unpack(log.Data)
+			if err != nil || len(values) != 2 {
+				continue
+			}
+			total.Add(total, values[1].(*big.Int))
+		}
+	}
+	return total
+}
+
+// conditionPayouts 读取条件的 payoutDenominator 和 payoutNumerators，未结算时分母为0
+func (c *BaseWeb3Client) conditionPayouts(ctx context.Context, conditionID common.Hash) (*big.Int, []*big.Int, error) {
+	var slots *big.Int
+	if err := c.callConditionalTokens(ctx, &slots, "getOutcomeSlotCount", conditionID); err != nil {
+		return nil, nil, err
+	}
+	if slots.Sign() == 0 {
+		return nil, nil, fmt.Errorf("condition %s is not prepared", conditionID.Hex())
+	}
+
+	var denominator *big.Int
+	if err := c.callConditionalTokens(ctx, &denominator, "payoutDenominator", conditionID); err != nil {
+		return nil, nil, err
+	}
+
+	numerators := make([]*big.Int, slots.Int64())
+	for i := range numerators {
+		numerators[i] = new(big.Int)
+		if denominator.Sign() == 0 {
+			continue
+		}
+		if err := c.callConditionalTokens(ctx, &numerators[i], "payoutNumerators", conditionID, big.NewInt(int64(i))); err != nil {
+			return nil, nil, err
+		}
+	}
+	return denominator, numerators, nil
+}
+
+// balancesOf 通过 balanceOfBatch 读取 owner 的多个条件代币余额，按 token ID 字符串索引
+func (c *BaseWeb3Client) balancesOf(ctx context.Context, owner common.Address, tokenIDs []*big.Int) (map[string]*big.Int, error) {
+	const chunk = 200
+	balances := make(map[string]*big.Int, len(tokenIDs))
+	for start := 0; start < len(tokenIDs); start += chunk {
+		ids := tokenIDs[start:min(start+chunk, len(tokenIDs))]
+		owners := make([]common.Address, len(ids))
+		for i := range owners {
+			owners[i] = owner
+		}
+		var values []*big.Int
+		if err := c.callConditionalTokens(ctx, &values, "balanceOfBatch", owners, ids); err != nil {
+			return nil, err
+		}
+		if len(values) != len(ids) {
+			return nil, fmt.Errorf("balanceOfBatch returned %d balances for %d ids", len(values), len(ids))
+		}
+		for i, id := range ids {
+			balances[id.String()] = values[i]
+		}
+	}
+	return balances, nil
+}
+
+// callConditionalTokens 调用 ConditionalTokens 的只读方法并解码结果
+func (c *BaseWeb3Client) callConditionalTokens(ctx context.Context, out interface{}, method string, args ...interface{}) error {
+	return c.callView(ctx, ConditionalTokensABI, c.ConditionalTokensAddress, out, method, args...)
+}
+
+// callView 调用合约的只读方法并解码结果
+func (c *BaseWeb3Client) callView(ctx context.Context, contractABI abi.ABI, to common.Address, out interface{}, method string, args ...interface{}) error {
+	data, err := contractABI.Pack(method, args...)
+	if err != nil {
+		return fmt.Errorf("failed to pack %s: %w", method, err)
+	}
+	result, err := c.client.CallContract(ctx, ethereum.CallMsg{
+		To:   &to,
+		Data: data,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	if err := contractABI.UnpackIntoInterface(out, method, result); err != nil {
+		return fmt.Errorf("failed to unpack %s: %w", method, err)
+	}
+	return nil
+}
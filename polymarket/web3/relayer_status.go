@@ -0,0 +1,222 @@
+package web3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/0xNetuser/Polymarket-golang/polymarket"
+)
+
+// RelayerState 中继交易状态
+type RelayerState string
+
+const (
+	RelayerStateNew       RelayerState = "STATE_NEW"       // 已接收，等待执行
+	RelayerStateExecuted  RelayerState = "STATE_EXECUTED"  // 已广播上链
+	RelayerStateMined     RelayerState = "STATE_MINED"     // 已打包
+	RelayerStateConfirmed RelayerState = "STATE_CONFIRMED" // 已确认（最终状态）
+	RelayerStateFailed    RelayerState = "STATE_FAILED"    // 执行失败（最终状态）
+	RelayerStateInvalid   RelayerState = "STATE_INVALID"   // 请求无效，被拒绝（最终状态）
+)
+
+// IsFinal 是否为最终状态
+func (s RelayerState) IsFinal() bool {
+	return s == RelayerStateConfirmed || s.IsFailure()
+}
+
+// IsSuccess 交易是否已上链（已打包或已确认）
+func (s RelayerState) IsSuccess() bool {
+	return s == RelayerStateMined || s == RelayerStateConfirmed
+}
+
+// IsFailure 交易是否失败
+func (s RelayerState) IsFailure() bool {
+	return s == RelayerStateFailed || s == RelayerStateInvalid
+}
+
+// ErrRelayerTxFailed 中继交易失败（STATE_FAILED 或 STATE_INVALID）
+var ErrRelayerTxFailed = errors.New("relayer transaction failed")
+
+// RelayerTxError 中继交易进入失败状态，匹配 ErrRelayerTxFailed
+type RelayerTxError struct {
+	TransactionID   string
+	TransactionHash string
+	State           RelayerState
+}
+
+// Error 实现 error 接口
+func (e *RelayerTxError) Error() string {
+	return fmt.Sprintf("relayer transaction %s %s (hash %q)", e.TransactionID, e.State, e.TransactionHash)
+}
+
+// Is 匹配 ErrRelayerTxFailed
+func (e *RelayerTxError) Is(target error) bool {
+	return target == ErrRelayerTxFailed
+}
+
+// RelayerTransaction 中继器记录的交易
+type RelayerTransaction struct {
+	TransactionID   string       `json:"transactionID"`
+	TransactionHash string       `json:"transactionHash"`
+	State           RelayerState `json:"state"`
+	Type            string       `json:"type"` // "PROXY" 或 "SAFE"
+	From            string       `json:"from"`
+	To              string       `json:"to"`
+	ProxyAddress    string       `json:"proxyAddress"`
+	Data            string       `json:"data"`
+	Nonce           string       `json:"nonce"`
+	Value           string       `json:"value"`
+	Signature       string       `json:"signature"`
+	Owner           string       `json:"owner"`
+	Metadata        string       `json:"metadata"`
+	CreatedAt       string       `json:"createdAt"`
+	UpdatedAt       string       `json:"updatedAt"`
+}
+
+// GetRelayerTransaction 按ID查询中继交易状态
+func (c *PolymarketGaslessWeb3Client) GetRelayerTransaction(ctx context.Context, transactionID string) (*RelayerTransaction, error) {
+	if transactionID == "" {
+		return nil, fmt.Errorf("transaction ID is required")
+	}
+	reqURL := fmt.Sprintf("%s/transaction?id=%s", c.relayConfig.RelayURL, url.QueryEscape(transactionID))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relayer transaction: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read relayer transaction: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, polymarket.NewAPIError("GET", "/transaction", resp.StatusCode, body)
+	}
+
+	// 中继器返回数组，兼容直接返回对象
+	var txs []RelayerTransaction
+	if err := json.Unmarshal(body, &txs); err != nil {
+		var tx RelayerTransaction
+		if err := json.Unmarshal(body, &tx); err != nil {
+			return nil, fmt.Errorf("failed to decode relayer transaction: %w", err)
+		}
+		txs = []RelayerTransaction{tx}
+	}
+	if len(txs) == 0 || txs[0].State == "" {
+		return nil, fmt.Errorf("%w: relayer transaction %s", polymarket.ErrNotFound, transactionID)
+	}
+	return &txs[0], nil
+}
+
+// RelayerTxHandle 已提交的中继交易，可以稍后查询状态或等待完成
+type RelayerTxHandle struct {
+	client *PolymarketGaslessWeb3Client
+
+	TransactionID   string       // 中继交易ID
+	TransactionHash string       // 提交时返回的交易哈希（中继器可能在之后才分配或替换）
+	State           RelayerState // 提交时的状态
+}
+
+// Status 查询当前状态，并更新 TransactionHash 和 State
+func (h *RelayerTxHandle) Status(ctx context.Context) (*RelayerTransaction, error) {
+	tx, err := h.client.GetRelayerTransaction(ctx, h.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+	if tx.TransactionHash != "" {
+		h.TransactionHash = tx.TransactionHash
+	}
+	h.State = tx.State
+	return tx, nil
+}
+
+// Wait 轮询中继器直到交易上链，再等待链上回执（使用客户端的 ReceiptWaitConfig）
+// 失败状态返回 *RelayerTxError；超时返回匹配 ErrReceiptTimeout 的错误；回执 Status 为0时仍返回回执
+func (h *RelayerTxHandle) Wait(ctx context.Context) (*TransactionReceipt, error) {
+	cfg := h.client.receiptConfig.withDefaults()
+	if h.TransactionID == "" {
+		// 中继器只返回了交易哈希，直接等待回执
+		return h.client.waitForReceipt(ctx, common.HexToHash(h.TransactionHash))
+	}
+
+	start := time.Now()
+	var deadline time.Time
+	if cfg.Timeout > 0 {
+		deadline = start.Add(cfg.Timeout)
+	}
+
+	var lastErr error
+	for {
+		tx, err := h.Status(ctx)
+		switch {
+		case err == nil:
+			if tx.State.IsFailure() {
+				return nil, &RelayerTxError{TransactionID: h.TransactionID, TransactionHash: h.TransactionHash, State: tx.State}
+			}
+			if tx.State.IsSuccess() && h.TransactionHash != "" {
+				return h.waitForReceipt(ctx, cfg, deadline)
+			}
+		case ctx.Err() != nil:
+			return nil, ctx.Err()
+		case polymarket.IsRetryable(err) || !isAPIError(err):
+			// 中继器临时错误或网络错误，继续轮询
+			lastErr = err
+		default:
+			return nil, err
+		}
+
+		wait := cfg.PollInterval
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				err := fmt.Errorf("%w: relayer transaction %s still %s after %s", ErrReceiptTimeout, h.TransactionID, h.State, time.Since(start).Round(time.Second))
+				if lastErr != nil {
+					err = fmt.Errorf("%w (last error: %v)", err, lastErr)
+				}
+				return nil, err
+			}
+			if remaining < wait {
+				wait = remaining
+			}
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// waitForReceipt 在剩余的超时时间内等待链上回执
+func (h *RelayerTxHandle) waitForReceipt(ctx context.Context, cfg ReceiptWaitConfig, deadline time.Time) (*TransactionReceipt, error) {
+	if !deadline.IsZero() {
+		cfg.Timeout = max(time.Until(deadline), time.Nanosecond)
+	}
+	receipt, err := NewReceiptWaiter(h.client.client, cfg).Wait(ctx, common.HexToHash(h.TransactionHash))
+	if err != nil {
+		return nil, err
+	}
+	return FromEthReceipt(receipt, h.client.account), nil
+}
+
+// isAPIError 是否为中继器返回的HTTP错误
+func isAPIError(err error) bool {
+	var apiErr *polymarket.APIError
+	return errors.As(err, &apiErr)
+}
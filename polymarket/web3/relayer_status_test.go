@@ -0,0 +1,259 @@
+package web3
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/0xNetuser/Polymarket-golang/polymarket"
+)
+
+// relayerReply 中继器对一次 /transaction 请求的响应
+type relayerReply struct {
+	status int
+	body   string
+}
+
+// relayerStub 按顺序返回预设响应的中继器，最后一个响应重复使用
+type relayerStub struct {
+	mu      sync.Mutex
+	replies []relayerReply
+	calls   int
+	lastID  string
+}
+
+func (s *relayerStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/transaction" {
+		http.NotFound(w, r)
+		return
+	}
+	s.mu.Lock()
+	reply := s.replies[min(s.calls, len(s.replies)-1)]
+	s.calls++
+	s.lastID = r.URL.Query().Get("id")
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(reply.status)
+	w.Write([]byte(reply.body))
+}
+
+func (s *relayerStub) callCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+// receiptNode 只实现 eth_getTransactionReceipt 的节点
+type receiptNode struct {
+	receipt *types.Receipt
+}
+
+func (n *receiptNode) GetTransactionReceipt(hash common.Hash) (*types.Receipt, error) {
+	if n.receipt == nil || n.receipt.TxHash != hash {
+		return nil, nil
+	}
+	return n.receipt, nil
+}
+
+// newRelayerTestClient 创建只访问本地中继器和节点的无gas客户端
+func newRelayerTestClient(t *testing.T, stub *relayerStub, node *receiptNode, timeout time.Duration) *PolymarketGaslessWeb3Client {
+	t.Helper()
+	relayer := httptest.NewServer(stub)
+	t.Cleanup(relayer.Close)
+
+	server := rpc.NewServer()
+	if node == nil {
+		node = &receiptNode{}
+	}
+	if err := server.RegisterName("eth", node); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Stop)
+	eth := ethclient.NewClient(rpc.DialInProc(server))
+	t.Cleanup(eth.Close)
+
+	return &PolymarketGaslessWeb3Client{
+		BaseWeb3Client: &BaseWeb3Client{
+			client:        eth,
+			receiptConfig: ReceiptWaitConfig{PollInterval: 5 * time.Millisecond, Timeout: timeout},
+		},
+		relayConfig: RelayConfig{RelayURL: relayer.URL},
+		httpClient:  relayer.Client(),
+	}
+}
+
+func TestGetRelayerTransaction(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"array", `[{"transactionID":"tx-1","transactionHash":"0xabc","state":"STATE_MINED","type":"SAFE"}]`},
+		{"object", `{"transactionID":"tx-1","transactionHash":"0xabc","state":"STATE_MINED","type":"SAFE"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &relayerStub{replies: []relayerReply{{http.StatusOK, tt.body}}}
+			client := newRelayerTestClient(t, stub, nil, time.Second)
+			tx, err := client.GetRelayerTransaction(context.Background(), "tx-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tx.TransactionID != "tx-1" || tx.TransactionHash != "0xabc" || tx.State != RelayerStateMined || tx.Type != "SAFE" {
+				t.Errorf("tx = %+v", tx)
+			}
+			if stub.lastID != "tx-1" {
+				t.Errorf("query id = %q, want tx-1", stub.lastID)
+			}
+		})
+	}
+}
+
+func TestGetRelayerTransactionErrors(t *testing.T) {
+	stub := &relayerStub{replies: []relayerReply{{http.StatusOK, `[]`}}}
+	client := newRelayerTestClient(t, stub, nil, time.Second)
+	if _, err := client.GetRelayerTransaction(context.Background(), "tx-1"); !errors.Is(err, polymarket.ErrNotFound) {
+		t.Errorf("empty array: err = %v, want ErrNotFound", err)
+	}
+
+	stub = &relayerStub{replies: []relayerReply{{http.StatusBadRequest, `{"error":"bad id"}`}}}
+	client = newRelayerTestClient(t, stub, nil, time.Second)
+	var apiErr *polymarket.APIError
+	if _, err := client.GetRelayerTransaction(context.Background(), "tx-1"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("400: err = %v, want *APIError", err)
+	}
+}
+
+func TestRelayerTxHandleWaitFailureStates(t *testing.T) {
+	for _, state := range []RelayerState{RelayerStateFailed, RelayerStateInvalid} {
+		t.Run(string(state), func(t *testing.T) {
+			stub := &relayerStub{replies: []relayerReply{
+				{http.StatusOK, `[{"transactionID":"tx-1","state":"STATE_NEW"}]`},
+				{http.StatusOK, `[{"transactionID":"tx-1","transactionHash":"0xdef","state":"` + string(state) + `"}]`},
+			}}
+			client := newRelayerTestClient(t, stub, nil, time.Second)
+			handle := &RelayerTxHandle{client: client, TransactionID: "tx-1", State: RelayerStateNew}
+
+			_, err := handle.Wait(context.Background())
+			var txErr *RelayerTxError
+			if !errors.As(err, &txErr) {
+				t.Fatalf("Wait = %v, want *RelayerTxError", err)
+			}
+			if !errors.Is(err, ErrRelayerTxFailed) {
+				t.Errorf("errors.Is(err, ErrRelayerTxFailed) = false")
+			}
+			if txErr.State != state || txErr.TransactionID != "tx-1" || txErr.TransactionHash != "0xdef" {
+				t.Errorf("RelayerTxError = %+v", txErr)
+			}
+			if handle.State != state {
+				t.Errorf("handle.State = %s, want %s", handle.State, state)
+			}
+		})
+	}
+}
+
+func TestRelayerTxHandleWaitPollsThroughTransientErrors(t *testing.T) {
+	stub := &relayerStub{replies: []relayerReply{
+		{http.StatusServiceUnavailable, `{"error":"unavailable"}`},
+		{http.StatusBadGateway, `bad gateway`},
+		{http.StatusOK, `[{"transactionID":"tx-1","state":"STATE_EXECUTED"}]`},
+		{http.StatusInternalServerError, `{"error":"internal"}`},
+		{http.StatusOK, `[{"transactionID":"tx-1","state":"STATE_FAILED"}]`},
+	}}
+	client := newRelayerTestClient(t, stub, nil, time.Second)
+	handle := &RelayerTxHandle{client: client, TransactionID: "tx-1"}
+
+	if _, err := handle.Wait(context.Background()); !errors.Is(err, ErrRelayerTxFailed) {
+		t.Fatalf("Wait = %v, want ErrRelayerTxFailed", err)
+	}
+	if got := stub.callCount(); got != 5 {
+		t.Errorf("relayer calls = %d, want 5", got)
+	}
+}
+
+func TestRelayerTxHandleWaitStopsOnClientError(t *testing.T) {
+	stub := &relayerStub{replies: []relayerReply{{http.StatusBadRequest, `{"error":"unknown transaction"}`}}}
+	client := newRelayerTestClient(t, stub, nil, time.Second)
+	handle := &RelayerTxHandle{client: client, TransactionID: "tx-1"}
+
+	var apiErr *polymarket.APIError
+	if _, err := handle.Wait(context.Background()); !errors.As(err, &apiErr) {
+		t.Fatalf("Wait = %v, want *APIError", err)
+	}
+	if got := stub.callCount(); got != 1 {
+		t.Errorf("relayer calls = %d, want 1", got)
+	}
+}
+
+func TestRelayerTxHandleWaitTimeout(t *testing.T) {
+	stub := &relayerStub{replies: []relayerReply{
+		{http.StatusOK, `[{"transactionID":"tx-1","state":"STATE_NEW"}]`},
+		{http.StatusServiceUnavailable, `{"error":"unavailable"}`},
+	}}
+	client := newRelayerTestClient(t, stub, nil, 50*time.Millisecond)
+	handle := &RelayerTxHandle{client: client, TransactionID: "tx-1"}
+
+	start := time.Now()
+	_, err := handle.Wait(context.Background())
+	if !errors.Is(err, ErrReceiptTimeout) {
+		t.Fatalf("Wait = %v, want ErrReceiptTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Wait took %s, want about 50ms", elapsed)
+	}
+	if stub.callCount() < 2 {
+		t.Errorf("relayer calls = %d, want polling", stub.callCount())
+	}
+}
+
+func TestRelayerTxHandleWaitContextCanceled(t *testing.T) {
+	stub := &relayerStub{replies: []relayerReply{{http.StatusOK, `[{"transactionID":"tx-1","state":"STATE_NEW"}]`}}}
+	client := newRelayerTestClient(t, stub, nil, -1)
+	handle := &RelayerTxHandle{client: client, TransactionID: "tx-1"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	if _, err := handle.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestRelayerTxHandleWaitMined(t *testing.T) {
+	txHash := common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	node := &receiptNode{receipt: &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		TxHash:      txHash,
+		BlockHash:   common.HexToHash("0x22"),
+		BlockNumber: big.NewInt(100),
+		GasUsed:     21000,
+		Logs:        []*types.Log{},
+	}}
+	stub := &relayerStub{replies: []relayerReply{
+		{http.StatusOK, `[{"transactionID":"tx-1","state":"STATE_NEW"}]`},
+		{http.StatusOK, `[{"transactionID":"tx-1","transactionHash":"` + txHash.Hex() + `","state":"STATE_MINED"}]`},
+	}}
+	client := newRelayerTestClient(t, stub, node, time.Second)
+	client.account = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	handle := &RelayerTxHandle{client: client, TransactionID: "tx-1"}
+
+	receipt, err := handle.Wait(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.TxHash != txHash || receipt.Status != 1 || receipt.BlockNumber != 100 || receipt.From != client.account {
+		t.Errorf("receipt = %+v", receipt)
+	}
+	if handle.TransactionHash != txHash.Hex() || handle.State != RelayerStateMined {
+		t.Errorf("handle = %s %s", handle.TransactionHash, handle.State)
+	}
+}
@@ -8,7 +8,8 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/0xNetuser/Polymarket-golang/polymarket"
 )
 
 // PolymarketWeb3Client Polymarket Web3客户端（支付gas）
@@ -32,18 +33,50 @@ func NewPolymarketWeb3Client(privateKey string, signatureType SignatureType, cha
 	}, nil
 }
 
-// Execute 执行链上交易
-func (c *PolymarketWeb3Client) Execute(to common.Address, data []byte, operationName string) (*TransactionReceipt, error) {
+// NewPolymarketWeb3ClientWithSigner 使用签名后端（如 polymarket.RemoteSigner）创建PolymarketWeb3Client
+// ctx 用于连接节点和查询代理钱包地址
+func NewPolymarketWeb3ClientWithSigner(ctx context.Context, signer polymarket.SignerBackend, signatureType SignatureType, chainID int64, rpcURL string) (*PolymarketWeb3Client, error) {
+	base, err := NewBaseWeb3ClientWithSigner(ctx, signer, signatureType, chainID, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PolymarketWeb3Client{
+		BaseWeb3Client: base,
+	}, nil
+}
+
+// Execute 执行链上交易，并等待交易上链
+// 交易是否执行成功由返回收据的 Status 表示，operationName 用于标注返回的错误
+func (c *PolymarketWeb3Client) Execute(ctx context.Context, to common.Address, data []byte, operationName string) (*TransactionReceipt, error) {
+	if c.signatureType == SignatureTypeEOA {
+		tx, err := c.buildEOATransaction(ctx, to, data)
+		if err != nil {
+			return nil, err
+		}
+		return c.executeTransaction(ctx, tx, operationName)
+	}
+	return c.ExecuteBatch(ctx, []Call{{To: to, Data: data}}, operationName)
+}
+
+// ExecuteBatch 在一笔交易中原子地执行多个调用（如 approve + split，或合并多个条件）
+// Poly 代理钱包通过 ProxyWalletFactory.proxy 执行多个调用；Safe 钱包通过 delegatecall MultiSend 执行。
+// 任一调用失败时整笔交易回滚。EOA 钱包无法原子批量执行
+func (c *PolymarketWeb3Client) ExecuteBatch(ctx context.Context, calls []Call, operationName string) (*TransactionReceipt, error) {
+	if len(calls) == 0 {
+		return nil, fmt.Errorf("no calls to execute")
+	}
+
 	var tx *types.Transaction
 	var err error
 
 	switch c.signatureType {
 	case SignatureTypeEOA:
-		tx, err = c.buildEOATransaction(to, data)
+		return nil, fmt.Errorf("EOA wallets cannot batch calls atomically, use Execute for each call")
 	case SignatureTypePolyProxy:
-		tx, err = c.buildProxyTransaction(to, data)
+		tx, err = c.buildProxyTransaction(ctx, calls)
 	case SignatureTypeSafe:
-		tx, err = c.buildSafeTransaction(to, data)
+		tx, err = c.buildSafeTransaction(ctx, calls)
 	default:
 		return nil, fmt.Errorf("invalid signature_type: %d", c.signatureType)
 	}
@@ -51,17 +84,17 @@ func (c *PolymarketWeb3Client) Execute(to common.Address, data []byte, operation
 		return nil, err
 	}
 
-	return c.executeTransaction(tx, operationName)
+	return c.executeTransaction(ctx, tx, operationName)
 }
 
 // buildEOATransaction 构建EOA钱包交易
-func (c *PolymarketWeb3Client) buildEOATransaction(to common.Address, data []byte) (*types.Transaction, error) {
-	nonce, err := c.client.PendingNonceAt(context.Background(), c.account)
+func (c *PolymarketWeb3Client) buildEOATransaction(ctx context.Context, to common.Address, data []byte) (*types.Transaction, error) {
+	nonce, err := c.client.PendingNonceAt(ctx, c.account)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get nonce: %w", err)
 	}
 
-	gasPrice, err := c.client.SuggestGasPrice(context.Background())
+	gasPrice, err := c.client.SuggestGasPrice(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price: %w", err)
 	}
@@ -69,7 +102,7 @@ func (c *PolymarketWeb3Client) buildEOATransaction(to common.Address, data []byt
 	adjustedGasPrice.Div(adjustedGasPrice, big.NewInt(100))
 
 	// 估算gas
-	gas, err := c.client.EstimateGas(context.Background(), ethereum.CallMsg{
+	gas, err := c.client.EstimateGas(ctx, ethereum.CallMsg{
 		From: c.Address,
 		To:   &to,
 		Data: data,
@@ -88,17 +121,17 @@ func (c *PolymarketWeb3Client) buildEOATransaction(to common.Address, data []byt
 		Data:     data,
 	})
 
-	return types.SignTx(tx, types.NewEIP155Signer(big.NewInt(c.chainID)), c.privateKey)
+	return c.signTx(ctx, tx)
 }
 
 // buildProxyTransaction 构建Poly代理钱包交易
-func (c *PolymarketWeb3Client) buildProxyTransaction(to common.Address, data []byte) (*types.Transaction, error) {
-	nonce, err := c.client.PendingNonceAt(context.Background(), c.account)
+func (c *PolymarketWeb3Client) buildProxyTransaction(ctx context.Context, calls []Call) (*types.Transaction, error) {
+	nonce, err := c.client.PendingNonceAt(ctx, c.account)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get nonce: %w", err)
 	}
 
-	gasPrice, err := c.client.SuggestGasPrice(context.Background())
+	gasPrice, err := c.client.SuggestGasPrice(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price: %w", err)
 	}
@@ -106,29 +139,13 @@ func (c *PolymarketWeb3Client) buildProxyTransaction(to common.Address, data []b
 	adjustedGasPrice.Div(adjustedGasPrice, big.NewInt(100))
 
 	// 编码代理交易 - 使用正确的切片类型
-	calls := []ProxyCall{
-		{
-			TypeCode: 1,
-			To:       to,
-			Value:    big.NewInt(0),
-			Data:     data,
-		},
-	}
-	proxyData, err := ProxyFactoryABI.Pack("proxy", calls)
+	proxyData, err := ProxyFactoryABI.Pack("proxy", proxyCalls(calls))
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode proxy transaction: %w", err)
 	}
 
 	// 估算gas
-	gas, err := c.client.EstimateGas(context.Background(), ethereum.CallMsg{
-		From: c.Address,
-		To:   &to,
-		Data: data,
-	})
-	if err != nil {
-		gas = 500000
-	}
-	gas = uint64(float64(gas)*1.05) + 100000
+	gas := c.estimateBatchGas(ctx, calls, c.ProxyFactoryAddress, proxyData)
 
 	tx := types.NewTx(&types.LegacyTx{
 		Nonce:    nonce,
@@ -139,17 +156,22 @@ func (c *PolymarketWeb3Client) buildProxyTransaction(to common.Address, data []b
 		Data:     proxyData,
 	})
 
-	return types.SignTx(tx, types.NewEIP155Signer(big.NewInt(c.chainID)), c.privateKey)
+	return c.signTx(ctx, tx)
 }
 
 // buildSafeTransaction 构建Safe钱包交易
-func (c *PolymarketWeb3Client) buildSafeTransaction(to common.Address, data []byte) (*types.Transaction, error) {
-	nonce, err := c.client.PendingNonceAt(context.Background(), c.account)
+func (c *PolymarketWeb3Client) buildSafeTransaction(ctx context.Context, calls []Call) (*types.Transaction, error) {
+	call, err := c.safeCallFor(calls)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := c.client.PendingNonceAt(ctx, c.account)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get nonce: %w", err)
 	}
 
-	gasPrice, err := c.client.SuggestGasPrice(context.Background())
+	gasPrice, err := c.client.SuggestGasPrice(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price: %w", err)
 	}
@@ -162,7 +184,7 @@ func (c *PolymarketWeb3Client) buildSafeTransaction(to common.Address, data []by
 		return nil, fmt.Errorf("failed to pack nonce call: %w", err)
 	}
 
-	result, err := c.client.CallContract(context.Background(), ethereum.CallMsg{
+	result, err := c.client.CallContract(ctx, ethereum.CallMsg{
 		To:   &c.Address,
 		Data: safeNonceData,
 	}, nil)
@@ -176,14 +198,13 @@ func (c *PolymarketWeb3Client) buildSafeTransaction(to common.Address, data []by
 	}
 
 	// 获取交易哈希
-	txHash, err := c.getSafeTransactionHash(to, data, safeNonce)
+	txHash, err := c.getSafeTransactionHash(ctx, call, safeNonce)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get safe transaction hash: %w", err)
 	}
 
 	// 签名
-	prefixedHash := crypto.Keccak256(append([]byte("\x19Ethereum Signed Message:\n32"), txHash...))
-	sig, err := crypto.Sign(prefixedHash, c.privateKey)
+	sig, err := c.signMessageHash(ctx, txHash)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign safe transaction: %w", err)
 	}
@@ -195,23 +216,12 @@ func (c *PolymarketWeb3Client) buildSafeTransaction(to common.Address, data []by
 		sig[64] += 4
 	}
 
-	// 估算gas
-	gas, err := c.client.EstimateGas(context.Background(), ethereum.CallMsg{
-		From: c.Address,
-		To:   &to,
-		Data: data,
-	})
-	if err != nil {
-		gas = 500000
-	}
-	gas = uint64(float64(gas)*1.05) + 100000
-
 	// 编码execTransaction调用
 	execData, err := SafeABI.Pack("execTransaction",
-		to,
-		big.NewInt(0),
-		data,
-		uint8(0),
+		call.To,
+		call.Value,
+		call.Data,
+		call.Operation,
 		big.NewInt(0),
 		big.NewInt(0),
 		big.NewInt(0),
@@ -223,6 +233,9 @@ func (c *PolymarketWeb3Client) buildSafeTransaction(to common.Address, data []by
 		return nil, fmt.Errorf("failed to encode execTransaction: %w", err)
 	}
 
+	// 估算gas
+	gas := c.estimateBatchGas(ctx, calls, c.Address, execData)
+
 	tx := types.NewTx(&types.LegacyTx{
 		Nonce:    nonce,
 		GasPrice: adjustedGasPrice,
@@ -232,16 +245,16 @@ func (c *PolymarketWeb3Client) buildSafeTransaction(to common.Address, data []by
 		Data:     execData,
 	})
 
-	return types.SignTx(tx, types.NewEIP155Signer(big.NewInt(c.chainID)), c.privateKey)
+	return c.signTx(ctx, tx)
 }
 
 // getSafeTransactionHash 获取Safe交易哈希
-func (c *PolymarketWeb3Client) getSafeTransactionHash(to common.Address, data []byte, nonce *big.Int) ([]byte, error) {
+func (c *PolymarketWeb3Client) getSafeTransactionHash(ctx context.Context, call safeCall, nonce *big.Int) ([]byte, error) {
 	txHashData, err := SafeABI.Pack("getTransactionHash",
-		to,
-		big.NewInt(0),
-		data,
-		uint8(0),
+		call.To,
+		call.Value,
+		call.Data,
+		call.Operation,
 		big.NewInt(0),
 		big.NewInt(0),
 		big.NewInt(0),
@@ -253,7 +266,7 @@ func (c *PolymarketWeb3Client) getSafeTransactionHash(to common.Address, data []
 		return nil, err
 	}
 
-	result, err := c.client.CallContract(context.Background(), ethereum.CallMsg{
+	result, err := c.client.CallContract(ctx, ethereum.CallMsg{
 		To:   &c.Address,
 		Data: txHashData,
 	}, nil)
@@ -270,44 +283,31 @@ func (c *PolymarketWeb3Client) getSafeTransactionHash(to common.Address, data []
 }
 
 // executeTransaction 执行交易并等待回执
-func (c *PolymarketWeb3Client) executeTransaction(tx *types.Transaction, operationName string) (*TransactionReceipt, error) {
-	err := c.client.SendTransaction(context.Background(), tx)
+func (c *PolymarketWeb3Client) executeTransaction(ctx context.Context, tx *types.Transaction, operationName string) (*TransactionReceipt, error) {
+	err := c.client.SendTransaction(ctx, tx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send transaction: %w", err)
+		return nil, fmt.Errorf("%s: failed to send transaction: %w", operationName, err)
 	}
 
-	fmt.Printf("Txn hash: %s\n", tx.Hash().Hex())
-
-	receipt, err := c.waitForReceipt(tx.Hash())
+	receipt, err := c.waitForReceipt(ctx, tx.Hash())
 	if err != nil {
-		return nil, fmt.Errorf("failed to wait for receipt: %w", err)
-	}
-
-	if receipt.Status == 1 {
-		fmt.Printf("%s succeeded\n", operationName)
-	} else {
-		fmt.Printf("%s failed\n", operationName)
+		return nil, fmt.Errorf("%s: failed to wait for receipt: %w", operationName, err)
 	}
 
-	gasUsed := new(big.Int).Mul(big.NewInt(int64(receipt.GasUsed)), receipt.EffectiveGasPrice)
-	fmt.Printf("Paid %f POL for gas\n", FromWei(gasUsed, 18))
-
 	return receipt, nil
 }
 
 // waitForReceipt 等待交易回执
-func (c *PolymarketWeb3Client) waitForReceipt(txHash common.Hash) (*TransactionReceipt, error) {
-	for {
-		receipt, err := c.client.TransactionReceipt(context.Background(), txHash)
-		if err == nil {
-			return FromEthReceipt(receipt, c.account), nil
-		}
-		// 继续等待...
+func (c *PolymarketWeb3Client) waitForReceipt(ctx context.Context, txHash common.Hash) (*TransactionReceipt, error) {
+	receipt, err := c.WaitForReceipt(ctx, txHash)
+	if err != nil {
+		return nil, err
 	}
+	return FromEthReceipt(receipt, c.account), nil
 }
 
 // SplitPosition 分割USDC为两个互补头寸
-func (c *PolymarketWeb3Client) SplitPosition(conditionID common.Hash, amount float64, negRisk bool) (*TransactionReceipt, error) {
+func (c *PolymarketWeb3Client) SplitPosition(ctx context.Context, conditionID common.Hash, amount float64, negRisk bool) (*TransactionReceipt, error) {
 	amountInt := ToWei(amount, 6)
 
 	var to common.Address
@@ -325,11 +325,11 @@ func (c *PolymarketWeb3Client) SplitPosition(conditionID common.Hash, amount flo
 		return nil, err
 	}
 
-	return c.Execute(to, data, "Split Position")
+	return c.Execute(ctx, to, data, "Split Position")
 }
 
 // MergePosition 合并两个互补头寸为USDC
-func (c *PolymarketWeb3Client) MergePosition(conditionID common.Hash, amount float64, negRisk bool) (*TransactionReceipt, error) {
+func (c *PolymarketWeb3Client) MergePosition(ctx context.Context, conditionID common.Hash, amount float64, negRisk bool) (*TransactionReceipt, error) {
 	amountInt := ToWei(amount, 6)
 
 	var to common.Address
@@ -347,11 +347,11 @@ func (c *PolymarketWeb3Client) MergePosition(conditionID common.Hash, amount flo
 		return nil, err
 	}
 
-	return c.Execute(to, data, "Merge Position")
+	return c.Execute(ctx, to, data, "Merge Position")
 }
 
 // RedeemPosition 赎回头寸为USDC
-func (c *PolymarketWeb3Client) RedeemPosition(conditionID common.Hash, amounts []float64, negRisk bool) (*TransactionReceipt, error) {
+func (c *PolymarketWeb3Client) RedeemPosition(ctx context.Context, conditionID common.Hash, amounts []float64, negRisk bool) (*TransactionReceipt, error) {
 	var to common.Address
 	var data []byte
 	var err error
@@ -371,11 +371,29 @@ func (c *PolymarketWeb3Client) RedeemPosition(conditionID common.Hash, amounts [
 		return nil, err
 	}
 
-	return c.Execute(to, data, "Redeem Position")
+	return c.Execute(ctx, to, data, "Redeem Position")
+}
+
+// RedeemAll 发现钱包的全部头寸，批量赎回已结算的市场，返回每个市场赎回的USDC
+// 代理/Safe钱包每笔交易批量执行 opts.BatchSize 个赎回；EOA钱包每个市场一笔交易
+// neg risk 市场通过 NegRiskAdapter 赎回，需要先调用 SetAllApprovals
+func (c *PolymarketWeb3Client) RedeemAll(ctx context.Context, opts *RedeemAllOptions) (*RedeemReport, error) {
+	batchSize := 0
+	if opts != nil {
+		batchSize = opts.BatchSize
+	}
+	if c.signatureType == SignatureTypeEOA {
+		return c.redeemAll(ctx, opts, 1, func(ctx context.Context, calls []Call) (*TransactionReceipt, error) {
+			return c.Execute(ctx, calls[0].To, calls[0].Data, "Redeem All")
+		})
+	}
+	return c.redeemAll(ctx, opts, batchSize, func(ctx context.Context, calls []Call) (*TransactionReceipt, error) {
+		return c.ExecuteBatch(ctx, calls, "Redeem All")
+	})
 }
 
 // ConvertPositions 转换NegRisk No头寸为Yes头寸和USDC
-func (c *PolymarketWeb3Client) ConvertPositions(questionIDs []string, amount float64) (*TransactionReceipt, error) {
+func (c *PolymarketWeb3Client) ConvertPositions(ctx context.Context, questionIDs []string, amount float64) (*TransactionReceipt, error) {
 	amountInt := ToWei(amount, 6)
 	negRiskMarketID := common.HexToHash(questionIDs[0][:len(questionIDs[0])-2] + "00")
 	indexSet := big.NewInt(int64(GetIndexSet(questionIDs)))
@@ -386,77 +404,77 @@ func (c *PolymarketWeb3Client) ConvertPositions(questionIDs []string, amount flo
 		return nil, err
 	}
 
-	return c.Execute(to, data, "Convert Positions")
+	return c.Execute(ctx, to, data, "Convert Positions")
 }
 
 // SetCollateralApproval 设置USDC授权
-func (c *PolymarketWeb3Client) SetCollateralApproval(spender common.Address) (*TransactionReceipt, error) {
+func (c *PolymarketWeb3Client) SetCollateralApproval(ctx context.Context, spender common.Address) (*TransactionReceipt, error) {
 	to := c.USDCAddress
 	data, err := USDCABI.Pack("approve", spender, MaxUint256())
 	if err != nil {
 		return nil, err
 	}
-	return c.Execute(to, data, "Collateral Approval")
+	return c.Execute(ctx, to, data, "Collateral Approval")
 }
 
 // SetConditionalTokensApproval 设置条件代币授权
-func (c *PolymarketWeb3Client) SetConditionalTokensApproval(spender common.Address) (*TransactionReceipt, error) {
+func (c *PolymarketWeb3Client) SetConditionalTokensApproval(ctx context.Context, spender common.Address) (*TransactionReceipt, error) {
 	to := c.ConditionalTokensAddress
 	data, err := ConditionalTokensABI.Pack("setApprovalForAll", spender, true)
 	if err != nil {
 		return nil, err
 	}
-	return c.Execute(to, data, "Conditional Tokens Approval")
+	return c.Execute(ctx, to, data, "Conditional Tokens Approval")
 }
 
 // SetAllApprovals 设置所有必要的授权
-func (c *PolymarketWeb3Client) SetAllApprovals() ([]*TransactionReceipt, error) {
+func (c *PolymarketWeb3Client) SetAllApprovals(ctx context.Context) ([]*TransactionReceipt, error) {
 	var receipts []*TransactionReceipt
 
 	fmt.Println("Approving ConditionalTokens as spender on USDC")
-	r, err := c.SetCollateralApproval(c.ConditionalTokensAddress)
+	r, err := c.SetCollateralApproval(ctx, c.ConditionalTokensAddress)
 	if err != nil {
 		return receipts, err
 	}
 	receipts = append(receipts, r)
 
 	fmt.Println("Approving CTFExchange as spender on USDC")
-	r, err = c.SetCollateralApproval(c.ExchangeAddress)
+	r, err = c.SetCollateralApproval(ctx, c.ExchangeAddress)
 	if err != nil {
 		return receipts, err
 	}
 	receipts = append(receipts, r)
 
 	fmt.Println("Approving NegRiskCtfExchange as spender on USDC")
-	r, err = c.SetCollateralApproval(c.NegRiskExchangeAddress)
+	r, err = c.SetCollateralApproval(ctx, c.NegRiskExchangeAddress)
 	if err != nil {
 		return receipts, err
 	}
 	receipts = append(receipts, r)
 
 	fmt.Println("Approving NegRiskAdapter as spender on USDC")
-	r, err = c.SetCollateralApproval(NegRiskAdapterAddress)
+	r, err = c.SetCollateralApproval(ctx, NegRiskAdapterAddress)
 	if err != nil {
 		return receipts, err
 	}
 	receipts = append(receipts, r)
 
 	fmt.Println("Approving CTFExchange as spender on ConditionalTokens")
-	r, err = c.SetConditionalTokensApproval(c.ExchangeAddress)
+	r, err = c.SetConditionalTokensApproval(ctx, c.ExchangeAddress)
 	if err != nil {
 		return receipts, err
 	}
 	receipts = append(receipts, r)
 
 	fmt.Println("Approving NegRiskCtfExchange as spender on ConditionalTokens")
-	r, err = c.SetConditionalTokensApproval(c.NegRiskExchangeAddress)
+	r, err = c.SetConditionalTokensApproval(ctx, c.NegRiskExchangeAddress)
 	if err != nil {
 		return receipts, err
 	}
 	receipts = append(receipts, r)
 
 	fmt.Println("Approving NegRiskAdapter as spender on ConditionalTokens")
-	r, err = c.SetConditionalTokensApproval(NegRiskAdapterAddress)
+	r, err = c.SetConditionalTokensApproval(ctx, NegRiskAdapterAddress)
 	if err != nil {
 		return receipts, err
 	}
@@ -467,8 +485,8 @@ func (c *PolymarketWeb3Client) SetAllApprovals() ([]*TransactionReceipt, error)
 }
 
 // TransferUSDC 转账USDC
-func (c *PolymarketWeb3Client) TransferUSDC(recipient common.Address, amount float64) (*TransactionReceipt, error) {
-	balance, err := c.GetUSDCBalance(common.Address{})
+func (c *PolymarketWeb3Client) TransferUSDC(ctx context.Context, recipient common.Address, amount float64) (*TransactionReceipt, error) {
+	balance, err := c.GetUSDCBalance(ctx, common.Address{})
 	if err != nil {
 		return nil, err
 	}
@@ -483,12 +501,12 @@ func (c *PolymarketWeb3Client) TransferUSDC(recipient common.Address, amount flo
 	if err != nil {
 		return nil, err
 	}
-	return c.Execute(to, data, "USDC Transfer")
+	return c.Execute(ctx, to, data, "USDC Transfer")
 }
 
 // TransferToken 转账条件代币
-func (c *PolymarketWeb3Client) TransferToken(tokenID string, recipient common.Address, amount float64) (*TransactionReceipt, error) {
-	balance, err := c.GetTokenBalance(tokenID, common.Address{})
+func (c *PolymarketWeb3Client) TransferToken(ctx context.Context, tokenID string, recipient common.Address, amount float64) (*TransactionReceipt, error) {
+	balance, err := c.GetTokenBalance(ctx, tokenID, common.Address{})
 	if err != nil {
 		return nil, err
 	}
@@ -506,5 +524,5 @@ func (c *PolymarketWeb3Client) TransferToken(tokenID string, recipient common.Ad
 	if err != nil {
 		return nil, err
 	}
-	return c.Execute(to, data, "Token Transfer")
+	return c.Execute(ctx, to, data, "Token Transfer")
 }
@@ -0,0 +1,320 @@
+package polymarket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// MarketEvent 市场频道事件
+// 具体类型为 *BookEvent、*PriceChangeEvent、*TickSizeChangeEvent、*LastTradePriceEvent 或 *ReconnectEvent
+type MarketEvent interface {
+	isMarketEvent()
+}
+
+// BookEvent 订单簿快照（订阅时和每次成交后推送）
+type BookEvent struct {
+	AssetID   string         `json:"asset_id"`
+	Market    string         `json:"market"`
+	Bids      []OrderSummary `json:"bids"`
+	Asks      []OrderSummary `json:"asks"`
+	Timestamp Timestamp      `json:"timestamp"`
+	Hash      string         `json:"hash"`
+}
+
+// UnmarshalJSON 兼容旧版本的 buys/sells 字段
+func (e *BookEvent) UnmarshalJSON(data []byte) error {
+	type alias BookEvent
+	var raw struct {
+		alias
+		Buys  []OrderSummary `json:"buys"`
+		Sells []OrderSummary `json:"sells"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*e = BookEvent(raw.alias)
+	if e.Bids == nil {
+		e.Bids = raw.Buys
+	}
+	if e.Asks == nil {
+		e.Asks = raw.Sells
+	}
+	return nil
+}
+
+// Summary 转换为 OrderBookSummary（与 GetOrderBook 返回的结构一致）
+func (e *BookEvent) Summary() *OrderBookSummary {
+	timestamp := e.Timestamp.Raw()
+	if timestamp == "" && !e.Timestamp.IsZero() {
+		timestamp = strconv.FormatInt(e.Timestamp.UnixMilli(), 10)
+	}
+	return &OrderBookSummary{
+		Market:    e.Market,
+		AssetID:   e.AssetID,
+		Timestamp: timestamp,
+		Bids:      append([]OrderSummary(nil), e.Bids...),
+		Asks:      append([]OrderSummary(nil), e.Asks...),
+		Hash:      e.Hash,
+	}
+}
+
+// PriceChange 单个价位的变化，Size 为该价位新的总挂单量（0 表示价位被移除）
+type PriceChange struct {
+	AssetID string          `json:"asset_id"`
+	Price   decimal.Decimal `json:"price"`
+	Size    decimal.Decimal `json:"size"`
+	Side    string          `json:"side"` // BUY（买盘）或 SELL（卖盘）
+	Hash    string          `json:"hash"`
+	BestBid decimal.Decimal `json:"best_bid"`
+	BestAsk decimal.Decimal `json:"best_ask"`
+
+	rawPrice, rawSize string // 服务器下发的原始字符串，用于 hash 校验
+}
+
+// UnmarshalJSON 保留 price 和 size 的原始字符串
+func (c *PriceChange) UnmarshalJSON(data []byte) error {
+	type alias PriceChange
+	if err := json.Unmarshal(data, (*alias)(c)); err != nil {
+		return err
+	}
+	var raw struct {
+		Price json.RawMessage `json:"price"`
+		Size  json.RawMessage `json:"size"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	c.rawPrice = strings.Trim(string(raw.Price), `"`)
+	c.rawSize = strings.Trim(string(raw.Size), `"`)
+	return nil
+}
+
+// PriceChangeEvent 订单簿价位变化（挂单、撤单）
+type PriceChangeEvent struct {
+	Market       string        `json:"market"`
+	PriceChanges []PriceChange `json:"price_changes"`
+	Timestamp    Timestamp     `json:"timestamp"`
+}
+
+// UnmarshalJSON 兼容旧版本的单资产 changes 格式
+func (e *PriceChangeEvent) UnmarshalJSON(data []byte) error {
+	type alias PriceChangeEvent
+	var raw struct {
+		alias
+		AssetID string        `json:"asset_id"`
+		Hash    string        `json:"hash"`
+		Changes []PriceChange `json:"changes"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*e = PriceChangeEvent(raw.alias)
+	if e.PriceChanges == nil {
+		for _, change := range raw.Changes {
+			change.AssetID = raw.AssetID
+			change.Hash = raw.Hash
+			e.PriceChanges = append(e.PriceChanges, change)
+		}
+	}
+	return nil
+}
+
+// TickSizeChangeEvent 最小价格变动调整（价格接近0或1时触发）
+type TickSizeChangeEvent struct {
+	AssetID     string    `json:"asset_id"`
+	Market      string    `json:"market"`
+	OldTickSize TickSize  `json:"old_tick_size"`
+	NewTickSize TickSize  `json:"new_tick_size"`
+	Side        string    `json:"side"`
+	Timestamp   Timestamp `json:"timestamp"`
+}
+
+// LastTradePriceEvent 成交
+type LastTradePriceEvent struct {
+	AssetID    string          `json:"asset_id"`
+	Market     string          `json:"market"`
+	Price      decimal.Decimal `json:"price"`
+	Size       decimal.Decimal `json:"size"`
+	Side       string          `json:"side"`
+	FeeRateBps decimal.Decimal `json:"fee_rate_bps"`
+	Timestamp  Timestamp       `json:"timestamp"`
+}
+
+func (*BookEvent) isMarketEvent()           {}
+func (*PriceChangeEvent) isMarketEvent()    {}
+func (*TickSizeChangeEvent) isMarketEvent() {}
+func (*LastTradePriceEvent) isMarketEvent() {}
+func (*ReconnectEvent) isMarketEvent()      {}
+
+// parseMarketEvent 解析一条市场频道消息，未知的事件类型返回 nil
+func parseMarketEvent(data []byte) (MarketEvent, error) {
+	var head struct {
+		EventType string `json:"event_type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, fmt.Errorf("invalid market message: %w", err)
+	}
+
+	var ev MarketEvent
+	switch head.EventType {
+	case "book":
+		ev = &BookEvent{}
+	case "price_change":
+		ev = &PriceChangeEvent{}
+	case "tick_size_change":
+		ev = &TickSizeChangeEvent{}
+	case "last_trade_price":
+		ev = &LastTradePriceEvent{}
+	default:
+		return nil, nil
+	}
+	if err := json.Unmarshal(data, ev); err != nil {
+		return nil, fmt.Errorf("invalid %s event: %w", head.EventType, err)
+	}
+	return ev, nil
+}
+
+// MarketStream CLOB 市场频道客户端
+// 断线后自动重连并重新订阅；事件按接收顺序通过 Events() 发出
+type MarketStream struct {
+	stream *wsStream
+	assets map[string]struct{} // 受 stream.mu 保护
+	events chan MarketEvent
+}
+
+// NewMarketStream 创建市场频道客户端，assetIDs 为初始订阅的 token ID
+func NewMarketStream(cfg StreamConfig, assetIDs ...string) *MarketStream {
+	cfg = cfg.withDefaults(WSMarketURL)
+	m := &MarketStream{
+		assets: make(map[string]struct{}, len(assetIDs)),
+		events: make(chan MarketEvent, cfg.EventBuffer),
+	}
+	for _, id := range assetIDs {
+		m.assets[id] = struct{}{}
+	}
+
+	m.stream = &wsStream{
+		cfg: cfg,
+		subscribeMsg: func() interface{} {
+			return map[string]interface{}{
+				"assets_ids": m.assetIDsLocked(),
+				"type":       "market",
+			}
+		},
+		handle: m.handle,
+		onReconnect: func(ctx context.Context, ev *ReconnectEvent) {
+			m.emit(ctx, ev)
+		},
+	}
+	return m
+}
+
+// Events 事件通道，Run 返回后关闭
+func (m *MarketStream) Events() <-chan MarketEvent {
+	return m.events
+}
+
+// Run 连接并持续接收事件，直到 ctx 取消
+// 只能调用一次
+func (m *MarketStream) Run(ctx context.Context) error {
+	err := m.stream.run(ctx)
+	if !errors.Is(err, ErrStreamStarted) {
+		close(m.events)
+	}
+	return err
+}
+
+// Subscribe 增加订阅的 token ID
+func (m *MarketStream) Subscribe(assetIDs ...string) error {
+	return m.stream.apply(func() interface{} {
+		var added []string
+		for _, id := range assetIDs {
+			if _, ok := m.assets[id]; !ok {
+				m.assets[id] = struct{}{}
+				added = append(added, id)
+			}
+		}
+		if len(added) == 0 {
+			return nil
+		}
+		return map[string]interface{}{
+			"assets_ids": added,
+			"operation":  "subscribe",
+		}
+	})
+}
+
+// Unsubscribe 取消订阅的 token ID
+func (m *MarketStream) Unsubscribe(assetIDs ...string) error {
+	return m.stream.apply(func() interface{} {
+		var removed []string
+		for _, id := range assetIDs {
+			if _, ok := m.assets[id]; ok {
+				delete(m.assets, id)
+				removed = append(removed, id)
+			}
+		}
+		if len(removed) == 0 {
+			return nil
+		}
+		return map[string]interface{}{
+			"assets_ids": removed,
+			"operation":  "unsubscribe",
+		}
+	})
+}
+
+// AssetIDs 当前订阅的 token ID
+func (m *MarketStream) AssetIDs() []string {
+	m.stream.mu.Lock()
+	defer m.stream.mu.Unlock()
+	return m.assetIDsLocked()
+}
+
+func (m *MarketStream) assetIDsLocked() []string {
+	ids := make([]string, 0, len(m.assets))
+	for id := range m.assets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (m *MarketStream) handle(ctx context.Context, data []byte) error {
+	msgs, err := splitMessages(data)
+	if err != nil {
+		return fmt.Errorf("invalid market message: %w", err)
+	}
+	for _, msg := range msgs {
+		ev, err := parseMarketEvent(msg)
+		if err != nil {
+			// 单条消息解析失败不影响同批次的其他事件
+			m.stream.reportError(err)
+			continue
+		}
+		if ev == nil {
+			continue
+		}
+		if err := m.emit(ctx, ev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// emit 发送事件，消费者处理不过来时阻塞（不丢弃事件）
+func (m *MarketStream) emit(ctx context.Context, ev MarketEvent) error {
+	select {
+	case m.events <- ev:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
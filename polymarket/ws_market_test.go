@@ -0,0 +1,312 @@
+package polymarket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsTestServer WebSocket 测试服务器，每个连接通过 conns 交给测试控制
+type wsTestServer struct {
+	*httptest.Server
+	conns  chan *wsTestConn
+	reject atomic.Int32 // 拒绝前 N 次连接
+	pong   bool         // 是否回复 PONG
+}
+
+// wsTestConn 服务器端的一个连接，msgs 为收到的文本消息（不含 PING）
+type wsTestConn struct {
+	conn  *websocket.Conn
+	msgs  chan string
+	pings atomic.Int32
+
+	writeMu sync.Mutex
+}
+
+func newWSTestServer(t *testing.T, pong bool) *wsTestServer {
+	t.Helper()
+	s := &wsTestServer{conns: make(chan *wsTestConn, 8), pong: pong}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.reject.Add(-1) >= 0 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		c := &wsTestConn{conn: conn, msgs: make(chan string, 16)}
+		s.conns <- c
+		defer conn.Close()
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if string(data) == "PING" {
+				c.pings.Add(1)
+				if s.pong {
+					c.write("PONG")
+				}
+				continue
+			}
+			c.msgs <- string(data)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *wsTestServer) url() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+// accept 等待下一个连接
+func (s *wsTestServer) accept(t *testing.T) *wsTestConn {
+	t.Helper()
+	select {
+	case c := <-s.conns:
+		return c
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for connection")
+		return nil
+	}
+}
+
+func (c *wsTestConn) write(msg string) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.conn.WriteMessage(websocket.TextMessage, []byte(msg))
+}
+
+// next 读取客户端发来的下一条 JSON 消息
+func (c *wsTestConn) next(t *testing.T) map[string]interface{} {
+	t.Helper()
+	select {
+	case msg := <-c.msgs:
+		var v map[string]interface{}
+		if err := json.Unmarshal([]byte(msg), &v); err != nil {
+			t.Fatalf("client message %q: %v", msg, err)
+		}
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for client message")
+		return nil
+	}
+}
+
+func bookMessage(assetID string) string {
+	return fmt.Sprintf(`{"event_type":"book","asset_id":%q,"market":"m","bids":[],"asks":[],"timestamp":"1","hash":"h"}`, assetID)
+}
+
+func nextMarketEvent(t *testing.T, m *MarketStream) MarketEvent {
+	t.Helper()
+	select {
+	case ev := <-m.Events():
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+		return nil
+	}
+}
+
+func assetIDs(t *testing.T, msg map[string]interface{}) []string {
+	t.Helper()
+	raw, _ := msg["assets_ids"].([]interface{})
+	ids := make([]string, len(raw))
+	for i, id := range raw {
+		ids[i], _ = id.(string)
+	}
+	return ids
+}
+
+// runMarketStream 在后台运行 m，返回停止函数；停止后检查 Run 的返回值和事件通道已关闭
+func runMarketStream(t *testing.T, m *MarketStream) (stop func()) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- m.Run(ctx) }()
+	return func() {
+		t.Helper()
+		cancel()
+		select {
+		case err := <-errCh:
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("Run = %v, want context.Canceled", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Run did not return after cancel")
+		}
+		for range m.Events() {
+		}
+	}
+}
+
+// waitGoroutines 等待 goroutine 数量回落到 baseline
+func waitGoroutines(t *testing.T, baseline int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > baseline {
+		if time.Now().After(deadline) {
+			buf := make([]byte, 1<<16)
+			t.Fatalf("goroutines = %d, want <= %d\n%s", runtime.NumGoroutine(), baseline, buf[:runtime.Stack(buf, true)])
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestMarketStreamReconnectResubscribes(t *testing.T) {
+	srv := newWSTestServer(t, true)
+	baseline := runtime.NumGoroutine()
+
+	m := NewMarketStream(StreamConfig{URL: srv.url(), ReconnectBackoff: 5 * time.Millisecond}, "a1")
+	stop := runMarketStream(t, m)
+
+	c1 := srv.accept(t)
+	if sub := c1.next(t); sub["type"] != "market" || !reflect.DeepEqual(assetIDs(t, sub), []string{"a1"}) {
+		t.Fatalf("initial subscription = %v", sub)
+	}
+	c1.write(bookMessage("a1"))
+	if ev, ok := nextMarketEvent(t, m).(*BookEvent); !ok || ev.AssetID != "a1" {
+		t.Fatalf("event = %+v, want book for a1", ev)
+	}
+
+	// 运行中增加订阅
+	if err := m.Subscribe("a2"); err != nil {
+		t.Fatal(err)
+	}
+	if sub := c1.next(t); sub["operation"] != "subscribe" || !reflect.DeepEqual(assetIDs(t, sub), []string{"a2"}) {
+		t.Fatalf("incremental subscription = %v", sub)
+	}
+
+	// 服务器断开连接，客户端重连并重新订阅全部 token
+	c1.conn.Close()
+	c2 := srv.accept(t)
+	if sub := c2.next(t); !reflect.DeepEqual(assetIDs(t, sub), []string{"a1", "a2"}) {
+		t.Fatalf("resubscription = %v, want [a1 a2]", sub)
+	}
+	reconnect, ok := nextMarketEvent(t, m).(*ReconnectEvent)
+	if !ok || reconnect.Attempts != 1 || reconnect.Err == nil {
+		t.Fatalf("event = %+v, want ReconnectEvent after one attempt", reconnect)
+	}
+	c2.write(bookMessage("a2"))
+	if ev, ok := nextMarketEvent(t, m).(*BookEvent); !ok || ev.AssetID != "a2" {
+		t.Fatalf("event = %+v, want book for a2", ev)
+	}
+
+	stop()
+	waitGoroutines(t, baseline)
+}
+
+func TestMarketStreamBackoffAfterDialErrors(t *testing.T) {
+	srv := newWSTestServer(t, true)
+	srv.reject.Store(2)
+
+	var dialErrors atomic.Int32
+	m := NewMarketStream(StreamConfig{
+		URL:              srv.url(),
+		ReconnectBackoff: 5 * time.Millisecond,
+		OnError:          func(error) { dialErrors.Add(1) },
+	}, "a1")
+	stop := runMarketStream(t, m)
+	defer stop()
+
+	srv.accept(t).next(t)
+	reconnect, ok := nextMarketEvent(t, m).(*ReconnectEvent)
+	if !ok || reconnect.Attempts != 2 || reconnect.Err == nil {
+		t.Fatalf("event = %+v, want ReconnectEvent after two failed dials", reconnect)
+	}
+	if n := dialErrors.Load(); n != 2 {
+		t.Errorf("OnError called %d times, want 2", n)
+	}
+}
+
+func TestMarketStreamPingKeepsConnectionAlive(t *testing.T) {
+	srv := newWSTestServer(t, true)
+	m := NewMarketStream(StreamConfig{
+		URL:          srv.url(),
+		PingInterval: 10 * time.Millisecond,
+		ReadTimeout:  100 * time.Millisecond,
+	}, "a1")
+	stop := runMarketStream(t, m)
+	defer stop()
+
+	c := srv.accept(t)
+	c.next(t)
+	time.Sleep(300 * time.Millisecond)
+	if c.pings.Load() == 0 {
+		t.Error("no PING sent")
+	}
+	// PONG 刷新读超时，不会重连
+	select {
+	case <-srv.conns:
+		t.Error("reconnected although the server answered PINGs")
+	default:
+	}
+}
+
+func TestMarketStreamReadTimeoutReconnects(t *testing.T) {
+	srv := newWSTestServer(t, false)
+	m := NewMarketStream(StreamConfig{
+		URL:              srv.url(),
+		PingInterval:     time.Hour,
+		ReadTimeout:      30 * time.Millisecond,
+		ReconnectBackoff: 5 * time.Millisecond,
+	}, "a1")
+	stop := runMarketStream(t, m)
+	defer stop()
+
+	srv.accept(t).next(t)
+	// 服务器不发送任何消息，超过 ReadTimeout 后视为断线
+	srv.accept(t).next(t)
+	if _, ok := nextMarketEvent(t, m).(*ReconnectEvent); !ok {
+		t.Fatal("want ReconnectEvent after read timeout")
+	}
+}
+
+func TestMarketStreamEmitBlocks(t *testing.T) {
+	srv := newWSTestServer(t, true)
+	m := NewMarketStream(StreamConfig{URL: srv.url(), EventBuffer: 1}, "a1")
+	stop := runMarketStream(t, m)
+	defer stop()
+
+	c := srv.accept(t)
+	c.next(t)
+	const n = 20
+	for i := range n {
+		c.write(bookMessage(fmt.Sprint(i)))
+	}
+	// 消费者处理缓慢时事件不会被丢弃
+	time.Sleep(50 * time.Millisecond)
+	for i := range n {
+		ev, ok := nextMarketEvent(t, m).(*BookEvent)
+		if !ok || ev.AssetID != fmt.Sprint(i) {
+			t.Fatalf("event %d = %+v, want book for %d", i, ev, i)
+		}
+	}
+}
+
+func TestMarketStreamRunOnce(t *testing.T) {
+	srv := newWSTestServer(t, true)
+	m := NewMarketStream(StreamConfig{URL: srv.url()}, "a1")
+	stop := runMarketStream(t, m)
+	srv.accept(t).next(t)
+
+	if err := m.Run(context.Background()); !errors.Is(err, ErrStreamStarted) {
+		t.Errorf("second Run = %v, want ErrStreamStarted", err)
+	}
+	stop()
+}
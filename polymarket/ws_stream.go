@@ -0,0 +1,243 @@
+package polymarket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebSocket 端点
+const (
+	WSMarketURL = "wss://ws-subscriptions-clob.polymarket.com/ws/market"
+	WSUserURL   = "wss://ws-subscriptions-clob.polymarket.com/ws/user"
+)
+
+// ErrStreamStarted Run 只能调用一次
+var ErrStreamStarted = errors.New("stream already started")
+
+// StreamConfig WebSocket 连接配置，零值字段使用默认值
+type StreamConfig struct {
+	URL                 string            // 端点地址，默认按频道选择 WSMarketURL / WSUserURL
+	Dialer              *websocket.Dialer // 自定义拨号器（代理、TLS），默认 websocket.DefaultDialer
+	PingInterval        time.Duration     // 发送 PING 的间隔，默认10秒
+	ReadTimeout         time.Duration     // 超过该时间未收到任何消息（包括 PONG）视为断线，默认30秒
+	ReconnectBackoff    time.Duration     // 首次重连前的等待时间，默认500毫秒
+	MaxReconnectBackoff time.Duration     // 重连等待时间上限，默认30秒
+	EventBuffer         int               // 事件通道缓冲区大小，默认256
+	OnError             func(error)       // 连接和解析错误回调（可选），错误不会中断 Run
+}
+
+// withDefaults 填充默认值
+func (c StreamConfig) withDefaults(url string) StreamConfig {
+	if c.URL == "" {
+		c.URL = url
+	}
+	if c.Dialer == nil {
+		c.Dialer = websocket.DefaultDialer
+	}
+	if c.PingInterval <= 0 {
+		c.PingInterval = 10 * time.Second
+	}
+	if c.ReadTimeout <= 0 {
+		c.ReadTimeout = 30 * time.Second
+	}
+	if c.ReconnectBackoff <= 0 {
+		c.ReconnectBackoff = 500 * time.Millisecond
+	}
+	if c.MaxReconnectBackoff <= 0 {
+		c.MaxReconnectBackoff = 30 * time.Second
+	}
+	if c.EventBuffer <= 0 {
+		c.EventBuffer = 256
+	}
+	return c
+}
+
+// ReconnectEvent 断线重连并重新订阅成功后发出
+// 断线期间的增量消息可能丢失，需要完整状态的调用方应在收到该事件后重新同步
+type ReconnectEvent struct {
+	Attempts int   // 本次重连尝试的次数
+	Err      error // 导致断线的错误
+}
+
+// wsStream 带自动重连、心跳和重新订阅的 WebSocket 连接
+type wsStream struct {
+	cfg StreamConfig
+
+	subscribeMsg func() interface{}                           // 连接建立后发送的订阅消息（在 mu 内调用）
+	handle       func(ctx context.Context, data []byte) error // 处理一条业务消息
+	onReconnect  func(ctx context.Context, ev *ReconnectEvent)
+
+	mu      sync.Mutex
+	conn    *websocket.Conn
+	started bool
+
+	writeMu sync.Mutex
+}
+
+// run 维持连接直到 ctx 取消
+func (s *wsStream) run(ctx context.Context) error {
+	s.mu.Lock()
+	if s.started {
+		s.mu.Unlock()
+		return ErrStreamStarted
+	}
+	s.started = true
+	s.mu.Unlock()
+
+	backoff := s.cfg.ReconnectBackoff
+	attempts := 0
+	var lastErr error
+	for {
+		connected, err := s.session(ctx, lastErr, attempts)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if connected {
+			// 成功连接过，重置退避
+			backoff = s.cfg.ReconnectBackoff
+			attempts = 0
+		}
+		lastErr = err
+		attempts++
+		s.reportError(fmt.Errorf("websocket disconnected: %w", err))
+
+		// 带抖动的指数退避
+		delay := backoff - time.Duration(rand.Float64()*float64(backoff)/2)
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+		backoff *= 2
+		if backoff > s.cfg.MaxReconnectBackoff {
+			backoff = s.cfg.MaxReconnectBackoff
+		}
+	}
+}
+
+// session 建立一次连接并读取消息直到断开
+// connected 表示是否成功完成了订阅
+func (s *wsStream) session(ctx context.Context, prevErr error, attempts int) (connected bool, err error) {
+	conn, _, err := s.cfg.Dialer.DialContext(ctx, s.cfg.URL, nil)
+	if err != nil {
+		return false, fmt.Errorf("dial %s: %w", s.cfg.URL, err)
+	}
+	defer conn.Close()
+
+	// 持有写锁直到初始订阅发出，保证之后的增量订阅排在其后
+	s.writeMu.Lock()
+	s.mu.Lock()
+	s.conn = conn
+	msg := s.subscribeMsg()
+	s.mu.Unlock()
+	err = conn.WriteJSON(msg)
+	s.writeMu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.conn = nil
+		s.mu.Unlock()
+	}()
+	if err != nil {
+		return false, fmt.Errorf("subscribe: %w", err)
+	}
+
+	if prevErr != nil && s.onReconnect != nil {
+		s.onReconnect(ctx, &ReconnectEvent{Attempts: attempts, Err: prevErr})
+	}
+
+	// ctx 取消或心跳失败时关闭连接，使 ReadMessage 返回
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(s.cfg.PingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				conn.Close()
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := s.writeMessage(conn, websocket.TextMessage, []byte("PING")); err != nil {
+					conn.Close()
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(s.cfg.ReadTimeout)); err != nil {
+			return true, err
+		}
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return true, err
+		}
+
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 || bytes.Equal(data, []byte("PONG")) {
+			continue
+		}
+		if err := s.handle(ctx, data); err != nil {
+			if ctx.Err() != nil {
+				return true, ctx.Err()
+			}
+			s.reportError(err)
+		}
+	}
+}
+
+// apply 在锁内更新订阅状态，已连接时发送 update 返回的增量消息
+// 未连接时只更新状态，重连后由 subscribeMsg 补发
+func (s *wsStream) apply(update func() interface{}) error {
+	s.mu.Lock()
+	msg := update()
+	conn := s.conn
+	s.mu.Unlock()
+	if conn == nil || msg == nil {
+		return nil
+	}
+	return s.writeJSON(conn, msg)
+}
+
+func (s *wsStream) writeJSON(conn *websocket.Conn, msg interface{}) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return conn.WriteJSON(msg)
+}
+
+func (s *wsStream) writeMessage(conn *websocket.Conn, messageType int, data []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return conn.WriteMessage(messageType, data)
+}
+
+func (s *wsStream) reportError(err error) {
+	if s.cfg.OnError != nil && err != nil {
+		s.cfg.OnError(err)
+	}
+}
+
+// splitMessages 服务器可能发送单个事件对象或事件数组
+func splitMessages(data []byte) ([][]byte, error) {
+	if len(data) == 0 || data[0] != '[' {
+		return [][]byte{data}, nil
+	}
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	msgs := make([][]byte, len(raw))
+	for i, r := range raw {
+		msgs[i] = r
+	}
+	return msgs, nil
+}
@@ -0,0 +1,354 @@
+package polymarket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// OrderEventType 用户频道订单事件类型
+type OrderEventType string
+
+const (
+	OrderEventPlacement    OrderEventType = "PLACEMENT"    // 下单
+	OrderEventUpdate       OrderEventType = "UPDATE"       // 部分成交
+	OrderEventCancellation OrderEventType = "CANCELLATION" // 撤单
+)
+
+// UserEvent 用户频道事件
+// 具体类型为 *UserOrderEvent、*UserTradeEvent、*ReconnectEvent 或 *UserBackfillEvent
+type UserEvent interface {
+	isUserEvent()
+}
+
+// UserOrderEvent 订单下单、更新、撤单事件
+type UserOrderEvent struct {
+	OpenOrder
+	Type       OrderEventType `json:"type"`
+	OrderOwner string         `json:"order_owner"`
+	Timestamp  Timestamp      `json:"timestamp"`
+}
+
+// UserTradeEvent 成交状态事件（MATCHED、MINED、CONFIRMED、RETRYING、FAILED）
+type UserTradeEvent struct {
+	Trade
+	TradeOwner string    `json:"trade_owner"`
+	Timestamp  Timestamp `json:"timestamp"`
+}
+
+// UnmarshalJSON 用户频道使用 matchtime 而不是 match_time
+func (e *UserTradeEvent) UnmarshalJSON(data []byte) error {
+	type alias UserTradeEvent
+	var raw struct {
+		alias
+		MatchTime Timestamp `json:"matchtime"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*e = UserTradeEvent(raw.alias)
+	if e.Trade.MatchTime.IsZero() {
+		e.Trade.MatchTime = raw.MatchTime
+	}
+	return nil
+}
+
+// UserBackfillEvent 断线重连后通过 REST 补齐的数据，在 ReconnectEvent 之后异步发出，
+// 期间重连后的实时事件照常推送，可能先于该事件到达。
+// Orders 为订阅市场中当前的挂单快照；Trades 为 Since 到 Until 之间的成交，可能与已收到的事件重复，按 ID 和状态去重即可
+type UserBackfillEvent struct {
+	Since  time.Time
+	Until  time.Time // 重连时间，之后的成交通过实时事件推送
+	Orders []OpenOrder
+	Trades []Trade
+	Err    error // 补齐失败时的错误，此时 Orders/Trades 可能不完整
+}
+
+func (*UserOrderEvent) isUserEvent()    {}
+func (*UserTradeEvent) isUserEvent()    {}
+func (*UserBackfillEvent) isUserEvent() {}
+func (*ReconnectEvent) isUserEvent()    {}
+
+// parseUserEvent 解析一条用户频道消息，未知的事件类型返回 nil
+func parseUserEvent(data []byte) (UserEvent, error) {
+	var head struct {
+		EventType string `json:"event_type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, fmt.Errorf("invalid user message: %w", err)
+	}
+
+	var ev UserEvent
+	switch head.EventType {
+	case "order":
+		ev = &UserOrderEvent{}
+	case "trade":
+		ev = &UserTradeEvent{}
+	default:
+		return nil, nil
+	}
+	if err := json.Unmarshal(data, ev); err != nil {
+		return nil, fmt.Errorf("invalid %s event: %w", head.EventType, err)
+	}
+	return ev, nil
+}
+
+// UserStream CLOB 用户频道客户端（需要L2认证）
+// 推送当前 API key 的订单和成交事件；断线重连后通过 REST 补齐断线期间的数据
+type UserStream struct {
+	client  *ClobClient
+	stream  *wsStream
+	markets map[string]struct{} // 受 stream.mu 保护，为空表示所有市场
+	events  chan UserEvent
+
+	mu             sync.Mutex
+	lastSeen       time.Time          // 最近一次确认连接正常的时间（收到消息或补齐成功），用于补齐
+	pendingSince   time.Time          // 尚未成功补齐的起点，为零表示没有
+	backfillGen    int                // 每次重连递增，旧的补齐结果不再生效
+	cancelBackfill context.CancelFunc // 取消进行中的补齐
+	backfills      sync.WaitGroup
+}
+
+// NewUserStream 创建用户频道客户端，使用 client 的 API 凭证
+// markets 为订阅的 condition ID，为空时接收所有市场的事件
+func NewUserStream(client *ClobClient, cfg StreamConfig, markets ...string) (*UserStream, error) {
+	if err := client.assertLevel2Auth(); err != nil {
+		return nil, err
+	}
+
+	cfg = cfg.withDefaults(WSUserURL)
+	u := &UserStream{
+		client:  client,
+		markets: make(map[string]struct{}, len(markets)),
+		events:  make(chan UserEvent, cfg.EventBuffer),
+	}
+	for _, id := range markets {
+		u.markets[id] = struct{}{}
+	}
+
+	u.stream = &wsStream{
+		cfg:          cfg,
+		subscribeMsg: u.subscribeMsg,
+		handle:       u.handle,
+		onReconnect:  u.recover,
+	}
+	return u, nil
+}
+
+// Events 事件通道，Run 返回后关闭
+func (u *UserStream) Events() <-chan UserEvent {
+	return u.events
+}
+
+// Run 连接并持续接收事件，直到 ctx 取消
+// 只能调用一次
+func (u *UserStream) Run(ctx context.Context) error {
+	u.mu.Lock()
+	if u.lastSeen.IsZero() {
+		u.lastSeen = time.Now()
+	}
+	u.mu.Unlock()
+
+	err := u.stream.run(ctx)
+	if !errors.Is(err, ErrStreamStarted) {
+		// 等待进行中的补齐退出后再关闭事件通道
+		u.backfills.Wait()
+		close(u.events)
+	}
+	return err
+}
+
+// Subscribe 增加订阅的市场（condition ID）
+func (u *UserStream) Subscribe(markets ...string) error {
+	return u.stream.apply(func() interface{} {
+		var added []string
+		for _, id := range markets {
+			if _, ok := u.markets[id]; !ok {
+				u.markets[id] = struct{}{}
+				added = append(added, id)
+			}
+		}
+		if len(added) == 0 {
+			return nil
+		}
+		return map[string]interface{}{
+			"markets":   added,
+			"operation": "subscribe",
+		}
+	})
+}
+
+// Unsubscribe 取消订阅的市场（condition ID）
+func (u *UserStream) Unsubscribe(markets ...string) error {
+	return u.stream.apply(func() interface{} {
+		var removed []string
+		for _, id := range markets {
+			if _, ok := u.markets[id]; ok {
+				delete(u.markets, id)
+				removed = append(removed, id)
+			}
+		}
+		if len(removed) == 0 {
+			return nil
+		}
+		return map[string]interface{}{
+			"markets":   removed,
+			"operation": "unsubscribe",
+		}
+	})
+}
+
+// Markets 当前订阅的市场
+func (u *UserStream) Markets() []string {
+	u.stream.mu.Lock()
+	defer u.stream.mu.Unlock()
+	return u.marketsLocked()
+}
+
+func (u *UserStream) marketsLocked() []string {
+	ids := make([]string, 0, len(u.markets))
+	for id := range u.markets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// subscribeMsg 每次连接时读取最新的凭证
+func (u *UserStream) subscribeMsg() interface{} {
+	creds := u.client.GetCreds()
+	auth := map[string]string{}
+	if creds != nil {
+		auth["apiKey"] = creds.APIKey
+		auth["secret"] = creds.APISecret
+		auth["passphrase"] = creds.APIPassphrase
+	}
+	return map[string]interface{}{
+		"auth":    auth,
+		"markets": u.marketsLocked(),
+		"type":    "user",
+	}
+}
+
+func (u *UserStream) handle(ctx context.Context, data []byte) error {
+	msgs, err := splitMessages(data)
+	if err != nil {
+		return fmt.Errorf("invalid user message: %w", err)
+	}
+	// 任何成功读取的消息都说明此前的事件已送达，不只是订单和成交
+	u.advance(time.Now())
+	for _, msg := range msgs {
+		ev, err := parseUserEvent(msg)
+		if err != nil {
+			u.stream.reportError(err)
+			continue
+		}
+		if ev == nil {
+			continue
+		}
+		if err := u.emit(ctx, ev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recover 重连后发出 ReconnectEvent，并在后台通过 REST 补齐断线期间的挂单和成交
+// 补齐不阻塞读取循环，避免慢速或重试中的 REST 请求导致连接读超时；
+// 新的重连会取消仍在进行的补齐，并从未成功补齐的最早时间点重新开始
+func (u *UserStream) recover(ctx context.Context, ev *ReconnectEvent) {
+	if err := u.emit(ctx, ev); err != nil {
+		return
+	}
+
+	until := time.Now()
+	u.mu.Lock()
+	// 多回退一分钟，覆盖断线前尚未推送的事件和时钟误差
+	since := u.lastSeen.Add(-time.Minute)
+	if !u.pendingSince.IsZero() && u.pendingSince.Before(since) {
+		since = u.pendingSince
+	}
+	u.pendingSince = since
+	if u.cancelBackfill != nil {
+		u.cancelBackfill()
+	}
+	u.backfillGen++
+	gen := u.backfillGen
+	bctx, cancel := context.WithCancel(ctx)
+	u.cancelBackfill = cancel
+	u.backfills.Add(1)
+	u.mu.Unlock()
+
+	markets := u.Markets()
+	go func() {
+		defer u.backfills.Done()
+		defer cancel()
+
+		backfill := u.backfill(bctx, since, until, markets)
+		if bctx.Err() != nil {
+			// 被新的重连取消或 Run 已停止，pendingSince 保留给下一次补齐
+			return
+		}
+		if err := u.emit(bctx, backfill); err != nil || backfill.Err != nil {
+			return
+		}
+
+		u.mu.Lock()
+		if u.backfillGen == gen {
+			u.pendingSince = time.Time{}
+		}
+		u.mu.Unlock()
+		// 补齐成功后，下次断线只需从本次重连的时间点查询
+		u.advance(until)
+	}()
+}
+
+// advance 将 lastSeen 推进到 t，不会回退
+func (u *UserStream) advance(t time.Time) {
+	u.mu.Lock()
+	if t.After(u.lastSeen) {
+		u.lastSeen = t
+	}
+	u.mu.Unlock()
+}
+
+// backfill 查询订阅市场的挂单和 since 到 until 之间的成交
+func (u *UserStream) backfill(ctx context.Context, since, until time.Time, markets []string) *UserBackfillEvent {
+	ev := &UserBackfillEvent{Since: since, Until: until}
+	if len(markets) == 0 {
+		// 订阅了所有市场
+		markets = []string{""}
+	}
+
+	for _, market := range markets {
+		orders, err := u.client.GetOrders(ctx, &OpenOrderParams{Market: market}, "")
+		if err != nil {
+			ev.Err = fmt.Errorf("backfill orders: %w", err)
+			return ev
+		}
+		ev.Orders = append(ev.Orders, orders...)
+
+		// GetTrades 会读取所有分页；until 之后的成交已通过实时事件推送，多查一秒覆盖同一秒内的成交
+		trades, err := u.client.GetTrades(ctx, &TradeParams{Market: market, After: int(since.Unix()), Before: int(until.Unix()) + 1}, "")
+		if err != nil {
+			ev.Err = fmt.Errorf("backfill trades: %w", err)
+			return ev
+		}
+		ev.Trades = append(ev.Trades, trades...)
+	}
+	return ev
+}
+
+// emit 发送事件，消费者处理不过来时阻塞（不丢弃事件）
+func (u *UserStream) emit(ctx context.Context, ev UserEvent) error {
+	select {
+	case u.events <- ev:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,208 @@
+package polymarket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// backfillServer 返回空挂单和两页成交，记录成交查询的 after / before 参数
+// block 不为空时挂单请求阻塞到 block 关闭或请求取消
+type backfillServer struct {
+	fail  bool
+	block chan struct{}
+
+	mu      sync.Mutex
+	afters  []string
+	befores []string
+}
+
+func (s *backfillServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if s.block != nil && r.URL.Path == Orders {
+		select {
+		case <-s.block:
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if s.fail {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"bad request"}`))
+		return
+	}
+	switch r.URL.Path {
+	case Orders:
+		json.NewEncoder(w).Encode(Page[OpenOrder]{NextCursor: EndCursor})
+	case Trades:
+		s.mu.Lock()
+		s.afters = append(s.afters, r.URL.Query().Get("after"))
+		s.befores = append(s.befores, r.URL.Query().Get("before"))
+		s.mu.Unlock()
+		if r.URL.Query().Get("next_cursor") == "MA==" {
+			json.NewEncoder(w).Encode(Page[Trade]{Data: []Trade{{ID: "t1"}}, NextCursor: "MQ=="})
+		} else {
+			json.NewEncoder(w).Encode(Page[Trade]{Data: []Trade{{ID: "t2"}}, NextCursor: EndCursor})
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestUserStream(t *testing.T, handler http.Handler) *UserStream {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client, err := NewClobClientWithOptions(server.URL, 137, testPrivateKey, WithRetryPolicy(NoRetryPolicy()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds := testOldCreds
+	client.SetAPICreds(&creds)
+	u, err := NewUserStream(client, StreamConfig{EventBuffer: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+func TestUserStreamLastSeenAdvancesOnAnyMessage(t *testing.T) {
+	u := newTestUserStream(t, &backfillServer{})
+	old := time.Now().Add(-time.Hour)
+	u.lastSeen = old
+
+	// 非订单/成交消息同样说明连接正常
+	if err := u.handle(context.Background(), []byte(`{"event_type":"unknown"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if !u.lastSeen.After(old) {
+		t.Errorf("lastSeen = %v, want after %v", u.lastSeen, old)
+	}
+}
+
+func TestUserStreamRecoverPagesTrades(t *testing.T) {
+	srv := &backfillServer{}
+	u := newTestUserStream(t, srv)
+	old := time.Now().Add(-time.Hour)
+	u.lastSeen = old
+
+	ctx := context.Background()
+	start := time.Now()
+	u.recover(ctx, &ReconnectEvent{Attempts: 1})
+	end := time.Now()
+	if _, ok := (<-u.events).(*ReconnectEvent); !ok {
+		t.Fatal("first event is not ReconnectEvent")
+	}
+	ev, ok := (<-u.events).(*UserBackfillEvent)
+	if !ok {
+		t.Fatal("second event is not UserBackfillEvent")
+	}
+	if ev.Err != nil {
+		t.Fatal(ev.Err)
+	}
+	if len(ev.Trades) != 2 || ev.Trades[0].ID != "t1" || ev.Trades[1].ID != "t2" {
+		t.Errorf("trades = %+v, want both pages", ev.Trades)
+	}
+	wantAfter := strconv.FormatInt(old.Add(-time.Minute).Unix(), 10)
+	for i, after := range srv.afters {
+		if after != wantAfter {
+			t.Errorf("after = %s, want %s", after, wantAfter)
+		}
+		// 只查询到重连时间，之后的成交通过实时事件推送
+		before, _ := strconv.ParseInt(srv.befores[i], 10, 64)
+		if before < start.Unix() || before > end.Unix()+1 {
+			t.Errorf("before = %d, want reconnect time %d..%d", before, start.Unix(), end.Unix()+1)
+		}
+	}
+
+	// 补齐成功后从本次重连的时间点继续
+	u.backfills.Wait()
+	if u.lastSeen.Before(start) || !u.pendingSince.IsZero() {
+		t.Errorf("lastSeen = %v, pendingSince = %v, want lastSeen at least %v and no pending backfill", u.lastSeen, u.pendingSince, start)
+	}
+}
+
+func TestUserStreamRecoverFailureKeepsLastSeen(t *testing.T) {
+	u := newTestUserStream(t, &backfillServer{fail: true})
+	old := time.Now().Add(-time.Hour)
+	u.lastSeen = old
+
+	u.recover(context.Background(), &ReconnectEvent{Attempts: 1})
+	<-u.events
+	if ev := (<-u.events).(*UserBackfillEvent); ev.Err == nil {
+		t.Fatal("backfill against failing server: want error")
+	}
+	u.backfills.Wait()
+	if !u.lastSeen.Equal(old) {
+		t.Errorf("lastSeen = %v, want unchanged %v", u.lastSeen, old)
+	}
+	if want := old.Add(-time.Minute); !u.pendingSince.Equal(want) {
+		t.Errorf("pendingSince = %v, want %v", u.pendingSince, want)
+	}
+}
+
+func TestUserStreamRecoverDoesNotBlock(t *testing.T) {
+	srv := &backfillServer{block: make(chan struct{})}
+	u := newTestUserStream(t, srv)
+	old := time.Now().Add(-time.Hour)
+	u.lastSeen = old
+	ctx := context.Background()
+
+	// 补齐请求阻塞时 recover 立即返回，读取循环可以继续
+	done := make(chan struct{})
+	go func() {
+		u.recover(ctx, &ReconnectEvent{Attempts: 1})
+		close(done)
+	}()
+	<-u.events
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("recover blocked on the REST backfill")
+	}
+
+	// 补齐完成前收到实时消息，lastSeen 前进；再次断线重连时仍从未补齐的起点开始
+	if err := u.handle(ctx, []byte(`{"event_type":"unknown"}`)); err != nil {
+		t.Fatal(err)
+	}
+	u.recover(ctx, &ReconnectEvent{Attempts: 1})
+	<-u.events
+	close(srv.block)
+
+	ev := (<-u.events).(*UserBackfillEvent)
+	if ev.Err != nil {
+		t.Fatal(ev.Err)
+	}
+	if want := old.Add(-time.Minute); !ev.Since.Equal(want) {
+		t.Errorf("Since = %v, want %v from the cancelled backfill", ev.Since, want)
+	}
+	u.backfills.Wait()
+	// 被取消的第一次补齐不发出事件
+	select {
+	case extra := <-u.events:
+		t.Errorf("unexpected event %T from cancelled backfill", extra)
+	default:
+	}
+}
+
+func TestUserStreamBackfillStopsOnCancel(t *testing.T) {
+	srv := &backfillServer{block: make(chan struct{})}
+	u := newTestUserStream(t, srv)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Run 停止时取消进行中的补齐，补齐退出后才关闭事件通道
+	u.recover(ctx, &ReconnectEvent{Attempts: 1})
+	<-u.events
+	cancel()
+	u.backfills.Wait()
+	select {
+	case ev := <-u.events:
+		t.Errorf("unexpected event %T after cancel", ev)
+	default:
+	}
+}